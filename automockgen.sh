@@ -4,7 +4,11 @@ files=(
     "svc" "avchar.go"
     "svc" "field.go"
     "svc" "game.go"
+    "svc" "gameword.go"
     "svc" "player.go"
+    "svc" "play.go"
+    "svc" "user.go"
+    "svc" "session.go"
     "ctrl" "game.go"
     "ctrl" "words.go"
 )
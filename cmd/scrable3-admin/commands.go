@@ -0,0 +1,324 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"scrable3/internal/cfg"
+	"scrable3/internal/lang"
+	"scrable3/internal/model"
+	"scrable3/internal/renderer"
+	"scrable3/internal/repo"
+	"scrable3/internal/svc"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type admin struct {
+	config     *cfg.Config
+	repository repo.Repository
+	out        io.Writer
+	in         io.Reader
+}
+
+// Reads the single UUID argument left after the flags
+func (a *admin) uuidArg(fs *flag.FlagSet, what string) (uuid.UUID, error) {
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return uuid.Nil, fmt.Errorf("expected %v uuid", what)
+	}
+	id, err := uuid.Parse(fs.Arg(0))
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%v uuid: %w", what, err)
+	}
+	return id, nil
+}
+
+func (a *admin) game(gameUUID uuid.UUID) (*model.Game, error) {
+	game, err := a.repository.SelectGameByUUID(gameUUID)
+	if errors.Is(err, repo.ErrNotExists) {
+		return nil, fmt.Errorf("game %v not found", gameUUID)
+	}
+	return game, err
+}
+
+func (a *admin) tabWriter() *tabwriter.Writer {
+	return tabwriter.NewWriter(a.out, 0, 0, 2, ' ', 0)
+}
+
+func gameStatus(game *model.Game) string {
+	switch {
+	case game.ForfeitPlayerUUID.Valid:
+		return "forfeited"
+	case game.IsFinished():
+		return "finished"
+	case !game.TurnPlayerUUID.Valid:
+		return "waiting"
+	default:
+		return "playing"
+	}
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format(time.DateTime)
+}
+
+func rackText(avChars []model.AvChar) string {
+	values := make([]string, 0, len(avChars))
+	for _, avChar := range avChars {
+		values = append(values, avChar.Value)
+	}
+	return strings.Join(values, " ")
+}
+
+func listGames(a *admin, fs *flag.FlagSet, args []string) error {
+	unfinished := fs.Bool("unfinished", false, "list only games that are not finished")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var games *[]model.Game
+	var err error
+	if *unfinished {
+		games, err = a.repository.SelectUnfinishedGames()
+	} else {
+		games, err = a.repository.SelectGames()
+	}
+	if err != nil {
+		return err
+	}
+
+	tw := a.tabWriter()
+	fmt.Fprintln(tw, "UUID\tLANGUAGE\tSIZE\tTURN\tPLAYERS\tSTATUS\tCREATED\tUPDATED")
+	for _, game := range *games {
+		players, err := a.repository.SelectPlayersByGameID(game.UUID)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
+			game.UUID, game.Rules.Language, game.Rules.BoardSize, game.Turn,
+			len(*players), gameStatus(&game),
+			formatDate(game.CreateDate), formatDate(game.UpdateDate),
+		)
+	}
+	return tw.Flush()
+}
+
+func showBoard(a *admin, fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	gameUUID, err := a.uuidArg(fs, "game")
+	if err != nil {
+		return err
+	}
+	game, err := a.game(gameUUID)
+	if err != nil {
+		return err
+	}
+	fields, err := a.repository.SelectFieldsByGameID(gameUUID)
+	if err != nil {
+		return err
+	}
+	text, err := renderer.NewText(game.Rules.BoardSize, *fields)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.out, "game %v, %v fields on a board of %v\n\n%v",
+		game.UUID, len(*fields), game.Rules.BoardSize, text)
+	return nil
+}
+
+func listPlayers(a *admin, fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	gameUUID, err := a.uuidArg(fs, "game")
+	if err != nil {
+		return err
+	}
+	game, err := a.game(gameUUID)
+	if err != nil {
+		return err
+	}
+	players, err := a.repository.SelectPlayersByGameID(gameUUID)
+	if err != nil {
+		return err
+	}
+
+	tw := a.tabWriter()
+	fmt.Fprintln(tw, "UUID\tNAME\tPOINTS\tTURN\tUSER\tRACK")
+	for _, player := range *players {
+		avChars, err := a.repository.SelectAvCharsByPlayerID(player.UUID)
+		if err != nil {
+			return err
+		}
+		turn := ""
+		if game.TurnPlayerUUID.Valid && game.TurnPlayerUUID.UUID == player.UUID {
+			turn = "*"
+		}
+		user := "-"
+		if player.UserUUID.Valid {
+			user = player.UserUUID.UUID.String()
+		}
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t%v\n",
+			player.UUID, player.Name, player.Points, turn, user, rackText(*avChars))
+	}
+	return tw.Flush()
+}
+
+func deleteStale(a *admin, fs *flag.FlagSet, args []string) error {
+	olderThan := fs.Duration("older-than", 30*24*time.Hour, "time since the last update of the game")
+	dryRun := fs.Bool("dry-run", false, "only list games that would be deleted")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *olderThan <= 0 {
+		return errors.New("-older-than should be positive")
+	}
+
+	games, err := a.repository.SelectGames()
+	if err != nil {
+		return err
+	}
+	deadline := time.Now().Add(-*olderThan)
+	deleted := 0
+	for _, game := range *games {
+		if !game.UpdateDate.Before(deadline) {
+			continue
+		}
+		if !*dryRun {
+			// Players, fields, racks, words and plays are deleted with the game
+			if err := a.repository.DeleteGame(&game); err != nil {
+				return fmt.Errorf("game %v: %w", game.UUID, err)
+			}
+		}
+		deleted++
+		fmt.Fprintf(a.out, "%v\t%v\t%v\n",
+			game.UUID, gameStatus(&game), formatDate(game.UpdateDate))
+	}
+
+	if *dryRun {
+		fmt.Fprintf(a.out, "%v games would be deleted\n", deleted)
+	} else {
+		fmt.Fprintf(a.out, "%v games deleted\n", deleted)
+	}
+	return nil
+}
+
+func resetRack(a *admin, fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	playerUUID, err := a.uuidArg(fs, "player")
+	if err != nil {
+		return err
+	}
+	player, err := a.repository.SelectPlayerByUUID(playerUUID)
+	if errors.Is(err, repo.ErrNotExists) {
+		return fmt.Errorf("player %v not found", playerUUID)
+	}
+	if err != nil {
+		return err
+	}
+	game, err := a.game(player.GameUUID)
+	if err != nil {
+		return err
+	}
+	packs, err := lang.LoadPacks(a.config.Langs.Dir)
+	if err != nil {
+		return err
+	}
+	pack, err := packs.Get(game.Rules.Language)
+	if err != nil {
+		return err
+	}
+
+	avCharService := svc.NewAvCharService(a.repository)
+	rack, err := avCharService.GetWithPlayerUUID(player.UUID)
+	if err != nil {
+		return err
+	}
+	ids := make([]int64, 0, len(*rack))
+	for _, avChar := range *rack {
+		ids = append(ids, avChar.ID)
+	}
+	// Old tiles go back to the bag first, so they can be drawn again
+	if err := avCharService.DeleteMany(&ids); err != nil {
+		return err
+	}
+	drawn, err := avCharService.CreateMany(player, game.Rules.RackSize, pack.Distribution)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.out, "rack of %v reset\nold: %v\nnew: %v\n",
+		player.UUID, rackText(*rack), rackText(*drawn))
+	return nil
+}
+
+func exportGame(a *admin, fs *flag.FlagSet, args []string) error {
+	output := fs.String("o", "", "file the game is written to, standard output when empty")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	gameUUID, err := a.uuidArg(fs, "game")
+	if err != nil {
+		return err
+	}
+	dump, err := a.dumpGame(gameUUID)
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		return dump.write(a.out)
+	}
+	fl, err := os.OpenFile(*output, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if err := dump.write(fl); err != nil {
+		fl.Close()
+		return err
+	}
+	return fl.Close()
+}
+
+func importGame(a *admin, fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return errors.New("expected at most one file")
+	}
+
+	in := a.in
+	if fs.NArg() == 1 {
+		fl, err := os.Open(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		defer fl.Close()
+		in = fl
+	}
+	dump, err := readGameDump(in)
+	if err != nil {
+		return err
+	}
+	if err := a.restoreGame(dump); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.out, "game %v imported\n", dump.Game.UUID)
+	return nil
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"scrable3/internal/model"
+	"scrable3/internal/repo"
+
+	"github.com/google/uuid"
+)
+
+// Version of the export format, raised when older files cannot be imported
+const gameDumpVersion = 1
+
+// Game with everything stored for it. Users are not exported, players keep
+// their account only when it exists in the database they are imported to.
+type gameDump struct {
+	Version   int              `json:"version"`
+	Game      model.Game       `json:"game"`
+	Players   []model.Player   `json:"players"`
+	Fields    []model.Field    `json:"fields"`
+	AvChars   []model.AvChar   `json:"available_characters"`
+	GameWords []model.GameWord `json:"game_words"`
+	Plays     []model.Play     `json:"plays"`
+}
+
+func readGameDump(r io.Reader) (*gameDump, error) {
+	var dump gameDump
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&dump); err != nil {
+		return nil, fmt.Errorf("reading game: %w", err)
+	}
+	if dump.Version != gameDumpVersion {
+		return nil, fmt.Errorf(
+			"export version %v is not supported, expected %v",
+			dump.Version, gameDumpVersion,
+		)
+	}
+	if err := dump.Game.Rules.Validate(); err != nil {
+		return nil, fmt.Errorf("game rules: %w", err)
+	}
+	return &dump, nil
+}
+
+func (dump *gameDump) write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(dump)
+}
+
+func (a *admin) dumpGame(gameUUID uuid.UUID) (*gameDump, error) {
+	game, err := a.game(gameUUID)
+	if err != nil {
+		return nil, err
+	}
+	dump := &gameDump{Version: gameDumpVersion, Game: *game}
+
+	players, err := a.repository.SelectPlayersByGameID(gameUUID)
+	if err != nil {
+		return nil, err
+	}
+	dump.Players = *players
+	fields, err := a.repository.SelectFieldsByGameID(gameUUID)
+	if err != nil {
+		return nil, err
+	}
+	dump.Fields = *fields
+	avChars, err := a.repository.SelectAvCharsByGameID(gameUUID)
+	if err != nil {
+		return nil, err
+	}
+	dump.AvChars = *avChars
+	gameWords, err := a.repository.SelectGameWordsByGameID(gameUUID)
+	if err != nil {
+		return nil, err
+	}
+	dump.GameWords = *gameWords
+	plays, err := a.repository.SelectPlaysByGameID(gameUUID)
+	if err != nil {
+		return nil, err
+	}
+	dump.Plays = *plays
+	return dump, nil
+}
+
+func (a *admin) insertDump(dump *gameDump) error {
+	for _, player := range dump.Players {
+		if player.UserUUID.Valid {
+			_, err := a.repository.SelectUserByUUID(player.UserUUID.UUID)
+			if errors.Is(err, repo.ErrNotExists) {
+				player.UserUUID = uuid.NullUUID{}
+			} else if err != nil {
+				return err
+			}
+		}
+		if err := a.repository.InsertPlayer(&player); err != nil {
+			return fmt.Errorf("player %v: %w", player.UUID, err)
+		}
+	}
+	// Rows get new IDs, inserting them in the exported order keeps the order
+	// of plays
+	for _, field := range dump.Fields {
+		if err := a.repository.InsertField(&field); err != nil {
+			return fmt.Errorf("field %v: %w", field.ID, err)
+		}
+	}
+	for _, avChar := range dump.AvChars {
+		if err := a.repository.InsertAvChar(&avChar); err != nil {
+			return fmt.Errorf("available character %v: %w", avChar.ID, err)
+		}
+	}
+	for _, gameWord := range dump.GameWords {
+		if err := a.repository.InsertGameWord(&gameWord); err != nil {
+			return fmt.Errorf("game word %v: %w", gameWord.Word, err)
+		}
+	}
+	for _, play := range dump.Plays {
+		if err := a.repository.InsertPlay(&play); err != nil {
+			return fmt.Errorf("play %v: %w", play.ID, err)
+		}
+	}
+	return nil
+}
+
+// Inserts the exported game. Games that fail half way are deleted, so
+// a broken file leaves nothing behind.
+func (a *admin) restoreGame(dump *gameDump) error {
+	game := dump.Game
+	_, err := a.repository.SelectGameByUUID(game.UUID)
+	if err == nil {
+		return fmt.Errorf("game %v already exists", game.UUID)
+	}
+	if !errors.Is(err, repo.ErrNotExists) {
+		return err
+	}
+	if err := a.repository.InsertGame(&game); err != nil {
+		return err
+	}
+
+	if err := a.insertDump(dump); err != nil {
+		if deleteErr := a.repository.DeleteGame(&game); deleteErr != nil {
+			return errors.Join(err, deleteErr)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"scrable3/internal/cfg"
+	"scrable3/internal/logging"
+	"scrable3/internal/metrics"
+	"scrable3/internal/repo"
+)
+
+// Subcommand working on the repository of the server
+type command struct {
+	name  string
+	args  string
+	usage string
+	run   func(a *admin, fs *flag.FlagSet, args []string) error
+}
+
+var commands = []command{
+	{"games", "[-unfinished]", "list games, recently updated first", listGames},
+	{"board", "<game uuid>", "show the board seen from every side and layer by layer", showBoard},
+	{"players", "<game uuid>", "list players of the game with points and racks", listPlayers},
+	{"delete-stale", "[-older-than 720h] [-dry-run]", "delete games not updated for a long time", deleteStale},
+	{"reset-rack", "<player uuid>", "put the rack of the player back and draw a new one", resetRack},
+	{"export", "[-o file] <game uuid>", "write the game with everything stored for it as JSON", exportGame},
+	{"import", "[file]", "read a game written by export, from standard input without file", importGame},
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage of scrable3-admin:")
+	fmt.Fprintln(w, "  scrable3-admin [options] <command> [command options]")
+	fmt.Fprintln(w, "Commands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %v %v\n\t%v\n", c.name, c.args, c.usage)
+	}
+	fmt.Fprintln(w, "Options, shared with the server:")
+	cfg.Usage(w)
+}
+
+func (c *command) flagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet(c.name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: scrable3-admin %v %v\n", c.name, c.args)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+func findCommand(name string) (*command, error) {
+	for i := range commands {
+		if commands[i].name == name {
+			return &commands[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unknown command %q", name)
+}
+
+func main() {
+	config, args, err := cfg.LoadArgs(os.Args[1:], os.LookupEnv)
+	if errors.Is(err, flag.ErrHelp) {
+		usage(os.Stdout)
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if len(args) == 0 {
+		usage(os.Stderr)
+		os.Exit(2)
+	}
+	cmd, err := findCommand(args[0])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	logger, err := logging.New(os.Stderr, config.Log.Level, config.Log.Format)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if _, err := os.Stat(config.Database.File); err != nil {
+		// Opening a missing file would create an empty database
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	db, err := sql.Open("sqlite3", config.Database.File)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	repository, err := repo.NewSqlite3Connection(db, logger, metrics.New())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	a := &admin{
+		config:     config,
+		repository: repository,
+		out:        os.Stdout,
+		in:         os.Stdin,
+	}
+	err = cmd.run(a, cmd.flagSet(), args[1:])
+	if closeErr := repository.CloseConn(); err == nil {
+		err = closeErr
+	}
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v: %v\n", cmd.name, err)
+		os.Exit(1)
+	}
+}
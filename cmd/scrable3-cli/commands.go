@@ -0,0 +1,201 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"scrable3/internal/dto"
+	"scrable3/internal/renderer"
+	"scrable3/internal/wsclient"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// Command typed by the player. 'sent' tells whether an action went to the
+// server, so the answer is waited for.
+type command struct {
+	name  string
+	args  string
+	usage string
+	run   func(c *cli, args []string) (sent bool, err error)
+}
+
+var commands = []command{
+	{"board", "[side|all]", "show the face seen from the side, or every face and layer", showBoard},
+	{"rack", "", "show the tiles on the rack", showRack},
+	{"players", "", "show players with points, * marks whose turn it is", showPlayers},
+	{"play", "<side> <h|v> <column>,<row> <word>", "play the word seen from the side, like play 90 h 3,4 LAMP", play},
+	{"challenge", "", "challenge the latest play", sendAction("challenge")},
+	{"undo", "", "ask to take back your latest play", sendAction("undoPlay")},
+	{"accept", "", "let the other player take back the play", sendAction("acceptUndo")},
+	{"reject", "", "keep the play the other player wants to take back", sendAction("rejectUndo")},
+	{"quit", "", "leave the game", quit},
+}
+
+var errQuit = errors.New("quit")
+
+type cli struct {
+	client *wsclient.Client
+	out    io.Writer
+	// Side the board is shown from, changed by board and play
+	side int
+}
+
+func printCommands(w io.Writer) {
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %v %v\n\t%v\n", c.name, c.args, c.usage)
+	}
+	fmt.Fprintln(w, "  help\n\tshow the commands")
+	fmt.Fprintln(w, "Sides are north, east, south, west, top and bottom, or 0, 90, 180 and 270.")
+	fmt.Fprintln(w, "Columns and rows are counted from 0 as on the board.")
+}
+
+// Runs the line typed by the player
+func (c *cli) run(line string) (bool, error) {
+	args := strings.Fields(line)
+	if len(args) == 0 {
+		return false, nil
+	}
+	if args[0] == "help" {
+		printCommands(c.out)
+		return false, nil
+	}
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd.run(c, args[1:])
+		}
+	}
+	return false, fmt.Errorf("unknown command %q, try help", args[0])
+}
+
+func (c *cli) printFace(s *wsclient.State) {
+	text, err := s.Text()
+	if err != nil {
+		fmt.Fprintf(c.out, "error: %v\n", err)
+		return
+	}
+	fmt.Fprint(c.out, text.Face(c.side))
+}
+
+func (c *cli) printRack(s *wsclient.State) {
+	values := make([]string, 0, len(s.Rack))
+	for _, tile := range s.Rack {
+		values = append(values, tile.Value)
+	}
+	fmt.Fprintf(c.out, "rack: %v\n", strings.Join(values, " "))
+}
+
+func (c *cli) printPlayers(s *wsclient.State) {
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	for _, player := range s.Players {
+		turn := " "
+		if player.HasTurn {
+			turn = "*"
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", turn, player.Name, player.Points, player.Clock)
+	}
+	w.Flush()
+}
+
+func (c *cli) printUpdate(update wsclient.Update) {
+	c.client.WithState(func(s *wsclient.State) {
+		if update.Fields {
+			c.printFace(s)
+		}
+		if update.Rack {
+			c.printRack(s)
+		}
+		if update.Players {
+			c.printPlayers(s)
+		}
+	})
+	if update.Error != "" {
+		fmt.Fprintf(c.out, "error: %v\n", update.Error)
+	}
+	if update.Notice != "" {
+		fmt.Fprintf(c.out, "notice: %v\n", update.Notice)
+	}
+}
+
+func showBoard(c *cli, args []string) (bool, error) {
+	if len(args) > 1 {
+		return false, errors.New("usage: board [side|all]")
+	}
+	if len(args) == 1 && args[0] == "all" {
+		c.client.WithState(func(s *wsclient.State) {
+			fmt.Fprint(c.out, renderer.Fields(s.Size, s.Fields()))
+		})
+		return false, nil
+	}
+	if len(args) == 1 {
+		side, err := wsclient.ParseSide(args[0])
+		if err != nil {
+			return false, err
+		}
+		c.side = side
+	}
+	c.client.WithState(c.printFace)
+	return false, nil
+}
+
+func showRack(c *cli, args []string) (bool, error) {
+	c.client.WithState(c.printRack)
+	return false, nil
+}
+
+func showPlayers(c *cli, args []string) (bool, error) {
+	c.client.WithState(c.printPlayers)
+	return false, nil
+}
+
+func play(c *cli, args []string) (bool, error) {
+	if len(args) != 4 {
+		return false, errors.New("usage: play <side> <h|v> <column>,<row> <word>")
+	}
+	side, err := wsclient.ParseSide(args[0])
+	if err != nil {
+		return false, err
+	}
+	var horizontal bool
+	switch strings.ToLower(args[1]) {
+	case "h":
+		horizontal = true
+	case "v":
+		horizontal = false
+	default:
+		return false, fmt.Errorf("direction should be h or v, not %q", args[1])
+	}
+	columnText, rowText, ok := strings.Cut(args[2], ",")
+	if !ok {
+		return false, fmt.Errorf("position should be column,row, not %q", args[2])
+	}
+	column, err := strconv.Atoi(columnText)
+	if err != nil {
+		return false, fmt.Errorf("column; %w", err)
+	}
+	row, err := strconv.Atoi(rowText)
+	if err != nil {
+		return false, fmt.Errorf("row; %w", err)
+	}
+
+	c.side = side
+	var playData *dto.PlayData
+	c.client.WithState(func(s *wsclient.State) {
+		playData, err = s.BuildPlay(side, horizontal, column, row, args[3])
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, c.client.Play(playData)
+}
+
+func sendAction(actionType string) func(c *cli, args []string) (bool, error) {
+	return func(c *cli, args []string) (bool, error) {
+		return true, c.client.Send(actionType)
+	}
+}
+
+func quit(c *cli, args []string) (bool, error) {
+	return false, errQuit
+}
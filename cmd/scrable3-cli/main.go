@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"scrable3/internal/wsclient"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// How long the server may take to answer an action, and how long it has to be
+// quiet before the answer is taken as complete
+const (
+	answerTimeout = 5 * time.Second
+	answerQuiet   = 300 * time.Millisecond
+)
+
+func usage(w io.Writer, fs *flag.FlagSet) {
+	fmt.Fprintln(w, "Usage of scrable3-cli:")
+	fmt.Fprintln(w, "  scrable3-cli -game <game uuid> (-name <name> | -token <token>) [options]")
+	fmt.Fprintln(w, "Commands are read from standard input, one per line:")
+	printCommands(w)
+	fmt.Fprintln(w, "Options:")
+	fs.SetOutput(w)
+	fs.PrintDefaults()
+}
+
+// Reads lines of the input until its end
+func readLines(in io.Reader) <-chan string {
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(in)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+	return lines
+}
+
+// Runs commands of the input and prints what the server sends. After an action
+// the next command waits for the answer, so scripts see the game change
+// before going on.
+func (c *cli) loop(in io.Reader) error {
+	lines := readLines(in)
+	// The server sends the board and the rack right after connecting
+	var pending <-chan string
+	answered := time.After(answerTimeout)
+	for {
+		select {
+		case update, ok := <-c.client.Updates():
+			if !ok {
+				return fmt.Errorf("connection closed; %w", c.client.Err())
+			}
+			c.printUpdate(update)
+			if pending == nil {
+				answered = time.After(answerQuiet)
+			}
+		case <-answered:
+			pending = lines
+			answered = nil
+		case line, ok := <-pending:
+			if !ok {
+				return nil
+			}
+			sent, err := c.run(line)
+			if errors.Is(err, errQuit) {
+				return nil
+			}
+			if err != nil {
+				fmt.Fprintf(c.out, "error: %v\n", err)
+			}
+			if sent {
+				pending = nil
+				answered = time.After(answerTimeout)
+			}
+		}
+	}
+}
+
+func main() {
+	fs := flag.NewFlagSet("scrable3-cli", flag.ContinueOnError)
+	server := fs.String("server", "http://localhost:8080", "address of the server")
+	game := fs.String("game", "", "uuid of the game")
+	name := fs.String("name", "", "join the game as a new player with the name")
+	token := fs.String("token", os.Getenv("SCRABLE3_TOKEN"),
+		"player cookie of a player already in the game, $SCRABLE3_TOKEN by default")
+	fs.SetOutput(io.Discard)
+	err := fs.Parse(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		usage(os.Stdout, fs)
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		usage(os.Stderr, fs)
+		os.Exit(2)
+	}
+	gameUUID, err := uuid.Parse(*game)
+	if err != nil || (*name == "") == (*token == "") {
+		usage(os.Stderr, fs)
+		os.Exit(2)
+	}
+
+	if *name != "" {
+		*token, err = wsclient.Join(*server, gameUUID, *name)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "joined as %v, connect again with -token %v\n", *name, *token)
+	}
+	client, err := wsclient.Dial(*server, gameUUID, *token)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	c := &cli{client: client, out: os.Stdout}
+	err = c.loop(os.Stdin)
+	client.Close()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -11,3 +11,7 @@ require (
 require github.com/gorilla/websocket v1.5.3
 
 require go.uber.org/mock v0.5.0
+
+require golang.org/x/crypto v0.31.0
+
+require golang.org/x/text v0.21.0
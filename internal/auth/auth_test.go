@@ -0,0 +1,206 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	oldKey = Key{ID: "2024", Secret: []byte("old-secret-0123456789")}
+	newKey = Key{ID: "2025", Secret: []byte("new-secret-0123456789")}
+)
+
+func setupSigner(t *testing.T, keys []Key) *playerTokenSigner {
+	signer, err := NewPlayerTokenSigner(keys, time.Hour)
+	if err != nil {
+		t.Fatalf("creating signer failed; %v", err)
+	}
+	return signer.(*playerTokenSigner)
+}
+
+func TestPlayerTokenRoundTrip(t *testing.T) {
+	signer := setupSigner(t, []Key{newKey})
+	gameUUID, playerUUID := uuid.New(), uuid.New()
+
+	token, expireDate, err := signer.Sign(gameUUID, playerUUID)
+	if err != nil {
+		t.Fatalf("signing failed; %v", err)
+	}
+	if time.Until(expireDate) > time.Hour {
+		t.Errorf("token expires too late; %v", expireDate)
+	}
+	if strings.Contains(token, playerUUID.String()) {
+		t.Errorf("token should not contain raw player uuid; %v", token)
+	}
+
+	verifiedUUID, err := signer.Verify(token, gameUUID)
+	if err != nil {
+		t.Fatalf("verifying failed; %v", err)
+	}
+	if verifiedUUID != playerUUID {
+		t.Errorf("expected %v, got %v", playerUUID, verifiedUUID)
+	}
+}
+
+func TestPlayerTokenLifetime(t *testing.T) {
+	gameUUID, playerUUID := uuid.New(), uuid.New()
+	issued := time.Now()
+	signer := setupSigner(t, []Key{newKey})
+	signer.now = func() time.Time { return issued }
+	longToken, longExpireDate, _ := signer.SignFor(gameUUID, playerUUID, 7*24*time.Hour)
+	if !longExpireDate.Equal(issued.Add(7 * 24 * time.Hour)) {
+		t.Errorf("long token expires at %v", longExpireDate)
+	}
+	_, shortExpireDate, _ := signer.SignFor(gameUUID, playerUUID, time.Minute)
+	if !shortExpireDate.Equal(issued.Add(time.Hour)) {
+		t.Errorf("token should live at least the default lifetime, expires at %v", shortExpireDate)
+	}
+
+	// Player returns after the default lifetime has passed
+	signer.now = func() time.Time { return issued.Add(3 * 24 * time.Hour) }
+	verifiedUUID, err := signer.Verify(longToken, gameUUID)
+	if err != nil {
+		t.Fatalf("long token rejected; %v", err)
+	}
+	if verifiedUUID != playerUUID {
+		t.Errorf("expected %v, got %v", playerUUID, verifiedUUID)
+	}
+	signer.now = func() time.Time { return issued.Add(8 * 24 * time.Hour) }
+	if _, err := signer.Verify(longToken, gameUUID); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("expected ErrTokenExpired, got %v", err)
+	}
+}
+
+func TestPlayerTokenKeyRotation(t *testing.T) {
+	gameUUID, playerUUID := uuid.New(), uuid.New()
+	oldToken, _, _ := setupSigner(t, []Key{oldKey}).Sign(gameUUID, playerUUID)
+
+	rotatedSigner := setupSigner(t, []Key{newKey, oldKey})
+	if _, err := rotatedSigner.Verify(oldToken, gameUUID); err != nil {
+		t.Errorf("token signed with old key rejected; %v", err)
+	}
+	newToken, _, _ := rotatedSigner.Sign(gameUUID, playerUUID)
+	if _, err := setupSigner(t, []Key{newKey}).Verify(newToken, gameUUID); err != nil {
+		t.Errorf("new token not signed with the current key; %v", err)
+	}
+
+	retiredSigner := setupSigner(t, []Key{newKey})
+	if _, err := retiredSigner.Verify(oldToken, gameUUID); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("expected ErrUnknownKey, got %v", err)
+	}
+}
+
+func TestInvalidPlayerTokens(t *testing.T) {
+	signer := setupSigner(t, []Key{newKey})
+	gameUUID, playerUUID := uuid.New(), uuid.New()
+	token, _, _ := signer.Sign(gameUUID, playerUUID)
+	payload, signature, _ := strings.Cut(token, ".")
+
+	forgedToken, _, _ := setupSigner(t, []Key{{
+		ID:     newKey.ID,
+		Secret: []byte("guessed-secret-0123456789"),
+	}}).Sign(gameUUID, playerUUID)
+
+	expiredSigner := setupSigner(t, []Key{newKey})
+	expiredSigner.now = func() time.Time { return time.Now().Add(-2 * time.Hour) }
+	expiredToken, _, _ := expiredSigner.Sign(gameUUID, playerUUID)
+
+	testCases := []struct {
+		name        string
+		token       string
+		gameUUID    uuid.UUID
+		expectedErr error
+	}{
+		{"raw player uuid", playerUUID.String(), gameUUID, ErrMalformedToken},
+		{"empty token", "", gameUUID, ErrMalformedToken},
+		{"forged signature", forgedToken, gameUUID, ErrBadSignature},
+		{"swapped signature", payload + "." + signature[1:] + "A", gameUUID, ErrBadSignature},
+		{"expired", expiredToken, gameUUID, ErrTokenExpired},
+		{"other game", token, uuid.New(), ErrWrongGame},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := signer.Verify(tc.token, tc.gameUUID)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	keys, err := ParseKeys("2025:new-secret, 2024:old:secret")
+	if err != nil {
+		t.Fatalf("parsing keys failed; %v", err)
+	}
+	if len(keys) != 2 || keys[0].ID != "2025" || string(keys[1].Secret) != "old:secret" {
+		t.Errorf("unexpected keys; %v", keys)
+	}
+
+	if _, err := ParseKeys("no-separator"); err == nil {
+		t.Error("key without separator accepted")
+	}
+	if _, err := NewPlayerTokenSigner([]Key{{ID: "short", Secret: []byte("x")}}, time.Hour); err == nil {
+		t.Error("short secret accepted")
+	}
+	if _, err := NewPlayerTokenSigner([]Key{newKey, newKey}, time.Hour); err == nil {
+		t.Error("duplicated key id accepted")
+	}
+}
+
+func TestOriginPolicy(t *testing.T) {
+	sameHostPolicy, err := NewOriginPolicy(nil)
+	if err != nil {
+		t.Fatalf("creating policy failed; %v", err)
+	}
+	listPolicy, err := NewOriginPolicy([]string{
+		"https://scrable.example.com",
+		"HTTP://localhost:8080/",
+	})
+	if err != nil {
+		t.Fatalf("creating policy failed; %v", err)
+	}
+	if _, err := NewOriginPolicy([]string{"localhost"}); err == nil {
+		t.Error("origin without scheme accepted")
+	}
+
+	testCases := []struct {
+		name    string
+		policy  OriginPolicy
+		host    string
+		origin  string
+		allowed bool
+	}{
+		{"no origin header", listPolicy, "localhost:8080", "", true},
+		{"same host", sameHostPolicy, "localhost:8080", "http://localhost:8080", true},
+		{"other host", sameHostPolicy, "localhost:8080", "http://evil.example", false},
+		{"other port", sameHostPolicy, "localhost:8080", "http://localhost:9090", false},
+		{"listed origin", listPolicy, "10.0.0.1:8080", "https://scrable.example.com", true},
+		{"listed origin case", listPolicy, "10.0.0.1:8080", "http://LOCALHOST:8080", true},
+		{"listed host other scheme", listPolicy, "10.0.0.1:8080", "http://scrable.example.com", false},
+		{"not listed", listPolicy, "scrable.example.com", "https://evil.example", false},
+		{"null origin", listPolicy, "localhost:8080", "null", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, _ := http.NewRequest(http.MethodGet, "http://"+tc.host+"/ws/x", nil)
+			if tc.origin != "" {
+				r.Header.Set("Origin", tc.origin)
+			}
+			err := tc.policy.Check(r)
+			if tc.allowed && err != nil {
+				t.Errorf("expected allowed, got %v", err)
+			}
+			if !tc.allowed && !errors.Is(err, ErrOriginNotAllowed) {
+				t.Errorf("expected ErrOriginNotAllowed, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+var ErrOriginNotAllowed = errors.New("origin not allowed")
+
+// Decides which browser origins may talk to the server
+type OriginPolicy interface {
+	// Returns nil for allowed requests, otherwise an error describing why the
+	// request was rejected
+	Check(r *http.Request) error
+}
+
+type originPolicy struct {
+	// Normalized "scheme://host[:port]" entries
+	allowedOrigins map[string]bool
+}
+
+// Creates policy allowing given origins. Without any origins only requests
+// coming from the same host as the server are allowed. Requests without the
+// Origin header come from non-browser clients and are always allowed.
+func NewOriginPolicy(allowedOrigins []string) (OriginPolicy, error) {
+	policy := &originPolicy{allowedOrigins: make(map[string]bool)}
+	for _, origin := range allowedOrigins {
+		normalized, err := policy.normalize(origin)
+		if err != nil {
+			return nil, err
+		}
+		policy.allowedOrigins[normalized] = true
+	}
+	return policy, nil
+}
+
+func (p *originPolicy) normalize(origin string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(origin))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("invalid origin '%v'", origin)
+	}
+	return strings.ToLower(u.Scheme + "://" + u.Host), nil
+}
+
+func (p *originPolicy) Check(r *http.Request) error {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return nil
+	}
+	normalized, err := p.normalize(origin)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrOriginNotAllowed, err)
+	}
+
+	if len(p.allowedOrigins) == 0 {
+		u, _ := url.Parse(normalized)
+		if !strings.EqualFold(u.Host, r.Host) {
+			return fmt.Errorf(
+				"%w: '%v' does not match host '%v'",
+				ErrOriginNotAllowed, origin, r.Host,
+			)
+		}
+		return nil
+	}
+	if !p.allowedOrigins[normalized] {
+		return fmt.Errorf(
+			"%w: '%v' is not on allowed origins list", ErrOriginNotAllowed, origin,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrMalformedToken = errors.New("malformed player token")
+	ErrUnknownKey     = errors.New("player token signed with unknown key")
+	ErrBadSignature   = errors.New("player token signature mismatch")
+	ErrTokenExpired   = errors.New("player token expired")
+	ErrWrongGame      = errors.New("player token issued for another game")
+)
+
+// Secret used to sign player tokens. The ID is stored in every token, so
+// tokens signed with older keys can still be verified after a rotation.
+type Key struct {
+	ID     string
+	Secret []byte
+}
+
+type PlayerTokenSigner interface {
+	Sign(gameUUID uuid.UUID, playerUUID uuid.UUID) (token string, expireDate time.Time, err error)
+	// Signs token that lives at least 'ttl', for players that can stay away
+	// longer than the default lifetime
+	SignFor(gameUUID uuid.UUID, playerUUID uuid.UUID, ttl time.Duration) (token string, expireDate time.Time, err error)
+	Verify(token string, gameUUID uuid.UUID) (playerUUID uuid.UUID, err error)
+}
+
+type playerTokenSigner struct {
+	// keys[0] signs new tokens, all of them verify
+	keys []Key
+	ttl  time.Duration
+	now  func() time.Time
+}
+
+// Creates signer that signs tokens with the first key and accepts tokens
+// signed with any of the keys.
+func NewPlayerTokenSigner(keys []Key, ttl time.Duration) (PlayerTokenSigner, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("at least one signing key is required")
+	}
+	ids := make(map[string]bool)
+	for _, key := range keys {
+		if key.ID == "" || strings.ContainsAny(key.ID, ".:,") {
+			return nil, fmt.Errorf("invalid signing key id '%v'", key.ID)
+		}
+		if len(key.Secret) < 16 {
+			return nil, fmt.Errorf(
+				"signing key '%v' should have at least 16 bytes", key.ID,
+			)
+		}
+		if ids[key.ID] {
+			return nil, fmt.Errorf("duplicated signing key id '%v'", key.ID)
+		}
+		ids[key.ID] = true
+	}
+	return &playerTokenSigner{keys: keys, ttl: ttl, now: time.Now}, nil
+}
+
+// Parses keys written as "id:secret,id:secret". The first key is the current
+// one.
+func ParseKeys(s string) ([]Key, error) {
+	var keys []Key
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, secret, ok := strings.Cut(part, ":")
+		if !ok {
+			return nil, fmt.Errorf("signing key '%v' should be written as id:secret", part)
+		}
+		keys = append(keys, Key{ID: id, Secret: []byte(secret)})
+	}
+	return keys, nil
+}
+
+// |PRIVATE| //
+
+func (s *playerTokenSigner) findKey(id string) (*Key, bool) {
+	for i := range s.keys {
+		if s.keys[i].ID == id {
+			return &s.keys[i], true
+		}
+	}
+	return nil, false
+}
+
+func (s *playerTokenSigner) signature(key *Key, payload string) []byte {
+	mac := hmac.New(sha256.New, key.Secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+// |PUBLIC| //
+
+func (s *playerTokenSigner) Sign(
+	gameUUID uuid.UUID,
+	playerUUID uuid.UUID,
+) (string, time.Time, error) {
+	return s.SignFor(gameUUID, playerUUID, s.ttl)
+}
+
+// Token is "base64(keyID.gameUUID.playerUUID.expireUnix).base64(HMAC-SHA256)"
+func (s *playerTokenSigner) SignFor(
+	gameUUID uuid.UUID,
+	playerUUID uuid.UUID,
+	ttl time.Duration,
+) (string, time.Time, error) {
+	key := &s.keys[0]
+	expireDate := s.now().Add(max(s.ttl, ttl))
+	payload := strings.Join([]string{
+		key.ID,
+		gameUUID.String(),
+		playerUUID.String(),
+		strconv.FormatInt(expireDate.Unix(), 10),
+	}, ".")
+
+	token := base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." +
+		base64.RawURLEncoding.EncodeToString(s.signature(key, payload))
+	return token, expireDate, nil
+}
+
+func (s *playerTokenSigner) Verify(
+	token string,
+	gameUUID uuid.UUID,
+) (uuid.UUID, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return uuid.Nil, ErrMalformedToken
+	}
+	payloadBytes, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return uuid.Nil, ErrMalformedToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return uuid.Nil, ErrMalformedToken
+	}
+	payload := string(payloadBytes)
+	parts := strings.Split(payload, ".")
+	if len(parts) != 4 {
+		return uuid.Nil, ErrMalformedToken
+	}
+
+	key, ok := s.findKey(parts[0])
+	if !ok {
+		return uuid.Nil, ErrUnknownKey
+	}
+	if !hmac.Equal(signature, s.signature(key, payload)) {
+		return uuid.Nil, ErrBadSignature
+	}
+
+	expireUnix, err := strconv.ParseInt(parts[3], 10, 64)
+	if err != nil {
+		return uuid.Nil, ErrMalformedToken
+	}
+	if !s.now().Before(time.Unix(expireUnix, 0)) {
+		return uuid.Nil, ErrTokenExpired
+	}
+
+	tokenGameUUID, err := uuid.Parse(parts[1])
+	if err != nil {
+		return uuid.Nil, ErrMalformedToken
+	}
+	if tokenGameUUID != gameUUID {
+		return uuid.Nil, ErrWrongGame
+	}
+	playerUUID, err := uuid.Parse(parts[2])
+	if err != nil {
+		return uuid.Nil, ErrMalformedToken
+	}
+	return playerUUID, nil
+}
@@ -0,0 +1,167 @@
+package board
+
+import (
+	"fmt"
+	"scrable3/internal/model"
+)
+
+// Position in the cube. X is the vertical axis, Y the horizontal one and Z
+// the depth seen from the north side.
+type Pos [3]int
+
+type Axis int
+
+const (
+	AxisX Axis = iota
+	AxisY
+	AxisZ
+)
+
+func (a Axis) String() string {
+	return [...]string{"X", "Y", "Z"}[a]
+}
+
+// Position next to 'p' along the axis, 'step' is -1 or 1
+func (p Pos) Step(axis Axis, step int) Pos {
+	p[axis] += step
+	return p
+}
+
+// Letter at the position in the cube
+type Tile struct {
+	Pos   Pos
+	Value string
+}
+
+// Whole 3D volume of the game, every position holds a letter or nothing
+type Board struct {
+	size  int
+	cells []string
+}
+
+func New(size int) *Board {
+	return &Board{
+		size:  size,
+		cells: make([]string, size*size*size),
+	}
+}
+
+// Loads fields of the game into the board. Fields outside of the cube or on
+// the same position mean the stored game is broken.
+func FromFields(size int, fields []model.Field) (*Board, error) {
+	b := New(size)
+	for _, field := range fields {
+		pos := Pos{field.PosX, field.PosY, field.PosZ}
+		if err := b.Set(pos, field.Value); err != nil {
+			return nil, fmt.Errorf("field %v: %w", field.ID, err)
+		}
+	}
+	return b, nil
+}
+
+// |PRIVATE| //
+
+func (b *Board) index(p Pos) int {
+	return (p[0]*b.size+p[1])*b.size + p[2]
+}
+
+func (b *Board) clone() *Board {
+	cells := make([]string, len(b.cells))
+	copy(cells, b.cells)
+	return &Board{size: b.size, cells: cells}
+}
+
+// |PUBLIC| //
+
+func (b *Board) Size() int {
+	return b.size
+}
+
+func (b *Board) Contains(p Pos) bool {
+	for _, coord := range p {
+		if coord < 0 || coord >= b.size {
+			return false
+		}
+	}
+	return true
+}
+
+// Letter on the position, empty for free positions and ones outside the cube
+func (b *Board) At(p Pos) string {
+	if !b.Contains(p) {
+		return ""
+	}
+	return b.cells[b.index(p)]
+}
+
+func (b *Board) Occupied(p Pos) bool {
+	return b.At(p) != ""
+}
+
+func (b *Board) Set(p Pos, value string) error {
+	if !b.Contains(p) {
+		return fmt.Errorf("position %v is outside of the board", p)
+	}
+	if b.Occupied(p) {
+		return fmt.Errorf("position %v is already taken by %v", p, b.At(p))
+	}
+	b.cells[b.index(p)] = value
+	return nil
+}
+
+// Occupied positions sharing a face with 'p'
+func (b *Board) Neighbours(p Pos) []Pos {
+	var neighbours []Pos
+	for _, axis := range []Axis{AxisX, AxisY, AxisZ} {
+		for _, step := range []int{-1, 1} {
+			if next := p.Step(axis, step); b.Occupied(next) {
+				neighbours = append(neighbours, next)
+			}
+		}
+	}
+	return neighbours
+}
+
+// Unbroken run of tiles along the axis going through 'p', ordered from the
+// lowest position. Empty when 'p' is free.
+func (b *Board) Line(p Pos, axis Axis) []Pos {
+	if !b.Occupied(p) {
+		return nil
+	}
+	start := p
+	for b.Occupied(start.Step(axis, -1)) {
+		start = start.Step(axis, -1)
+	}
+	var line []Pos
+	for pos := start; b.Occupied(pos); pos = pos.Step(axis, 1) {
+		line = append(line, pos)
+	}
+	return line
+}
+
+// Letters on the positions joined together
+func (b *Board) Word(positions []Pos) string {
+	var word string
+	for _, pos := range positions {
+		word += b.At(pos)
+	}
+	return word
+}
+
+// Tiles seen from the side, the one nearest to the face on every face
+// position. Keys are column and row on the face.
+func (b *Board) Surface(side int) map[[2]int]Pos {
+	surface := make(map[[2]int]Pos)
+	for column := range b.size {
+		for row := range b.size {
+			for depth := range b.size {
+				pos := Unproject(b.size, side, column, row, depth)
+				if b.Occupied(pos) {
+					surface[[2]int{column, row}] = pos
+					break
+				}
+			}
+		}
+	}
+	return surface
+}
@@ -0,0 +1,232 @@
+package board
+
+import (
+	"reflect"
+	"scrable3/internal/model"
+	"testing"
+)
+
+// Board of size 9 with LAMP along Y on the middle row of the north side and
+// PA going down along X from the P
+func setupBoard(t *testing.T) *Board {
+	fields := []model.Field{
+		{Value: "L", PosX: 4, PosY: 4, PosZ: 4},
+		{Value: "A", PosX: 4, PosY: 5, PosZ: 4},
+		{Value: "M", PosX: 4, PosY: 6, PosZ: 4},
+		{Value: "P", PosX: 4, PosY: 7, PosZ: 4},
+		{Value: "A", PosX: 5, PosY: 7, PosZ: 4},
+	}
+	b, err := FromFields(9, fields)
+	if err != nil {
+		t.Fatalf("loading fields failed; %v", err)
+	}
+	return b
+}
+
+func TestFromFieldsErrors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		fields []model.Field
+	}{
+		{"outside", []model.Field{{Value: "L", PosX: 9}}},
+		{"negative", []model.Field{{Value: "L", PosZ: -1}}},
+		{"same position", []model.Field{{Value: "L"}, {Value: "A"}}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := FromFields(9, tc.fields); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestQueries(t *testing.T) {
+	b := setupBoard(t)
+
+	if !b.Occupied(Pos{4, 4, 4}) || b.Occupied(Pos{4, 4, 5}) || b.Occupied(Pos{-1, 4, 4}) {
+		t.Error("wrong occupied positions")
+	}
+
+	neighbours := b.Neighbours(Pos{4, 7, 4})
+	expectedNeighbours := []Pos{{5, 7, 4}, {4, 6, 4}}
+	if !reflect.DeepEqual(neighbours, expectedNeighbours) {
+		t.Errorf("expected neighbours %v, got %v", expectedNeighbours, neighbours)
+	}
+
+	testCases := []struct {
+		pos      Pos
+		axis     Axis
+		expected string
+	}{
+		{Pos{4, 6, 4}, AxisY, "LAMP"},
+		{Pos{4, 7, 4}, AxisX, "PA"},
+		{Pos{4, 7, 4}, AxisZ, "P"},
+		{Pos{0, 0, 0}, AxisY, ""},
+	}
+	for _, tc := range testCases {
+		if word := b.Word(b.Line(tc.pos, tc.axis)); word != tc.expected {
+			t.Errorf("line through %v along %v; expected %v, got %v",
+				tc.pos, tc.axis, tc.expected, word)
+		}
+	}
+}
+
+func TestSurface(t *testing.T) {
+	b := setupBoard(t)
+	if err := b.Set(Pos{4, 4, 2}, "M"); err != nil {
+		t.Fatal(err)
+	}
+
+	north := b.Surface(0)
+	if len(north) != 5 {
+		t.Errorf("expected 5 tiles seen from the north, got %v", len(north))
+	}
+	if pos := north[[2]int{4, 4}]; pos != (Pos{4, 4, 2}) {
+		t.Errorf("expected nearest tile on %v, got %v", Pos{4, 4, 2}, pos)
+	}
+
+	// From the east the middle row shows only the nearest of its tiles
+	east := b.Surface(90)
+	if len(east) != 3 {
+		t.Errorf("expected 3 tiles seen from the east, got %v", len(east))
+	}
+	if pos := east[[2]int{4, 4}]; pos != (Pos{4, 4, 4}) {
+		t.Errorf("expected L seen from the east, got %v", b.At(pos))
+	}
+}
+
+func TestProject(t *testing.T) {
+	size := 9
+
+	// Field in the top left corner nearest to the north side
+	corner := Pos{0, 0, 0}
+	expectedCorners := map[int][3]int{
+		0:          {0, 0, 0},
+		90:         {8, 0, 0},
+		180:        {8, 0, 8},
+		270:        {0, 0, 8},
+		SideTop:    {0, 8, 0},
+		SideBottom: {0, 0, 8},
+	}
+	for side, expected := range expectedCorners {
+		column, row, depth := Project(size, side, corner)
+		if [3]int{column, row, depth} != expected {
+			t.Errorf(
+				"side %v; expected %v, got %v",
+				side, expected, [3]int{column, row, depth},
+			)
+		}
+	}
+
+	b := New(size)
+	for _, side := range []int{0, 90, 180, 270, SideTop, SideBottom} {
+		for _, pos := range []Pos{{0, 0, 0}, {1, 2, 3}, {8, 4, 0}, {5, 8, 7}} {
+			column, row, depth := Project(size, side, pos)
+			if !b.Contains(Pos{column, row, depth}) {
+				t.Errorf("side %v; %v projected outside of the board", side, pos)
+			}
+			unprojected := Unproject(size, side, column, row, depth)
+			if unprojected != pos {
+				t.Errorf("side %v; expected %v, got %v", side, pos, unprojected)
+			}
+		}
+	}
+}
+
+func TestCheckPlay(t *testing.T) {
+	b := setupBoard(t)
+	testCases := []struct {
+		name     string
+		side     int
+		axis     Axis
+		tiles    []Tile
+		expected string
+		isErr    bool
+	}{
+		{
+			name: "extends word", side: 0, axis: AxisY,
+			tiles:    []Tile{{Pos{4, 8, 4}, "S"}},
+			expected: "LAMPS",
+		},
+		{
+			name: "word before", side: 0, axis: AxisY,
+			tiles:    []Tile{{Pos{4, 2, 4}, "C"}, {Pos{4, 3, 4}, "A"}},
+			expected: "CALAMP",
+		},
+		{
+			name: "down from letter", side: 0, axis: AxisX,
+			tiles:    []Tile{{Pos{5, 4, 4}, "A"}, {Pos{6, 4, 4}, "B"}},
+			expected: "LAB",
+		},
+		{
+			name: "along depth from the east", side: 90, axis: AxisZ,
+			tiles:    []Tile{{Pos{4, 4, 5}, "O"}},
+			expected: "OL",
+		},
+		{
+			name: "right to left from the south", side: 180, axis: AxisY,
+			tiles:    []Tile{{Pos{4, 8, 4}, "S"}},
+			expected: "SPMAL",
+		},
+		{
+			name: "from the top", side: SideTop, axis: AxisZ,
+			tiles:    []Tile{{Pos{4, 4, 5}, "O"}},
+			expected: "OL",
+		},
+		{
+			name: "from the bottom", side: SideBottom, axis: AxisZ,
+			tiles:    []Tile{{Pos{4, 4, 5}, "O"}},
+			expected: "LO",
+		},
+		{
+			name: "under other tile from the top", side: SideTop, axis: AxisZ,
+			tiles: []Tile{{Pos{6, 7, 4}, "S"}},
+			isErr: true,
+		},
+		{
+			name: "floating", side: 0, axis: AxisY,
+			tiles: []Tile{{Pos{0, 0, 0}, "A"}, {Pos{0, 1, 0}, "B"}},
+			isErr: true,
+		},
+		{
+			name: "gap", side: 0, axis: AxisY,
+			tiles: []Tile{{Pos{4, 8, 4}, "S"}, {Pos{4, 2, 4}, "C"}},
+			isErr: true,
+		},
+		{
+			name: "taken position", side: 0, axis: AxisY,
+			tiles: []Tile{{Pos{4, 4, 4}, "S"}},
+			isErr: true,
+		},
+		{
+			name: "behind other tile", side: 0, axis: AxisY,
+			tiles: []Tile{{Pos{4, 4, 5}, "S"}},
+			isErr: true,
+		},
+		{
+			name: "not in line", side: 0, axis: AxisY,
+			tiles: []Tile{{Pos{4, 8, 4}, "S"}, {Pos{3, 8, 4}, "O"}},
+			isErr: true,
+		},
+		{
+			name: "outside", side: 0, axis: AxisY,
+			tiles: []Tile{{Pos{4, 9, 4}, "S"}},
+			isErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			word, err := b.CheckPlay(tc.side, tc.axis, tc.tiles)
+			if (err != nil) != tc.isErr {
+				t.Fatalf("expected error %v, got %v", tc.isErr, err)
+			}
+			if word != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, word)
+			}
+		})
+	}
+	if b.Occupied(Pos{4, 8, 4}) {
+		t.Error("checking play changed the board")
+	}
+}
@@ -0,0 +1,79 @@
+package board
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Checks tiles placed from the side against the board and returns the word
+// they make. Tiles must
+//   - lie inside the cube on free positions that can be reached from the side
+//     without passing through other tiles,
+//   - lie on one straight line along the axis with no gaps once placed,
+//   - share a face with at least one tile already on the board.
+//
+// The board is not changed.
+func (b *Board) CheckPlay(side int, axis Axis, tiles []Tile) (string, error) {
+	if len(tiles) == 0 {
+		return "", errors.New("play has no tiles")
+	}
+
+	placed := b.clone()
+	for _, tile := range tiles {
+		if tile.Value == "" {
+			return "", fmt.Errorf("tile on %v has no letter", tile.Pos)
+		}
+		if err := placed.Set(tile.Pos, tile.Value); err != nil {
+			return "", err
+		}
+		column, row, depth := Project(b.size, side, tile.Pos)
+		for d := range depth {
+			if pos := Unproject(b.size, side, column, row, d); b.Occupied(pos) {
+				return "", fmt.Errorf(
+					"tile on %v is hidden behind %v on %v", tile.Pos, b.At(pos), pos,
+				)
+			}
+		}
+	}
+
+	first := tiles[0].Pos
+	for _, tile := range tiles[1:] {
+		for a := range len(first) {
+			if Axis(a) != axis && tile.Pos[a] != first[a] {
+				return "", fmt.Errorf(
+					"tiles on %v and %v are not in one line along %v",
+					first, tile.Pos, axis,
+				)
+			}
+		}
+	}
+
+	// Words are read left to right and top to bottom on the face, which goes
+	// against the axis on some sides
+	line := placed.Line(first, axis)
+	if len(line) > 1 {
+		firstColumn, firstRow, _ := Project(b.size, side, line[0])
+		lastColumn, lastRow, _ := Project(b.size, side, line[len(line)-1])
+		if firstColumn+firstRow > lastColumn+lastRow {
+			slices.Reverse(line)
+		}
+	}
+	inLine := make(map[Pos]bool, len(line))
+	for _, pos := range line {
+		inLine[pos] = true
+	}
+	touching := false
+	for _, tile := range tiles {
+		if !inLine[tile.Pos] {
+			return "", fmt.Errorf("gap between tiles on %v and %v", first, tile.Pos)
+		}
+		if len(b.Neighbours(tile.Pos)) > 0 {
+			touching = true
+		}
+	}
+	if !touching {
+		return "", errors.New("tiles do not touch any tile on the board")
+	}
+	return placed.Word(line), nil
+}
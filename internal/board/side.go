@@ -0,0 +1,72 @@
+package board
+
+// Sides seen after tilting the cube. They lie outside of the 0-359 range of
+// rotations around the cube, so they never mix with the four sides.
+const (
+	SideTop    = 360
+	SideBottom = 450
+)
+
+// Projects cube coordinates on the face seen from the side. Returns column and
+// row counted from the top left corner of the face and depth counted from the
+// face towards the opposite one.
+//
+//	0      column Y,     row X,     depth Z      // North
+//	90     column M - Z, row X,     depth Y      // East
+//	180    column M - Y, row X,     depth M - Z  // South
+//	270    column Z,     row X,     depth M - Y  // West
+//	Top    column Y,     row M - Z, depth X
+//	Bottom column Y,     row Z,     depth M - X
+//
+// where M is the last position on the board. Top and bottom are seen with the
+// north side tilted towards them, so the north edge is at the bottom of the
+// top face and at the top of the bottom face.
+func Project(size int, side int, pos Pos) (column int, row int, depth int) {
+	m := size - 1
+	x, y, z := pos[0], pos[1], pos[2]
+	switch side {
+	case 90:
+		return m - z, x, y
+	case 180:
+		return m - y, x, m - z
+	case 270:
+		return z, x, m - y
+	case SideTop:
+		return y, m - z, x
+	case SideBottom:
+		return y, z, m - x
+	default:
+		return y, x, z
+	}
+}
+
+// Reverses Project, returns cube coordinates of the position on the side
+func Unproject(size int, side int, column int, row int, depth int) Pos {
+	m := size - 1
+	switch side {
+	case 90:
+		return Pos{row, depth, m - column}
+	case 180:
+		return Pos{row, m - column, m - depth}
+	case 270:
+		return Pos{row, m - depth, column}
+	case SideTop:
+		return Pos{depth, column, m - row}
+	case SideBottom:
+		return Pos{m - depth, column, row}
+	default:
+		return Pos{row, column, depth}
+	}
+}
+
+// Cube axes along columns and rows of the face seen from the side
+func FaceAxes(side int) (column Axis, row Axis) {
+	switch side {
+	case 90, 270:
+		return AxisZ, AxisX
+	case SideTop, SideBottom:
+		return AxisY, AxisZ
+	default:
+		return AxisY, AxisX
+	}
+}
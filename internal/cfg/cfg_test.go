@@ -0,0 +1,143 @@
+package cfg
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func envFunc(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+}
+
+func writeConfigFile(t *testing.T, content string) string {
+	filePath := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(filePath, []byte(content), 0600); err != nil {
+		t.Fatalf("writing config file failed; %v", err)
+	}
+	return filePath
+}
+
+func TestLoadDefaults(t *testing.T) {
+	config, err := Load(nil, envFunc(nil))
+	if err != nil {
+		t.Fatalf("loading defaults failed; %v", err)
+	}
+	if !reflect.DeepEqual(*config, Default()) {
+		t.Errorf("expected defaults, got %+v", *config)
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	filePath := writeConfigFile(t, `{
+		"server": {"addr": ":7000"},
+		"database": {"file": "file.db", "reset": false},
+		"game": {"board_size": 9, "rack_size": 7},
+		"auth": {"player_token_ttl": "2h", "allowed_origins": ["https://file.example"]}
+	}`)
+	env := map[string]string{
+		"SCRABLE3_CONFIG":          filePath,
+		"SCRABLE3_ADDR":            ":7100",
+		"SCRABLE3_BOARD_SIZE":      "11",
+		"SCRABLE3_ALLOWED_ORIGINS": "https://env.example, https://other.example",
+	}
+	args := []string{"-addr", ":7200", "-secure-cookies"}
+
+	config, err := Load(args, envFunc(env))
+	if err != nil {
+		t.Fatalf("loading failed; %v", err)
+	}
+
+	testCases := []struct {
+		name     string
+		got      any
+		expected any
+	}{
+		{"flag over env", config.Server.Addr, ":7200"},
+		{"bool flag", config.Auth.SecureCookies, true},
+		{"env over file", config.Game.BoardSize, 11},
+		{"env list", config.Auth.AllowedOrigins, []string{"https://env.example", "https://other.example"}},
+		{"file over default", config.Database.File, "file.db"},
+		{"file bool", config.Database.Reset, false},
+		{"file int", config.Game.RackSize, 7},
+		{"file duration", config.Auth.PlayerTokenTTL, Duration(2 * time.Hour)},
+		{"default", config.Game.MinWordLen, 3},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tc.got, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, tc.got)
+			}
+		})
+	}
+}
+
+func TestLoadEmptyEnv(t *testing.T) {
+	env := map[string]string{"SCRABLE3_METRICS_ADDR": ""}
+	config, err := Load(nil, envFunc(env))
+	if err != nil {
+		t.Fatalf("loading failed; %v", err)
+	}
+	if config.Server.MetricsAddr != "" {
+		t.Errorf("expected metrics listener disabled, got %v", config.Server.MetricsAddr)
+	}
+}
+
+func TestLoadArgs(t *testing.T) {
+	args := []string{"-database-file", "other.db", "games", "-all"}
+	config, rest, err := LoadArgs(args, envFunc(nil))
+	if err != nil {
+		t.Fatalf("loading failed; %v", err)
+	}
+	if config.Database.File != "other.db" {
+		t.Errorf("expected database file other.db, got %v", config.Database.File)
+	}
+	if !reflect.DeepEqual(rest, []string{"games", "-all"}) {
+		t.Errorf("expected command arguments, got %v", rest)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		args []string
+		env  map[string]string
+	}{
+		{"unknown flag", []string{"-port", "80"}, nil},
+		{"public url without scheme", []string{"-public-url", "scrable3.example"}, nil},
+		{"bad flag value", []string{"-board-size", "big"}, nil},
+		{"bad env value", nil, map[string]string{"SCRABLE3_PLAYER_TOKEN_TTL": "day"}},
+		{"missing file", []string{"-config", "/nonexistent/config.json"}, nil},
+		{"unknown file field", []string{"-config", writeConfigFile(t, `{"port": 80}`)}, nil},
+		{"invalid board size", []string{"-board-size", "2"}, nil},
+		{"board smaller than games", []string{"-board-size", "4"}, nil},
+		{"board larger than games", []string{"-board-size", "22"}, nil},
+		{"one letter words", []string{"-min-word-len", "1"}, nil},
+		{"rack larger than games", []string{"-rack-size", "21"}, nil},
+		{"word longer than board", []string{"-min-word-len", "16"}, nil},
+		{"empty rack", nil, map[string]string{"SCRABLE3_RACK_SIZE": "0"}},
+		{"increment without bank", []string{"-time-increment", "5s"}, nil},
+		{"unknown log level", []string{"-log-level", "verbose"}, nil},
+		{"no shutdown timeout", []string{"-shutdown-timeout", "0s"}, nil},
+		{"unknown log format", nil, map[string]string{"SCRABLE3_LOG_FORMAT": "xml"}},
+		{"negative cleanup", []string{"-cleanup-finished-retention", "-1h"}, nil},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Load(tc.args, envFunc(tc.env)); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+
+	if _, err := Load([]string{"-help"}, envFunc(nil)); !errors.Is(err, flag.ErrHelp) {
+		t.Errorf("expected flag.ErrHelp, got %v", err)
+	}
+}
@@ -0,0 +1,363 @@
+package cfg
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"scrable3/internal/model"
+	"strings"
+	"time"
+)
+
+type Config struct {
+	Server   ServerConfig   `json:"server"`
+	Database DatabaseConfig `json:"database"`
+	Langs    LangsConfig    `json:"langs"`
+	Game     GameConfig     `json:"game"`
+	Auth     AuthConfig     `json:"auth"`
+	Notify   NotifyConfig   `json:"notify"`
+	Log      LogConfig      `json:"log"`
+	Cleanup  CleanupConfig  `json:"cleanup"`
+}
+
+type ServerConfig struct {
+	Addr       string `json:"addr"`
+	StaticDir  string `json:"static_dir"`
+	StylesDir  string `json:"styles_dir"`
+	ScriptsDir string `json:"scripts_dir"`
+	// Time for finishing plays and closing connections after a stop signal
+	ShutdownTimeout Duration `json:"shutdown_timeout"`
+	// Address /metrics is served on. Labels of the metrics hold game UUIDs that
+	// let anyone join the games, so it is kept apart from the game server.
+	// Empty turns metrics off.
+	MetricsAddr string `json:"metrics_addr"`
+	// Address players reach the server at, like https://scrable3.example.
+	// Links shared outside of the pages, such as board previews, are built
+	// from it rather than from the Host header. Empty leaves them out.
+	PublicURL string `json:"public_url"`
+}
+
+type DatabaseConfig struct {
+	File string `json:"file"`
+	// Remove the database file on startup
+	Reset bool `json:"reset"`
+}
+
+type LangsConfig struct {
+	// Directory with language packs, every *.json file is one pack
+	Dir string `json:"dir"`
+}
+
+// Default rules offered when creating a game
+type GameConfig struct {
+	BoardSize  int `json:"board_size"`
+	MinWordLen int `json:"min_word_len"`
+	// Code of the language pack
+	Language string `json:"language"`
+	// Number of available characters every player holds
+	RackSize    int   `json:"rack_size"`
+	PointsToWin int64 `json:"points_to_win"`
+	// Time for a single turn, zero without limit
+	TimeControl Duration `json:"time_control"`
+	// Time every player has for the whole game, zero without limit
+	TimeBank Duration `json:"time_bank"`
+	// Time added to the bank of the player after every turn
+	TimeIncrement Duration `json:"time_increment"`
+	// Accept words without checking them, opponents can challenge them
+	ChallengeMode bool `json:"challenge_mode"`
+	// Play turns from the game page without websocket
+	Correspondence bool `json:"correspondence"`
+	// Correspondence games where nobody played for this long are forfeited by
+	// the player that has the turn, zero never forfeits
+	ForfeitAfter Duration `json:"forfeit_after"`
+}
+
+type AuthConfig struct {
+	// Player token signing keys written as "id:secret,id:secret", the first
+	// key signs new tokens
+	PlayerTokenKeys string   `json:"player_token_keys"`
+	PlayerTokenTTL  Duration `json:"player_token_ttl"`
+	SecureCookies   bool     `json:"secure_cookies"`
+	AllowedOrigins  []string `json:"allowed_origins"`
+	// Logins of users allowed to see admin pages
+	AdminLogins []string `json:"admin_logins"`
+}
+
+type NotifyConfig struct {
+	// File notifications are appended to, standard output when empty
+	File string `json:"file"`
+}
+
+// Deleting games that are not needed anymore, zero keeps them forever
+type CleanupConfig struct {
+	// Unfinished games nobody updated for this long are deleted
+	AbandonedAfter Duration `json:"abandoned_after"`
+	// Finished games are deleted this long after they finished
+	FinishedRetention Duration `json:"finished_retention"`
+}
+
+type LogConfig struct {
+	// Lowest level written: debug, info, warn or error
+	Level string `json:"level"`
+	// Either text or json
+	Format string `json:"format"`
+}
+
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Addr:            ":8080",
+			StaticDir:       "static",
+			StylesDir:       "styles",
+			ScriptsDir:      "scripts",
+			ShutdownTimeout: Duration(15 * time.Second),
+			MetricsAddr:     "localhost:9091",
+		},
+		Database: DatabaseConfig{
+			File:  "sqlite.db",
+			Reset: false,
+		},
+		Langs: LangsConfig{
+			Dir: "langs",
+		},
+		Game: GameConfig{
+			BoardSize:    15,
+			MinWordLen:   3,
+			Language:     "en",
+			RackSize:     9,
+			PointsToWin:  20,
+			ForfeitAfter: Duration(7 * 24 * time.Hour),
+		},
+		Auth: AuthConfig{
+			PlayerTokenTTL: Duration(24 * time.Hour),
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: "text",
+		},
+		Cleanup: CleanupConfig{
+			AbandonedAfter:    Duration(30 * 24 * time.Hour),
+			FinishedRetention: Duration(90 * 24 * time.Hour),
+		},
+	}
+}
+
+// |PRIVATE| //
+
+// Settable option, available as a "-name" flag and a SCRABLE3_NAME variable
+type option struct {
+	name  string
+	usage string
+	value flag.Value
+}
+
+func (o *option) envName() string {
+	return "SCRABLE3_" + strings.ToUpper(strings.ReplaceAll(o.name, "-", "_"))
+}
+
+func (c *Config) options() []option {
+	return []option{
+		{"addr", "address the server listens on", stringValue{&c.Server.Addr}},
+		{"static-dir", "directory served under /static/", stringValue{&c.Server.StaticDir}},
+		{"styles-dir", "directory served under /styles/", stringValue{&c.Server.StylesDir}},
+		{"scripts-dir", "directory served under /scripts/", stringValue{&c.Server.ScriptsDir}},
+		{"shutdown-timeout", "time for finishing plays and closing connections after a stop signal", durationValue{&c.Server.ShutdownTimeout}},
+		{"metrics-addr", "address /metrics is served on, apart from the game server, empty turns it off", stringValue{&c.Server.MetricsAddr}},
+		{"public-url", "address players reach the server at, used in board preview links, empty leaves them out", stringValue{&c.Server.PublicURL}},
+		{"database-file", "sqlite database file", stringValue{&c.Database.File}},
+		{"database-reset", "remove the database file on startup", boolValue{&c.Database.Reset}},
+		{"langs-dir", "directory with language packs", stringValue{&c.Langs.Dir}},
+		{"board-size", "default number of fields along every edge of the cube", intValue{&c.Game.BoardSize}},
+		{"min-word-len", "default shortest word accepted in play", intValue{&c.Game.MinWordLen}},
+		{"language", "default language pack code", stringValue{&c.Game.Language}},
+		{"rack-size", "default number of available characters of every player", intValue{&c.Game.RackSize}},
+		{"points-to-win", "default points needed to win the game", int64Value{&c.Game.PointsToWin}},
+		{"time-control", "default time for a single turn, 0 without limit", durationValue{&c.Game.TimeControl}},
+		{"time-bank", "default time every player has for the whole game, 0 without limit", durationValue{&c.Game.TimeBank}},
+		{"time-increment", "default time added to the bank of the player after every turn", durationValue{&c.Game.TimeIncrement}},
+		{"challenge-mode", "accept words without checking them, opponents can challenge them", boolValue{&c.Game.ChallengeMode}},
+		{"correspondence", "play turns from the game page without websocket", boolValue{&c.Game.Correspondence}},
+		{"forfeit-after", "inactivity after which correspondence games are forfeited, 0 never", durationValue{&c.Game.ForfeitAfter}},
+		{"player-token-keys", "player token signing keys, id:secret,id:secret", stringValue{&c.Auth.PlayerTokenKeys}},
+		{"player-token-ttl", "player token lifetime, at least forfeit-after in correspondence games", durationValue{&c.Auth.PlayerTokenTTL}},
+		{"secure-cookies", "send cookies only over HTTPS", boolValue{&c.Auth.SecureCookies}},
+		{"allowed-origins", "comma separated origins allowed to connect, same host when empty", listValue{&c.Auth.AllowedOrigins}},
+		{"admin-logins", "comma separated logins of users allowed to see admin pages", listValue{&c.Auth.AdminLogins}},
+		{"notify-file", "file notifications are appended to, standard output when empty", stringValue{&c.Notify.File}},
+		{"log-level", "lowest level written to the log, debug, info, warn or error", stringValue{&c.Log.Level}},
+		{"log-format", "log format, text or json", stringValue{&c.Log.Format}},
+		{"cleanup-abandoned-after", "time without updates after which unfinished games are deleted, 0 never", durationValue{&c.Cleanup.AbandonedAfter}},
+		{"cleanup-finished-retention", "time finished games are kept for, 0 forever", durationValue{&c.Cleanup.FinishedRetention}},
+	}
+}
+
+func (c *Config) loadFile(filePath string) error {
+	fl, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer fl.Close()
+
+	decoder := json.NewDecoder(fl)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(c); err != nil {
+		return fmt.Errorf("config file %v: %w", filePath, err)
+	}
+	return nil
+}
+
+// |PUBLIC| //
+
+// Loads configuration with precedence: flags, environment variables, config
+// file (-config flag or SCRABLE3_CONFIG), defaults. 'lookupEnv' works like
+// os.LookupEnv, variables that are set but empty override the defaults too.
+func Load(args []string, lookupEnv func(string) (string, bool)) (*Config, error) {
+	config, _, err := LoadArgs(args, lookupEnv)
+	return config, err
+}
+
+// Loads configuration like Load and returns arguments left after the flags,
+// used by tools that take a command after the options
+func LoadArgs(
+	args []string,
+	lookupEnv func(string) (string, bool),
+) (*Config, []string, error) {
+	// Flags are parsed first to find the config file. Their values are kept
+	// aside and applied again after the file and environment.
+	var configFile string
+	parsed := Default()
+	fs := flag.NewFlagSet("scrable3", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	envConfigFile, _ := lookupEnv("SCRABLE3_CONFIG")
+	fs.StringVar(&configFile, "config", envConfigFile, "JSON config file")
+	for _, o := range parsed.options() {
+		fs.Var(o.value, o.name, o.usage)
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+	flagValues := make(map[string]string)
+	fs.Visit(func(f *flag.Flag) {
+		flagValues[f.Name] = f.Value.String()
+	})
+
+	config := Default()
+	if configFile != "" {
+		if err := config.loadFile(configFile); err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, o := range config.options() {
+		if value, ok := lookupEnv(o.envName()); ok {
+			if err := o.value.Set(value); err != nil {
+				return nil, nil, fmt.Errorf("%v: %w", o.envName(), err)
+			}
+		}
+		if value, ok := flagValues[o.name]; ok {
+			if err := o.value.Set(value); err != nil {
+				return nil, nil, fmt.Errorf("-%v: %w", o.name, err)
+			}
+		}
+	}
+
+	return &config, fs.Args(), config.Validate()
+}
+
+// Writes description of every option, used as -help output
+func Usage(w io.Writer) {
+	config := Default()
+	fmt.Fprintf(w, "  -config\n\tJSON config file (SCRABLE3_CONFIG)\n")
+	for _, o := range config.options() {
+		fmt.Fprintf(
+			w, "  -%v\n\t%v (%v, default %q)\n",
+			o.name, o.usage, o.envName(), o.value.String(),
+		)
+	}
+}
+
+func (c *Config) Validate() error {
+	var errs []error
+	if c.Server.Addr == "" {
+		errs = append(errs, errors.New("addr cannot be empty"))
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		errs = append(errs, errors.New("shutdown-timeout should be positive"))
+	}
+	if c.Server.PublicURL != "" {
+		publicURL, err := url.Parse(c.Server.PublicURL)
+		if err != nil ||
+			(publicURL.Scheme != "http" && publicURL.Scheme != "https") ||
+			publicURL.Host == "" {
+			errs = append(errs, fmt.Errorf(
+				"public-url should be an http or https address, not %q",
+				c.Server.PublicURL))
+		}
+	}
+	if c.Database.File == "" {
+		errs = append(errs, errors.New("database-file cannot be empty"))
+	}
+	if c.Langs.Dir == "" {
+		errs = append(errs, errors.New("langs-dir cannot be empty"))
+	}
+	// Limits of the rules every game is created with
+	if c.Game.BoardSize < model.MinBoardSize || c.Game.BoardSize > model.MaxBoardSize {
+		errs = append(errs, fmt.Errorf(
+			"board-size should be between %v and %v, not %v",
+			model.MinBoardSize, model.MaxBoardSize, c.Game.BoardSize))
+	}
+	if c.Game.MinWordLen < model.ShortestWordLen || c.Game.MinWordLen > c.Game.BoardSize {
+		errs = append(errs, fmt.Errorf(
+			"min-word-len should be between %v and board-size, not %v",
+			model.ShortestWordLen, c.Game.MinWordLen))
+	}
+	if c.Game.Language == "" {
+		errs = append(errs, errors.New("language cannot be empty"))
+	}
+	if c.Game.RackSize < 1 || c.Game.RackSize > model.MaxRackSize {
+		errs = append(errs, fmt.Errorf(
+			"rack-size should be between 1 and %v, not %v",
+			model.MaxRackSize, c.Game.RackSize))
+	}
+	if c.Game.PointsToWin < 1 {
+		errs = append(errs, fmt.Errorf(
+			"points-to-win should be at least 1, not %v", c.Game.PointsToWin))
+	}
+	if c.Game.TimeControl < 0 {
+		errs = append(errs, errors.New("time-control cannot be negative"))
+	}
+	if c.Game.TimeBank < 0 {
+		errs = append(errs, errors.New("time-bank cannot be negative"))
+	}
+	if c.Game.TimeIncrement < 0 {
+		errs = append(errs, errors.New("time-increment cannot be negative"))
+	}
+	if c.Game.TimeIncrement > 0 && c.Game.TimeBank == 0 {
+		errs = append(errs, errors.New("time-increment needs time-bank"))
+	}
+	if c.Game.ForfeitAfter < 0 {
+		errs = append(errs, errors.New("forfeit-after cannot be negative"))
+	}
+	if c.Cleanup.AbandonedAfter < 0 {
+		errs = append(errs, errors.New("cleanup-abandoned-after cannot be negative"))
+	}
+	if c.Cleanup.FinishedRetention < 0 {
+		errs = append(errs, errors.New("cleanup-finished-retention cannot be negative"))
+	}
+	if c.Auth.PlayerTokenTTL <= 0 {
+		errs = append(errs, errors.New("player-token-ttl should be positive"))
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.Log.Level)); err != nil {
+		errs = append(errs, fmt.Errorf("log-level: %w", err))
+	}
+	if c.Log.Format != "text" && c.Log.Format != "json" {
+		errs = append(errs, fmt.Errorf(
+			"log-format should be text or json, not %q", c.Log.Format))
+	}
+	return errors.Join(errs...)
+}
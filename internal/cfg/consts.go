@@ -1,8 +0,0 @@
-package cfg
-
-const (
-	BOARD_SIZE                  int    = 15
-	MIN_WORD_LEN                int    = 3
-	ALLOWED_CHARACTERS          string = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
-	AVAILABLE_CHARACTERS_NUMBER int    = 9
-)
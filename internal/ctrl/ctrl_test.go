@@ -1,25 +1,64 @@
 package ctrl
 
 import (
+	"bytes"
+	"io"
 	"os"
 	"path/filepath"
 	"reflect"
+	"scrable3/internal/board"
 	"scrable3/internal/dto"
+	"scrable3/internal/lang"
+	"scrable3/internal/logging"
+	"scrable3/internal/metrics"
 	"scrable3/internal/mock"
 	"scrable3/internal/model"
+	"scrable3/internal/notify"
+	"scrable3/internal/renderer"
+	"scrable3/internal/repo"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"go.uber.org/mock/gomock"
 )
 
+var testRules = model.GameRules{
+	BoardSize:   15,
+	RackSize:    9,
+	MinWordLen:  3,
+	PointsToWin: 20,
+	Language:    "en",
+	Alphabet:    "ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+}
+
+// Game service returning the house rule words for every game
+func mockGameWordService(
+	t *testing.T,
+	gameWords []model.GameWord,
+) *mock.MockGameWordService {
+	gameWordService := mock.NewMockGameWordService(gomock.NewController(t))
+	gameWordService.EXPECT().
+		GetWithGameUUID(gomock.Any()).
+		Return(&gameWords, nil).
+		AnyTimes()
+	return gameWordService
+}
+
 func setupGameControllerImplementation(t *testing.T) *gameController {
 	mockController := gomock.NewController(t)
 	return &gameController{
 		mock.NewMockWordsController(mockController),
+		mock.NewMockGameService(mockController),
 		mock.NewMockPlayerService(mockController),
 		mock.NewMockFieldService(mockController),
 		mock.NewMockAvCharService(mockController),
+		mock.NewMockPlayService(mockController),
+		lang.Packs{},
+		notify.NewLogNotifier(io.Discard),
+		logging.Discard(),
+		metrics.New(),
 	}
 }
 
@@ -115,7 +154,7 @@ func TestMakeExistingCharsInStraightAxisMap(t *testing.T) {
 				6: "E",
 			},
 		},
-		{ // * 5 Y not straigth and diff side *
+		{ // * 5 Y not straigth and diff side, reversed Y as column *
 			fields: []model.Field{
 				{Value: "Q", PosX: 3, PosY: 4, PosZ: 3},
 				{Value: "W", PosX: 3, PosY: 5, PosZ: 2},
@@ -125,9 +164,9 @@ func TestMakeExistingCharsInStraightAxisMap(t *testing.T) {
 			straightAxisId:     1,
 			sideInt:            180,
 			idealOutput: map[int]string{
-				4: "Q",
-				5: "W",
-				6: "E",
+				10: "Q",
+				9:  "W",
+				8:  "E",
 			},
 		},
 		{ // * 6 check reverse depth *
@@ -136,7 +175,7 @@ func TestMakeExistingCharsInStraightAxisMap(t *testing.T) {
 				{Value: "W", PosX: 4, PosY: 6, PosZ: 2},
 				{Value: "E", PosX: 4, PosY: 6, PosZ: 1},
 			},
-			straightAxisNumber: 6,
+			straightAxisNumber: 8,
 			straightAxisId:     0,
 			sideInt:            180,
 			idealOutput: map[int]string{
@@ -154,92 +193,86 @@ func TestMakeExistingCharsInStraightAxisMap(t *testing.T) {
 			sideInt:            180,
 			idealOutput:        map[int]string{},
 		},
-		{ // * 8 biggest X as depth *
+		{ // * 8 smallest Y as depth *
 			fields: []model.Field{
-				{Value: "Q", PosX: 4, PosY: 12, PosZ: 3},
-				{Value: "W", PosX: 4, PosY: 12, PosZ: 2},
-				{Value: "E", PosX: 4, PosY: 12, PosZ: 1},
-				{Value: "R", PosX: 5, PosY: 12, PosZ: 1},
-				{Value: "T", PosX: 6, PosY: 12, PosZ: 1},
-				{Value: "Y", PosX: 1, PosY: 12, PosZ: 5},
-				{Value: "U", PosX: 2, PosY: 12, PosZ: 5},
-				{Value: "I", PosX: 15, PosY: 12, PosZ: 5},
-				{Value: "O", PosX: 14, PosY: 12, PosZ: 5},
+				{Value: "Q", PosX: 4, PosY: 3, PosZ: 2},
+				{Value: "W", PosX: 4, PosY: 2, PosZ: 2},
+				{Value: "E", PosX: 4, PosY: 1, PosZ: 2},
+				{Value: "R", PosX: 5, PosY: 9, PosZ: 2},
+				{Value: "T", PosX: 6, PosY: 0, PosZ: 2},
+				{Value: "Y", PosX: 1, PosY: 5, PosZ: 5},
+				{Value: "U", PosX: 2, PosY: 12, PosZ: 3},
+				{Value: "I", PosX: 14, PosY: 5, PosZ: 2},
+				{Value: "O", PosX: 14, PosY: 4, PosZ: 2},
 			},
 			straightAxisNumber: 12,
 			straightAxisId:     0,
 			sideInt:            90,
 			idealOutput: map[int]string{
-				3: "Q",
-				2: "W",
-				1: "T",
-				5: "I",
+				4:  "E",
+				5:  "R",
+				6:  "T",
+				14: "O",
 			},
 		},
-		{ // * 9 smallest X as depth *
+		{ // * 9 biggest Y as depth *
 			fields: []model.Field{
-				{Value: "Q", PosX: 4, PosY: 12, PosZ: 3},
-				{Value: "W", PosX: 4, PosY: 12, PosZ: 2},
-				{Value: "E", PosX: 4, PosY: 12, PosZ: 1},
-				{Value: "R", PosX: 5, PosY: 12, PosZ: 1},
-				{Value: "T", PosX: 6, PosY: 12, PosZ: 1},
-				{Value: "Y", PosX: 1, PosY: 12, PosZ: 5},
-				{Value: "U", PosX: 2, PosY: 12, PosZ: 5},
-				{Value: "I", PosX: 15, PosY: 12, PosZ: 5},
-				{Value: "O", PosX: 14, PosY: 12, PosZ: 5},
+				{Value: "Q", PosX: 4, PosY: 3, PosZ: 2},
+				{Value: "W", PosX: 4, PosY: 2, PosZ: 2},
+				{Value: "E", PosX: 4, PosY: 1, PosZ: 2},
+				{Value: "R", PosX: 5, PosY: 9, PosZ: 2},
+				{Value: "T", PosX: 6, PosY: 0, PosZ: 2},
+				{Value: "Y", PosX: 1, PosY: 5, PosZ: 5},
+				{Value: "U", PosX: 2, PosY: 12, PosZ: 3},
+				{Value: "I", PosX: 14, PosY: 5, PosZ: 2},
+				{Value: "O", PosX: 14, PosY: 4, PosZ: 2},
 			},
-			straightAxisNumber: 12,
+			straightAxisNumber: 2,
 			straightAxisId:     0,
 			sideInt:            270,
 			idealOutput: map[int]string{
-				3: "Q",
-				2: "W",
-				1: "E",
-				5: "Y",
+				4:  "Q",
+				5:  "R",
+				6:  "T",
+				14: "I",
 			},
 		},
-		{ // * 10 *
+		{ // * 10 row on east side, reversed Z as column *
 			fields: []model.Field{
-				{Value: "Q", PosX: 6, PosY: 1, PosZ: 3},
+				{Value: "Q", PosX: 3, PosY: 1, PosZ: 3},
 				{Value: "W", PosX: 3, PosY: 2, PosZ: 1},
-				{Value: "E", PosX: 9, PosY: 3, PosZ: 1},
-				{Value: "R", PosX: 3, PosY: 4, PosZ: 1},
-				{Value: "T", PosX: 6, PosY: 4, PosZ: 1},
-				{Value: "Y", PosX: 10, PosY: 7, PosZ: 5},
-				{Value: "U", PosX: 9, PosY: 8, PosZ: 1},
-				{Value: "I", PosX: 15, PosY: 8, PosZ: 1},
-				{Value: "O", PosX: 4, PosY: 9, PosZ: 5},
+				{Value: "E", PosX: 3, PosY: 0, PosZ: 1},
+				{Value: "R", PosX: 3, PosY: 4, PosZ: 4},
+				{Value: "T", PosX: 6, PosY: 4, PosZ: 4},
+				{Value: "Y", PosX: 3, PosY: 7, PosZ: 14},
 			},
-			straightAxisNumber: 1,
+			straightAxisNumber: 3,
 			straightAxisId:     1,
 			sideInt:            90,
 			idealOutput: map[int]string{
-				2: "W",
-				3: "E",
-				4: "T",
-				8: "I",
+				11: "Q",
+				13: "E",
+				10: "R",
+				0:  "Y",
 			},
 		},
-		{ // * 11 *
+		{ // * 11 row on west side, Z as column *
 			fields: []model.Field{
-				{Value: "Q", PosX: 6, PosY: 1, PosZ: 3},
+				{Value: "Q", PosX: 3, PosY: 1, PosZ: 3},
 				{Value: "W", PosX: 3, PosY: 2, PosZ: 1},
-				{Value: "E", PosX: 9, PosY: 3, PosZ: 1},
-				{Value: "R", PosX: 3, PosY: 4, PosZ: 1},
-				{Value: "T", PosX: 6, PosY: 4, PosZ: 1},
-				{Value: "Y", PosX: 10, PosY: 7, PosZ: 5},
-				{Value: "U", PosX: 9, PosY: 8, PosZ: 1},
-				{Value: "I", PosX: 15, PosY: 8, PosZ: 1},
-				{Value: "O", PosX: 4, PosY: 9, PosZ: 5},
+				{Value: "E", PosX: 3, PosY: 0, PosZ: 1},
+				{Value: "R", PosX: 3, PosY: 4, PosZ: 4},
+				{Value: "T", PosX: 6, PosY: 4, PosZ: 4},
+				{Value: "Y", PosX: 3, PosY: 7, PosZ: 14},
 			},
-			straightAxisNumber: 1,
+			straightAxisNumber: 3,
 			straightAxisId:     1,
 			sideInt:            270,
 			idealOutput: map[int]string{
-				2: "W",
-				3: "E",
-				4: "R",
-				8: "U",
+				3:  "Q",
+				1:  "W",
+				4:  "R",
+				14: "Y",
 			},
 		},
 		{ // * 12 *
@@ -259,16 +292,51 @@ func TestMakeExistingCharsInStraightAxisMap(t *testing.T) {
 			sideInt:            270,
 			idealOutput:        map[int]string{},
 		},
+		{ // * 13 top with X as depth *
+			fields: []model.Field{
+				{Value: "Q", PosX: 2, PosY: 5, PosZ: 3},
+				{Value: "W", PosX: 1, PosY: 5, PosZ: 3},
+				{Value: "E", PosX: 9, PosY: 5, PosZ: 0},
+				{Value: "R", PosX: 0, PosY: 6, PosZ: 0},
+			},
+			straightAxisNumber: 5,
+			straightAxisId:     0,
+			sideInt:            board.SideTop,
+			idealOutput: map[int]string{
+				11: "W",
+				14: "E",
+			},
+		},
+		{ // * 14 bottom with X as depth *
+			fields: []model.Field{
+				{Value: "Q", PosX: 2, PosY: 5, PosZ: 3},
+				{Value: "W", PosX: 1, PosY: 5, PosZ: 3},
+				{Value: "E", PosX: 9, PosY: 5, PosZ: 0},
+				{Value: "R", PosX: 0, PosY: 6, PosZ: 0},
+			},
+			straightAxisNumber: 5,
+			straightAxisId:     0,
+			sideInt:            board.SideBottom,
+			idealOutput: map[int]string{
+				3: "Q",
+				0: "E",
+			},
+		},
 	}
-	for i, data := range datasets[len(datasets)-1:] {
+	for i, data := range datasets {
 		existingChars, existingCharsDetph := gc.makeCharsInStraightAxisFromFieldsMap(
+			&testRules,
 			&data.fields,
 			data.straightAxisNumber,
 			data.straightAxisId,
 			data.sideInt,
 		)
 		if !reflect.DeepEqual(existingChars, &data.idealOutput) {
-			t.Errorf("%v. %v %v", i, existingChars, &data.idealOutput)
+			t.Errorf(
+				"%v. %v %v\n%v",
+				i, existingChars, &data.idealOutput,
+				renderer.FieldsFace(testRules.BoardSize, data.fields, data.sideInt),
+			)
 		}
 		if len(*existingChars) != len(*existingCharsDetph) {
 			t.Errorf(
@@ -312,7 +380,7 @@ func TestFindDepthFromLeftMostPosition(t *testing.T) {
 		},
 	}
 	for i, dataset := range datasets {
-		depth := gc.findDepthFromLeftMostPosition(&dataset.data)
+		depth := gc.findDepthFromLeftMostPosition(&testRules, &dataset.data)
 		if depth != dataset.idealOutput {
 			t.Errorf("%v. wrong output; Returned: %v; IdealOutput: %v",
 				i, depth, dataset.idealOutput,
@@ -340,23 +408,36 @@ func TestCreateFieldData(t *testing.T) {
 			char:        dto.Char{Value: "Q", Position: [2]int{2, 1}},
 			sideInt:     90,
 			depthLevel:  3,
-			idealOutput: dto.FieldData{Value: "Q", Pos: [3]int{3, 2, 1}},
+			idealOutput: dto.FieldData{Value: "Q", Pos: [3]int{1, 3, 12}},
 		},
 		{
 			char:        dto.Char{Value: "Q", Position: [2]int{2, 1}},
 			sideInt:     180,
 			depthLevel:  3,
-			idealOutput: dto.FieldData{Value: "Q", Pos: [3]int{13, 2, 3}},
+			idealOutput: dto.FieldData{Value: "Q", Pos: [3]int{1, 12, 11}},
 		},
 		{
 			char:        dto.Char{Value: "Q", Position: [2]int{2, 1}},
 			sideInt:     270,
 			depthLevel:  3,
+			idealOutput: dto.FieldData{Value: "Q", Pos: [3]int{1, 11, 2}},
+		},
+		{
+			char:        dto.Char{Value: "Q", Position: [2]int{2, 1}},
+			sideInt:     board.SideTop,
+			depthLevel:  3,
 			idealOutput: dto.FieldData{Value: "Q", Pos: [3]int{3, 2, 13}},
 		},
+		{
+			char:        dto.Char{Value: "Q", Position: [2]int{2, 1}},
+			sideInt:     board.SideBottom,
+			depthLevel:  3,
+			idealOutput: dto.FieldData{Value: "Q", Pos: [3]int{11, 2, 1}},
+		},
 	}
 	for i, dataset := range datasets {
 		fieldData := gc.createFieldData(
+			&testRules,
 			&dataset.char,
 			dataset.sideInt,
 			dataset.depthLevel,
@@ -370,6 +451,67 @@ func TestCreateFieldData(t *testing.T) {
 	}
 }
 
+func TestPlaySide(t *testing.T) {
+	gc := setupGameControllerImplementation(t)
+	testCases := []struct {
+		pl       dto.PlayData
+		expected int
+		isErr    bool
+	}{
+		{pl: dto.PlayData{SideInt: 90}, expected: 90},
+		{pl: dto.PlayData{SideInt: -90}, expected: 90},
+		{pl: dto.PlayData{SideInt: 630}, expected: 270},
+		{pl: dto.PlayData{SideInt: 90, Tilt: 90}, expected: board.SideTop},
+		{pl: dto.PlayData{SideInt: 180, Tilt: -90}, expected: board.SideBottom},
+		{pl: dto.PlayData{SideInt: 45}, isErr: true},
+		{pl: dto.PlayData{Tilt: 45}, isErr: true},
+	}
+	for i, tc := range testCases {
+		side, err := gc.playSide(&tc.pl)
+		if (err != nil) != tc.isErr {
+			t.Errorf("%v. expected error %v, got %v", i, tc.isErr, err)
+		}
+		if side != tc.expected {
+			t.Errorf("%v. expected side %v, got %v", i, tc.expected, side)
+		}
+	}
+}
+
+// Field created from a char placed on a side is seen from that side at the
+// same column, row and depth, so later plays on the side connect with it
+func TestCreatedFieldSeenFromItsSide(t *testing.T) {
+	gc := setupGameControllerImplementation(t)
+	char := dto.Char{Value: "Q", Position: [2]int{2, 1}}
+	depthLevel := 3
+	for _, sideInt := range []int{0, 90, 180, 270, board.SideTop, board.SideBottom} {
+		fieldData := gc.createFieldData(&testRules, &char, sideInt, depthLevel)
+		fields := []model.Field{{
+			Value: fieldData.Value,
+			PosX:  fieldData.Pos[0],
+			PosY:  fieldData.Pos[1],
+			PosZ:  fieldData.Pos[2],
+		}}
+		for straightAxisId := range 2 {
+			existingChars, depths := gc.makeCharsInStraightAxisFromFieldsMap(
+				&testRules,
+				&fields,
+				char.Position[straightAxisId],
+				straightAxisId,
+				sideInt,
+			)
+			changingPos := char.Position[straightAxisId^1]
+			if (*existingChars)[changingPos] != "Q" ||
+				(*depths)[changingPos] != depthLevel {
+				t.Errorf(
+					"side %v, axis %v; field %v seen as %v at depths %v",
+					sideInt, straightAxisId, fieldData.Pos,
+					*existingChars, *depths,
+				)
+			}
+		}
+	}
+}
+
 func TestValidCheckChars(t *testing.T) {
 	gc := setupGameControllerImplementation(t)
 	mockAvChars := &[]model.AvChar{
@@ -414,7 +556,7 @@ func TestValidCheckChars(t *testing.T) {
 		},
 	}
 	for i, chars := range validChars {
-		err := gc.checkChars(uuid.UUID{}, &chars)
+		err := gc.checkChars(&testRules, uuid.UUID{}, &chars)
 		if err != nil {
 			t.Errorf("Check() number %v failed; %v", i+1, err)
 		}
@@ -488,6 +630,21 @@ func TestInvalidCheckChars(t *testing.T) {
 			},
 			expectedErr: "fields overlap or are not aligned; Y: 5 X: 1",
 		},
+		{
+			name: "position outside of the board",
+			fields: []dto.Char{
+				{Value: "Q", Position: [2]int{14, 6}, HtmlIdentifier: "char-Q1"},
+				{Value: "W", Position: [2]int{15, 6}, HtmlIdentifier: "char-W2"},
+			},
+			expectedErr: "char char-W2 position [15 6] is outside of the board",
+		},
+		{
+			name: "negative position",
+			fields: []dto.Char{
+				{Value: "Q", Position: [2]int{3, -1}, HtmlIdentifier: "char-Q1"},
+			},
+			expectedErr: "char char-Q1 position [3 -1] is outside of the board",
+		},
 		{
 			name: "character not in available characters",
 			fields: []dto.Char{
@@ -499,7 +656,7 @@ func TestInvalidCheckChars(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			err := gc.checkChars(uuid.UUID{}, &tc.fields)
+			err := gc.checkChars(&testRules, uuid.UUID{}, &tc.fields)
 			if err == nil {
 				t.Error("expected error, got nil")
 				return
@@ -516,22 +673,71 @@ func TestLoadWords(t *testing.T) {
 	if err != nil {
 		t.Errorf("geting current working directory failed; %v", err)
 	}
-	filePath := filepath.Join(
-		cwd, "..", "..", "words", "words_alpha.txt",
-	)
+	packs, err := lang.LoadPacks(filepath.Join(cwd, "..", "..", "langs"))
+	if err != nil {
+		t.Fatalf("loading language packs failed; %v", err)
+	}
+	pack, err := packs.Get("en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Words lists are downloaded separately
+	if _, err := os.Stat(pack.WordsFile); err != nil {
+		t.Skipf("no words list; %v", err)
+	}
 
 	// Init wordsController
-	wordsController, err := NewWordsController(filePath)
+	wordsController, err := NewWordsController(
+		lang.Packs{"en": pack},
+		mockGameWordService(t, nil),
+	)
 	if err != nil {
 		t.Errorf("loading words failed; %v", err)
 	}
 
 	// Test if not empty
-	if wordsController.WordsNumber() == 0 {
+	if wordsController.WordsNumber("en") == 0 {
 		t.Error("Empty words.Map")
 	}
 }
 
+func TestLoadWordsWithDiacritics(t *testing.T) {
+	// "żółw" with decomposed letters and "gęś" upper case
+	filePath := filepath.Join(t.TempDir(), "slowa.txt")
+	content := "z\u0307o\u0301\u0142w\nGĘŚ\n\n"
+	if err := os.WriteFile(filePath, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	packs := lang.Packs{"pl": &lang.Pack{Code: "pl", WordsFile: filePath}}
+
+	wordsController, err := NewWordsController(
+		packs,
+		mockGameWordService(t, nil),
+	)
+	if err != nil {
+		t.Fatalf("loading words failed; %v", err)
+	}
+	if wordsController.WordsNumber("pl") != 2 {
+		t.Errorf("expected 2 words, got %v", wordsController.WordsNumber("pl"))
+	}
+
+	rules := testRules
+	rules.Language = "pl"
+	rules.MinWordLen = 4
+	for _, word := range []string{"ŻÓŁW", "żółw", "z\u0307o\u0301łw"} {
+		if err := wordsController.CheckWord(&model.Game{Rules: rules}, word); err != nil {
+			t.Errorf("`%v` not checked as word; %v", word, err)
+		}
+	}
+	// Three letters written with five code points
+	if err := wordsController.CheckWord(&model.Game{Rules: rules}, "ge\u0328s\u0301"); err == nil {
+		t.Error("word shorter than minimum word length checked as word")
+	}
+	if err := wordsController.CheckWord(&model.Game{Rules: testRules}, "żółw"); err == nil {
+		t.Error("word checked with words list of another language")
+	}
+}
+
 func TestCheckWord(t *testing.T) {
 	realWords := []string{
 		"breeze",
@@ -559,12 +765,13 @@ func TestCheckWord(t *testing.T) {
 		wMap[word] = true
 	}
 	wordsController := wordsController{
-		words: &wMap,
+		words:           map[string]map[string]bool{"en": wMap},
+		gameWordService: mockGameWordService(t, nil),
 	}
 
 	// Check Words.CheckWord() with real words
 	for _, word := range realWords {
-		err := wordsController.CheckWord(word)
+		err := wordsController.CheckWord(&model.Game{Rules: testRules}, word)
 		if err != nil {
 			t.Errorf("`%v` not checked as word; %v", word, err.Error())
 		}
@@ -572,9 +779,417 @@ func TestCheckWord(t *testing.T) {
 
 	// Check Words.CheckWord() with not real words
 	for _, notWord := range notRealWords {
-		err := wordsController.CheckWord(notWord)
+		err := wordsController.CheckWord(&model.Game{Rules: testRules}, notWord)
 		if err == nil {
 			t.Errorf("`%v` checked as word", notWord)
 		}
 	}
+
+	// Check Words.CheckWord() with rules of the game
+	longWordsRules := testRules
+	longWordsRules.MinWordLen = 6
+	if err := wordsController.CheckWord(&model.Game{Rules: longWordsRules}, "echo"); err == nil {
+		t.Error("`echo` shorter than minimum word length checked as word")
+	}
+	if err := wordsController.CheckWord(&model.Game{Rules: longWordsRules}, "galaxy"); err != nil {
+		t.Errorf("`galaxy` not checked as word; %v", err)
+	}
+}
+
+func TestCheckWordWithGameWords(t *testing.T) {
+	game := &model.Game{UUID: uuid.New(), Rules: testRules}
+	wordsController := wordsController{
+		words: map[string]map[string]bool{
+			"en": {"echo": true, "galaxy": true},
+		},
+		gameWordService: mockGameWordService(t, []model.GameWord{
+			{GameUUID: game.UUID, Word: "houseapple", Allowed: true},
+			{GameUUID: game.UUID, Word: "echo", Allowed: false},
+			{GameUUID: game.UUID, Word: "zz", Allowed: true},
+		}),
+	}
+
+	testCases := []struct {
+		word    string
+		allowed bool
+	}{
+		{"galaxy", true},     // base layer
+		{"HOUSEAPPLE", true}, // allowed by the game
+		{"echo", false},      // blocked by the game
+		{"briize", false},    // on neither layer
+		{"zz", false},        // allowed but too short
+	}
+	for _, tc := range testCases {
+		err := wordsController.CheckWord(game, tc.word)
+		if tc.allowed && err != nil {
+			t.Errorf("`%v` not checked as word; %v", tc.word, err)
+		}
+		if !tc.allowed && err == nil {
+			t.Errorf("`%v` checked as word", tc.word)
+		}
+	}
+}
+
+func TestCheckCharsWithGameAlphabet(t *testing.T) {
+	gc := setupGameControllerImplementation(t)
+	gc.avCharService.(*mock.MockAvCharService).
+		EXPECT().
+		GetWithPlayerUUID(uuid.UUID{}).
+		Return(&[]model.AvChar{{ID: 1, Value: "A"}, {ID: 2, Value: "Z"}}, nil).
+		AnyTimes()
+
+	rules := testRules
+	rules.Alphabet = "ABC"
+	chars := []dto.Char{
+		{Value: "A", Position: [2]int{3, 6}, HtmlIdentifier: "char-A1"},
+	}
+	if err := gc.checkChars(&rules, uuid.UUID{}, &chars); err != nil {
+		t.Errorf("char from the alphabet rejected; %v", err)
+	}
+	chars = []dto.Char{
+		{Value: "Z", Position: [2]int{3, 6}, HtmlIdentifier: "char-Z2"},
+	}
+	if err := gc.checkChars(&rules, uuid.UUID{}, &chars); err == nil {
+		t.Error("char outside of the alphabet accepted")
+	}
+}
+
+func TestCheckCharsWithDiacritics(t *testing.T) {
+	gc := setupGameControllerImplementation(t)
+	gc.avCharService.(*mock.MockAvCharService).
+		EXPECT().
+		GetWithPlayerUUID(uuid.UUID{}).
+		Return(&[]model.AvChar{{ID: 1, Value: "Ż"}, {ID: 2, Value: "Ó"}}, nil).
+		AnyTimes()
+
+	rules := testRules
+	rules.Language = "pl"
+	rules.Alphabet = "AĄBCĆDEĘFGHIJKLŁMNŃOÓPRSŚTUWYZŹŻ"
+	chars := []dto.Char{
+		{Value: "Ż", Position: [2]int{3, 6}, HtmlIdentifier: "char-Ż1"},
+		{Value: "Ó", Position: [2]int{3, 7}, HtmlIdentifier: "char-Ó2"},
+	}
+	if err := gc.checkChars(&rules, uuid.UUID{}, &chars); err != nil {
+		t.Errorf("letters with diacritics rejected; %v", err)
+	}
+
+	chars = []dto.Char{
+		{Value: "Q", Position: [2]int{3, 6}, HtmlIdentifier: "char-Q1"},
+	}
+	if err := gc.checkChars(&rules, uuid.UUID{}, &chars); err == nil {
+		t.Error("letter outside of the polish alphabet accepted")
+	}
+}
+
+func TestChallengeErrors(t *testing.T) {
+	gc := setupGameControllerImplementation(t)
+	author := &model.Player{UUID: uuid.New(), Name: "Ala"}
+	challenger := &model.Player{UUID: uuid.New(), Name: "Ola"}
+	rules := testRules
+	rules.ChallengeMode = true
+	game := &model.Game{UUID: uuid.New(), Rules: rules}
+
+	playService := gc.playService.(*mock.MockPlayService)
+	provisional := &model.Play{PlayerUUID: author.UUID, Word: "cab", Provisional: true}
+	accepted := &model.Play{PlayerUUID: author.UUID, Word: "cab"}
+
+	testCases := []struct {
+		name   string
+		game   *model.Game
+		player *model.Player
+		last   *model.Play
+	}{
+		{"challenges off", &model.Game{Rules: testRules}, challenger, nil},
+		{"own word", game, author, provisional},
+		{"word already accepted", game, challenger, accepted},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.last != nil {
+				playService.EXPECT().GetLast(game.UUID).Return(tc.last, nil)
+			}
+			ctx := &dto.WsContext{Game: tc.game, Player: tc.player}
+			_, _, challenged, err := gc.Challenge(ctx)
+			if err == nil || challenged != nil {
+				t.Errorf("expected error, got %v", challenged)
+			}
+		})
+	}
+}
+
+func TestReceiveCharsCountsRejected(t *testing.T) {
+	gc := setupGameControllerImplementation(t)
+	gc.packs = lang.Packs{"en": &lang.Pack{Code: "en"}}
+	player := &model.Player{UUID: uuid.New(), Name: "Ala"}
+	current := &model.Player{UUID: uuid.New(), Name: "Ola"}
+	game := &model.Game{UUID: uuid.New(), Rules: testRules}
+	gc.gameService.(*mock.MockGameService).
+		EXPECT().
+		CurrentPlayer(game).
+		Return(current, nil)
+
+	ctx := &dto.WsContext{Game: game, Player: player}
+	playData := &dto.PlayData{Chars: []dto.Char{{Value: "A", Position: [2]int{7, 7}}}}
+	if _, _, err := gc.ReceiveChars(ctx, playData); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	var buf strings.Builder
+	if err := gc.metrics.WriteText(&buf); err != nil {
+		t.Fatalf("writing metrics failed; %v", err)
+	}
+	line := `scrable3_plays_total{result="rejected",reason="turn"} 1`
+	if !strings.Contains(buf.String(), line) {
+		t.Errorf("missing %q in\n%v", line, buf.String())
+	}
+}
+
+func TestLoseTurn(t *testing.T) {
+	gc := setupGameControllerImplementation(t)
+	gameService := gc.gameService.(*mock.MockGameService)
+	playerService := gc.playerService.(*mock.MockPlayerService)
+	game := &model.Game{UUID: uuid.New()}
+	current := &model.Player{UUID: uuid.New()}
+	other := &model.Player{UUID: uuid.New()}
+	gameService.EXPECT().CurrentPlayer(game).Return(current, nil).Times(2)
+
+	// Challenger that has the turn passes it at once
+	gameService.EXPECT().NextTurn(game).Return(nil)
+	if err := gc.loseTurn(&dto.WsContext{Game: game, Player: current}); err != nil {
+		t.Error(err)
+	}
+
+	// Other challengers skip their next turn
+	playerService.EXPECT().Update(other).Return(nil)
+	if err := gc.loseTurn(&dto.WsContext{Game: game, Player: other}); err != nil {
+		t.Error(err)
+	}
+	if !other.SkipTurn || current.SkipTurn {
+		t.Error("wrong player skips the turn")
+	}
+}
+
+func TestUndoPlayErrors(t *testing.T) {
+	gc := setupGameControllerImplementation(t)
+	playService := gc.playService.(*mock.MockPlayService)
+	author := &model.Player{UUID: uuid.New(), Name: "Ala"}
+	opponent := &model.Player{UUID: uuid.New(), Name: "Ola"}
+	game := &model.Game{UUID: uuid.New(), Rules: testRules}
+	play := &model.Play{PlayerUUID: author.UUID, Word: "cab"}
+	requested := &model.Play{PlayerUUID: author.UUID, Word: "cab", UndoRequested: true}
+
+	// No plays yet
+	playService.EXPECT().GetLast(game.UUID).Return(nil, repo.ErrNotExists)
+	ctx := &dto.WsContext{Game: game, Player: author}
+	if _, _, _, err := gc.UndoPlay(ctx); err == nil {
+		t.Error("expected error without plays")
+	}
+
+	// Only the author can take the play back
+	playService.EXPECT().GetLast(game.UUID).Return(play, nil)
+	ctx = &dto.WsContext{Game: game, Player: opponent}
+	if _, _, _, err := gc.UndoPlay(ctx); err == nil {
+		t.Error("expected error for play of another player")
+	}
+
+	// Opponents answer only requested undos
+	playService.EXPECT().GetLast(game.UUID).Return(play, nil)
+	if _, _, _, err := gc.AnswerUndo(ctx, true); err == nil {
+		t.Error("expected error without undo request")
+	}
+
+	// Author cannot accept their own request
+	playService.EXPECT().GetLast(game.UUID).Return(requested, nil)
+	ctx = &dto.WsContext{Game: game, Player: author}
+	if _, _, _, err := gc.AnswerUndo(ctx, true); err == nil {
+		t.Error("expected error for author answering the request")
+	}
+}
+
+func TestCheckTurnClock(t *testing.T) {
+	gc := setupGameControllerImplementation(t)
+	gameService := gc.gameService.(*mock.MockGameService)
+	player := &model.Player{UUID: uuid.New(), TimeLeft: time.Minute}
+	rules := testRules
+	rules.TimeControl = 30 * time.Second
+	rules.TimeBank = 10 * time.Minute
+	gameService.EXPECT().CurrentPlayer(gomock.Any()).Return(player, nil).AnyTimes()
+
+	testCases := []struct {
+		name    string
+		elapsed time.Duration
+		isErr   bool
+	}{
+		{"in time", 10 * time.Second, false},
+		{"turn limit passed", 40 * time.Second, true},
+		{"bank shorter than turn limit", 20 * time.Second, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			game := &model.Game{
+				UUID:          uuid.New(),
+				TurnStartDate: time.Now().Add(-tc.elapsed),
+				Rules:         rules,
+			}
+			err := gc.checkTurn(&dto.WsContext{Game: game, Player: player})
+			if (err != nil) != tc.isErr {
+				t.Errorf("expected error %v, got %v", tc.isErr, err)
+			}
+		})
+	}
+
+	// Bank runs out before the turn limit
+	player.TimeLeft = 5 * time.Second
+	game := &model.Game{TurnStartDate: time.Now().Add(-10 * time.Second), Rules: rules}
+	if err := gc.checkTurn(&dto.WsContext{Game: game, Player: player}); err == nil {
+		t.Error("expected error for empty bank")
+	}
+}
+
+func TestTickWithoutClock(t *testing.T) {
+	gc := setupGameControllerImplementation(t)
+	games := []*model.Game{
+		{UUID: uuid.New(), Rules: testRules},
+		{UUID: uuid.New(), Rules: testRules, FinishDate: time.Now()},
+	}
+	games[1].Rules.TimeControl = time.Second
+
+	// Neither game asks services about players
+	for _, game := range games {
+		response, passed, err := gc.Tick(game, time.Now().Add(time.Hour))
+		if response != nil || passed != nil || err != nil {
+			t.Errorf("expected no tick, got %s, %v, %v", response, passed, err)
+		}
+	}
+}
+
+func TestFormatClock(t *testing.T) {
+	gc := setupGameControllerImplementation(t)
+	testCases := []struct {
+		left     time.Duration
+		expected string
+	}{
+		{0, "0:00"},
+		{300 * time.Millisecond, "0:01"},
+		{59 * time.Second, "0:59"},
+		{10*time.Minute + 5*time.Second, "10:05"},
+	}
+	for _, tc := range testCases {
+		if got := gc.formatClock(tc.left); got != tc.expected {
+			t.Errorf("expected %v for %v, got %v", tc.expected, tc.left, got)
+		}
+	}
+}
+
+func TestPassTurnNotifies(t *testing.T) {
+	gc := setupGameControllerImplementation(t)
+	var notifications bytes.Buffer
+	gc.notifier = notify.NewLogNotifier(&notifications)
+	gameService := gc.gameService.(*mock.MockGameService)
+	next := &model.Player{UUID: uuid.New(), Name: "Ola"}
+
+	// Live games are watched, nobody is notified
+	live := &model.Game{UUID: uuid.New(), Rules: testRules}
+	gameService.EXPECT().NextTurn(live).Return(nil)
+	if err := gc.passTurn(live); err != nil {
+		t.Error(err)
+	}
+	if notifications.Len() != 0 {
+		t.Errorf("expected no notification, got %s", notifications.String())
+	}
+
+	correspondence := &model.Game{UUID: uuid.New(), Rules: testRules}
+	correspondence.Rules.Correspondence = true
+	gameService.EXPECT().NextTurn(correspondence).Return(nil)
+	gameService.EXPECT().CurrentPlayer(correspondence).Return(next, nil)
+	if err := gc.passTurn(correspondence); err != nil {
+		t.Error(err)
+	}
+	if !bytes.Contains(notifications.Bytes(), []byte(next.UUID.String())) {
+		t.Errorf("expected notification for Ola, got %s", notifications.String())
+	}
+}
+
+func TestForfeit(t *testing.T) {
+	gc := setupGameControllerImplementation(t)
+	var notifications bytes.Buffer
+	gc.notifier = notify.NewLogNotifier(&notifications)
+	gameService := gc.gameService.(*mock.MockGameService)
+	playService := gc.playService.(*mock.MockPlayService)
+	now := time.Now()
+	after := 24 * time.Hour
+	current := model.Player{UUID: uuid.New(), Name: "Ala", CreateDate: now.Add(-72 * time.Hour)}
+	other := model.Player{UUID: uuid.New(), Name: "Ola", CreateDate: now.Add(-72 * time.Hour)}
+
+	rules := testRules
+	rules.Correspondence = true
+	live := &model.Game{Rules: testRules, TurnStartDate: now.Add(-48 * time.Hour)}
+	forfeited, err := gc.Forfeit(live, now, after)
+	if forfeited != nil || err != nil {
+		t.Errorf("expected no forfeit, got %v, %v", forfeited, err)
+	}
+
+	active := &model.Game{UUID: uuid.New(), Rules: rules, TurnStartDate: now.Add(-48 * time.Hour)}
+	gameService.EXPECT().CurrentPlayer(active).Return(&current, nil)
+	playService.EXPECT().GetLast(active.UUID).Return(
+		&model.Play{CreateDate: now.Add(-time.Hour)}, nil,
+	)
+	forfeited, err = gc.Forfeit(active, now, after)
+	if forfeited != nil || err != nil {
+		t.Errorf("expected no forfeit, got %v, %v", forfeited, err)
+	}
+
+	// Turn restarted by passing on timeout does not count as activity
+	stale := &model.Game{UUID: uuid.New(), Rules: rules, TurnStartDate: now.Add(-time.Hour)}
+	gameService.EXPECT().CurrentPlayer(stale).Return(&current, nil)
+	playService.EXPECT().GetLast(stale.UUID).Return(
+		&model.Play{CreateDate: now.Add(-48 * time.Hour)}, nil,
+	)
+	gameService.EXPECT().Forfeit(stale, &current).Return(nil)
+	gameService.EXPECT().TurnOrder(stale.UUID).Return([]model.Player{current, other}, nil)
+	forfeited, err = gc.Forfeit(stale, now, after)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if forfeited != &current {
+		t.Errorf("expected Ala to forfeit, got %v", forfeited)
+	}
+	if lines := bytes.Count(notifications.Bytes(), []byte("\n")); lines != 2 {
+		t.Errorf("expected both players notified, got %v notifications", lines)
+	}
+
+	// Before anyone played, inactivity is counted from joining
+	joined := model.Player{UUID: uuid.New(), Name: "Ela", CreateDate: now.Add(-time.Hour)}
+	unplayed := &model.Game{UUID: uuid.New(), Rules: rules, TurnStartDate: now.Add(-48 * time.Hour)}
+	gameService.EXPECT().CurrentPlayer(unplayed).Return(&joined, nil)
+	playService.EXPECT().GetLast(unplayed.UUID).Return(nil, repo.ErrNotExists)
+	forfeited, err = gc.Forfeit(unplayed, now, after)
+	if forfeited != nil || err != nil {
+		t.Errorf("expected no forfeit, got %v, %v", forfeited, err)
+	}
+}
+
+func TestFinishedGameRejectsActions(t *testing.T) {
+	gc := setupGameControllerImplementation(t)
+	gc.packs = lang.Packs{"en": &lang.Pack{Code: "en"}}
+	player := &model.Player{UUID: uuid.New(), Name: "Ala"}
+	rules := testRules
+	rules.ChallengeMode = true
+	game := &model.Game{UUID: uuid.New(), Rules: rules, FinishDate: time.Now()}
+	ctx := &dto.WsContext{Game: game, Player: player}
+
+	// No service is asked about the game
+	playData := &dto.PlayData{Chars: []dto.Char{{Value: "A", Position: [2]int{7, 7}}}}
+	if _, _, err := gc.ReceiveChars(ctx, playData); err == nil {
+		t.Error("expected error for play")
+	}
+	if _, _, _, err := gc.Challenge(ctx); err == nil {
+		t.Error("expected error for challenge")
+	}
+	if _, _, _, err := gc.UndoPlay(ctx); err == nil {
+		t.Error("expected error for undo request")
+	}
+	if _, _, _, err := gc.AnswerUndo(ctx, true); err == nil {
+		t.Error("expected error for undo answer")
+	}
 }
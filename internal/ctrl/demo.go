@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"scrable3/internal/dto"
+	"scrable3/internal/model"
 	"text/template"
 
 	"golang.org/x/exp/rand"
@@ -25,13 +26,15 @@ func randomFieldHtmlContent() ([]byte, error) {
 		return htmlContent, err
 	}
 
-	x := rand.Intn(15)
-	y := rand.Intn(15)
-	z := rand.Intn(15)
+	rules := &model.GameRules{BoardSize: model.MaxBoardSize}
+	x := rand.Intn(rules.BoardSize)
+	y := rand.Intn(rules.BoardSize)
+	z := rand.Intn(rules.BoardSize)
 
 	letter := string(randomUppercaseLetter())
 
 	data := dto.NewHtmlFieldData(
+		rules,
 		letter,
 		x,
 		y,
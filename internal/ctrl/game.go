@@ -3,16 +3,26 @@ package ctrl
 import (
 	"bytes"
 	"container/heap"
+	"context"
 	"errors"
 	"fmt"
-	"log"
-	"scrable3/internal/cfg"
+	"html/template"
+	"io"
+	"log/slog"
+	"scrable3/internal/board"
 	"scrable3/internal/common"
 	"scrable3/internal/dto"
+	"scrable3/internal/lang"
+	"scrable3/internal/logging"
+	"scrable3/internal/metrics"
 	"scrable3/internal/model"
+	"scrable3/internal/notify"
+	"scrable3/internal/renderer"
+	"scrable3/internal/repo"
 	"scrable3/internal/svc"
 	"strings"
-	"text/template"
+	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
@@ -20,6 +30,7 @@ import (
 type GameController interface {
 	GetCurrentFields(ctx *dto.WsContext) ([]byte, error)
 	GetAvaibleChars(ctx *dto.WsContext) (senderResponse []byte, err error)
+	GetPlayers(ctx *dto.WsContext) (broadcastResponse []byte, err error)
 	ReceiveChars(
 		ctx *dto.WsContext,
 		p *dto.PlayData,
@@ -28,31 +39,113 @@ type GameController interface {
 		senderResponse []byte,
 		err error,
 	)
+	// Checks the last provisional word. The word is taken off the board when
+	// it fails, otherwise the challenger loses a turn. 'challenged' is the
+	// removed play.
+	Challenge(ctx *dto.WsContext) (
+		broadcastResponse []byte,
+		senderResponse []byte,
+		challenged *model.Play,
+		err error,
+	)
+	// Takes back the last play of the player. In games with more than one
+	// player opponents have to accept it first, 'undone' is nil until then.
+	UndoPlay(ctx *dto.WsContext) (
+		broadcastResponse []byte,
+		senderResponse []byte,
+		undone *model.Play,
+		err error,
+	)
+	// Accepts or declines undo requested by the opponent
+	AnswerUndo(ctx *dto.WsContext, accept bool) (
+		broadcastResponse []byte,
+		senderResponse []byte,
+		undone *model.Play,
+		err error,
+	)
+	// Passes the turn when the current player ran out of time and renders
+	// remaining time of players. 'passed' is the player that lost the turn.
+	Tick(game *model.Game, now time.Time) (
+		broadcastResponse []byte,
+		passed *model.Player,
+		err error,
+	)
+	// Finishes the correspondence game when nobody played for 'after', the
+	// player that has the turn forfeits. 'forfeited' is nil for active games.
+	Forfeit(game *model.Game, now time.Time, after time.Duration) (
+		forfeited *model.Player,
+		err error,
+	)
+	// Renders the notice shown to players in place of the error popup
+	BuildNotice(message string) (broadcastResponse []byte, err error)
 }
 
 type gameController struct {
 	wordsController WordsController
+	gameService     svc.GameService
 	playerService   svc.PlayerService
 	fieldService    svc.FieldService
 	avCharService   svc.AvCharService
+	playService     svc.PlayService
+	packs           lang.Packs
+	notifier        notify.Notifier
+	logger          *slog.Logger
+	metrics         *metrics.Metrics
 }
 
 func NewGameController(
 	wordsController WordsController,
+	gameService svc.GameService,
 	playerService svc.PlayerService,
 	fieldService svc.FieldService,
 	avCharService svc.AvCharService,
+	playService svc.PlayService,
+	packs lang.Packs,
+	notifier notify.Notifier,
+	logger *slog.Logger,
+	metrics *metrics.Metrics,
 ) GameController {
 	return &gameController{
 		wordsController: wordsController,
+		gameService:     gameService,
 		playerService:   playerService,
 		fieldService:    fieldService,
 		avCharService:   avCharService,
+		playService:     playService,
+		packs:           packs,
+		notifier:        notifier,
+		logger:          logger,
+		metrics:         metrics,
 	}
 }
 
 // |PRIVATE| //
 
+// Executes the template and records how long it took
+func (gc *gameController) execute(tmpl *template.Template, w io.Writer, data any) error {
+	defer gc.metrics.ObserveRender(tmpl.Name(), time.Now())
+	return tmpl.Execute(w, data)
+}
+
+// Looks the word up in the dictionary and counts the lookup
+func (gc *gameController) checkWord(game *model.Game, word string) error {
+	err := gc.wordsController.CheckWord(game, word)
+	gc.metrics.DictionaryLookup(err == nil)
+	return err
+}
+
+// Logger of the request when the handler passed one, otherwise the controller
+// logger tagged with the game and the player
+func (gc *gameController) contextLogger(ctx *dto.WsContext) *slog.Logger {
+	if ctx.Logger != nil {
+		return ctx.Logger
+	}
+	return gc.logger.With(
+		logging.KeyGame, ctx.Game.UUID,
+		logging.KeyPlayer, ctx.Player.UUID,
+	)
+}
+
 // 1 is horizontal, 0 is vertical
 func (gc *gameController) whichAxisIsStraight(chars *[]dto.Char) int {
 	firstElementNumber := (*chars)[0].Position[0]
@@ -64,10 +157,13 @@ func (gc *gameController) whichAxisIsStraight(chars *[]dto.Char) int {
 	}
 }
 
-func (gc *gameController) findDepthFromLeftMostPosition(depthMap *map[int]int) int {
+func (gc *gameController) findDepthFromLeftMostPosition(
+	rules *model.GameRules,
+	depthMap *map[int]int,
+) int {
 	var value int
 	var ok bool
-	for i := range cfg.BOARD_SIZE {
+	for i := range rules.BoardSize {
 		value, ok = (*depthMap)[int(i)]
 		if ok {
 			return value
@@ -76,22 +172,12 @@ func (gc *gameController) findDepthFromLeftMostPosition(depthMap *map[int]int) i
 	return -1
 }
 
-// Maps characters to their positions along a specified axis, considering their
-// depth positions. The 'sideInt' and 'straightAxisId' parameters determines
-// which axis (X, Y, Z) is used as 'changingPos', 'staticPos', and 'depthPos'.
-//
-// The 'depthPos' varies based on the value of 'sideInt':
-//
-//	0   (smallest Z as depth) // North
-//	90  (highest Y as depth) // East
-//	180 (highest Z as depth)  // South
-//	270 (smallest Y as depth)  // West
-//
-// The 'staticPos' varies based on the value of 'straightAxisId':
-//
-//	0 (Y as static)
-//	1 (X or Z as static)
+// Maps characters visible from the side to their positions along the straight
+// axis. 'straightAxisId' tells which position on the face is static: 0 is the
+// column and 1 is the row. Only the character nearest to the face is visible on
+// every position; the second map holds its depth.
 func (gc *gameController) makeCharsInStraightAxisFromFieldsMap(
+	rules *model.GameRules,
 	fields *[]model.Field,
 	straightAxisNumber int,
 	straightAxisId int,
@@ -101,162 +187,75 @@ func (gc *gameController) makeCharsInStraightAxisFromFieldsMap(
 	currentDepthMap := make(map[int]int)
 
 	for _, field := range *fields {
-		// Pointer to the axis position that changes based on 'sideInt' and
-		// 'straightAxisId'
-		var changingPos *int
-		// Pointer to the axis position that remains static based on
-		// 'straightAxisNumber'
-		var staticPos *int
-		// Pointer to the axis position used for depth comparison based on
-		// 'sideInt'
-		var depthPos *int
-
-		if sideInt == 0 {
-			depthPos = &field.PosZ
-			if straightAxisId == 0 {
-				// @@ Check
-				changingPos = &field.PosX
-				staticPos = &field.PosY
-			} else {
-				// @@ Check
-				changingPos = &field.PosY
-				staticPos = &field.PosX
-			}
-		} else if sideInt == 90 {
-			depthPos = &field.PosY
-			if straightAxisId == 0 {
-				// @@ Check
-				var temp = cfg.BOARD_SIZE - 1 - field.PosZ
-				changingPos = &field.PosX
-				staticPos = &temp
-				// log.Println(*changingPos, *staticPos, *depthPos)
-			} else {
-				changingPos = &field.PosX
-				staticPos = &field.PosZ
-			}
-		} else if sideInt == 180 {
-			depthPos = &field.PosZ
-			if straightAxisId == 0 {
-				changingPos = &field.PosX
-				staticPos = &field.PosY
-			} else {
-				changingPos = &field.PosY
-				staticPos = &field.PosX
-			}
-		} else if sideInt == 270 {
-			depthPos = &field.PosY
-			if straightAxisId == 0 {
-				// @@ Check
-				changingPos = &field.PosX
-				staticPos = &field.PosZ
-			} else {
-				// @@ Check
-				changingPos = &field.PosZ
-				staticPos = &field.PosX
-			}
-		}
+		column, row, depth := board.Project(
+			rules.BoardSize,
+			sideInt,
+			board.Pos{field.PosX, field.PosY, field.PosZ},
+		)
+		facePos := [2]int{column, row}
 
 		// If the field's static position does not match the specified straight
 		// axis number, skip this field.
-		if *staticPos != straightAxisNumber {
+		if facePos[straightAxisId] != straightAxisNumber {
 			continue
 		}
 
-		currDepthPos, ok := currentDepthMap[*changingPos]
-		if !ok { // initialize the depth position
-			currentDepthMap[*changingPos], currDepthPos = *depthPos, *depthPos
-		}
-		// Update the depth position and character mapping if the current depth
-		// position meets the criteria based on 'sideInt'
-		if ((sideInt == 90 || sideInt == 180) && currDepthPos <= *depthPos) ||
-			((sideInt == 0 || sideInt == 270) && currDepthPos >= *depthPos) {
-			currentDepthMap[*changingPos] = *depthPos
-			existingCharsInStraightAxis[*changingPos] = field.Value
+		changingPos := facePos[straightAxisId^1]
+		currDepthPos, ok := currentDepthMap[changingPos]
+		if !ok || depth < currDepthPos {
+			currentDepthMap[changingPos] = depth
+			existingCharsInStraightAxis[changingPos] = field.Value
 		}
 	}
 	return &existingCharsInStraightAxis, &currentDepthMap
 }
 
 // Generates a dto.FieldData structure based on the provided character data,
-// side orientation, depth level and word orientation.
+// side orientation and depth level counted from the side.
 func (gc *gameController) createFieldData(
+	rules *model.GameRules,
 	char *dto.Char,
 	sideInt int,
 	depthLevel int,
-	isHorizonatal int,
 ) *dto.FieldData {
-	fieldData := dto.FieldData{
+	return &dto.FieldData{
 		Value: char.Value,
-		Pos:   [3]int{},
-	}
-	log.Println("\n*** createFieldData ***")
-	log.Println("sideInt")
-	log.Println(sideInt)
-	log.Println("char.Value")
-	log.Println(char.Value)
-	log.Println("char.Position[1]")
-	log.Println(char.Position[1])
-	log.Println("char.Position[0]")
-	log.Println(char.Position[0])
-	log.Println("depthLevel")
-	log.Println(depthLevel)
-	log.Println("isHorizonatal")
-	log.Println(isHorizonatal)
-	log.Println("\n")
-
-	if isHorizonatal == 1 {
-		// Word is horizontal (along X axis)
-		if sideInt == 0 {
-			fieldData.Pos[0] = char.Position[1]
-			fieldData.Pos[1] = char.Position[0]
-			fieldData.Pos[2] = depthLevel
-		} else if sideInt == 90 {
-			fieldData.Pos[0] = depthLevel
-			fieldData.Pos[1] = char.Position[1]
-			fieldData.Pos[2] = cfg.BOARD_SIZE - 1 - char.Position[0]
-		} else if sideInt == 180 {
-			fieldData.Pos[0] = cfg.BOARD_SIZE - 1 - char.Position[1]
-			fieldData.Pos[1] = cfg.BOARD_SIZE - 1 - char.Position[0]
-			fieldData.Pos[2] = depthLevel
-		} else { // sideInt == 270
-			// @@ Check
-			fieldData.Pos[0] = char.Position[1]
-			fieldData.Pos[1] = depthLevel
-			fieldData.Pos[2] = char.Position[0]
+		Pos: board.Unproject(
+			rules.BoardSize,
+			sideInt,
+			char.Position[0],
+			char.Position[1],
+			depthLevel,
+		),
+	}
+}
+
+// Side of the cube the play was made on. Tilted cube shows the top or the
+// bottom whatever its rotation.
+func (gc *gameController) playSide(pl *dto.PlayData) (int, error) {
+	switch pl.Tilt {
+	case 90:
+		return board.SideTop, nil
+	case -90:
+		return board.SideBottom, nil
+	case 0:
+		sideInt := common.Abs(pl.SideInt) % 360
+		if sideInt%90 != 0 {
+			return 0, fmt.Errorf("side should be a multiple of 90, not %v", pl.SideInt)
 		}
-	} else {
-		// Word is vertical (along Y axis)
-		if sideInt == 0 {
-			fieldData.Pos[0] = char.Position[1]
-			fieldData.Pos[1] = char.Position[0]
-			fieldData.Pos[2] = depthLevel
-		} else if sideInt == 90 {
-			fieldData.Pos[0] = char.Position[1]
-			fieldData.Pos[1] = char.Position[0]
-			fieldData.Pos[2] = depthLevel
-		} else if sideInt == 180 {
-			fieldData.Pos[0] = char.Position[1]
-			fieldData.Pos[1] = char.Position[0]
-			fieldData.Pos[2] = depthLevel
-		} else { // sideInt == 270
-			// @@ Check
-			fieldData.Pos[0] = char.Position[1]
-			fieldData.Pos[1] = depthLevel
-			fieldData.Pos[2] = char.Position[0]
-		}
-	}
-	log.Println(fieldData)
-
-	return &fieldData
+		return sideInt, nil
+	default:
+		return 0, fmt.Errorf("tilt should be -90, 0 or 90, not %v", pl.Tilt)
+	}
 }
 
 func (gc *gameController) obtainWordAndFieldsData(
-	gameUUID uuid.UUID, pl *dto.PlayData,
+	game *model.Game, pl *dto.PlayData, logger *slog.Logger,
 ) (string, *[]dto.FieldData, error) {
 	var obtainedWord string
 	var fieldsData []dto.FieldData
 
-	fields, err := gc.fieldService.GetWithGameUUID(gameUUID)
+	fields, err := gc.fieldService.GetWithGameUUID(game.UUID)
 	if err != nil {
 		return obtainedWord, &fieldsData, err
 	}
@@ -279,36 +278,45 @@ func (gc *gameController) obtainWordAndFieldsData(
 	firstCharInHeap := charsPositionsMinHeap.GetTop().Content
 	lastPosition := firstCharInHeap.Position[nonStraightAxisId]
 	straightAxisNumber := firstCharInHeap.Position[straightAxisId]
-	sideInt := common.Abs(pl.SideInt) % 360
+	sideInt, err := gc.playSide(pl)
+	if err != nil {
+		return obtainedWord, &fieldsData, err
+	}
 
-	for _, field := range *fields {
-		log.Println(field)
+	logger.Debug(
+		"play seen from the side",
+		"side", sideInt,
+		"straight_axis_id", straightAxisId,
+		"straight_axis_number", straightAxisNumber,
+		"fields", len(*fields),
+	)
+	// Rendering the board is skipped unless somebody reads it
+	if logger.Enabled(context.Background(), slog.LevelDebug) {
+		logger.Debug(
+			"board before the play",
+			"face", renderer.FieldsFace(game.Rules.BoardSize, *fields, sideInt),
+		)
 	}
-	log.Println("straightAxisNumber")
-	log.Println(straightAxisNumber)
-	log.Println("straightAxisId")
-	log.Println(straightAxisId)
-	log.Println("sideInt")
-	log.Println(sideInt)
 
 	existingChars, existingCharsDepth := gc.makeCharsInStraightAxisFromFieldsMap(
+		&game.Rules,
 		fields,
 		straightAxisNumber,
 		straightAxisId,
 		sideInt,
 	)
-	log.Println("* existingChars")
-	log.Println(*existingChars)
-
-	log.Println("* existingCharsDepth")
-	log.Println(*existingCharsDepth)
+	logger.Debug(
+		"characters on the straight axis",
+		"chars", *existingChars,
+		"depths", *existingCharsDepth,
+	)
 
 	if len(*existingChars) == 0 || len(*existingCharsDepth) == 0 {
 		err := errors.New("no existing characters on straight axis")
 		return obtainedWord, &fieldsData, err
 	}
 
-	depthLevel := gc.findDepthFromLeftMostPosition(existingCharsDepth)
+	depthLevel := gc.findDepthFromLeftMostPosition(&game.Rules, existingCharsDepth)
 	if depthLevel == -1 {
 		err := errors.New("no depth level found")
 		return obtainedWord, &fieldsData, err
@@ -358,11 +366,15 @@ func (gc *gameController) obtainWordAndFieldsData(
 		obtainedWord += char.Value
 		lastPosition = char.Position[nonStraightAxisId] + 1
 		fieldData := gc.createFieldData(
+			&game.Rules,
 			char,
 			sideInt,
 			depthLevel,
-			straightAxisId,
 		)
+		if !game.Rules.IsOnBoard(fieldData.Pos[:]...) {
+			err := fmt.Errorf("char %v is outside of the board", char)
+			return obtainedWord, &fieldsData, err
+		}
 		fieldsData = append(fieldsData, *fieldData)
 	}
 
@@ -376,7 +388,51 @@ func (gc *gameController) obtainWordAndFieldsData(
 		return obtainedWord, &fieldsData, err
 	}
 
-	return obtainedWord, &fieldsData, nil
+	err = gc.checkPlayInCube(
+		&game.Rules,
+		sideInt,
+		straightAxisId,
+		fields,
+		&fieldsData,
+		obtainedWord,
+	)
+	return obtainedWord, &fieldsData, err
+}
+
+// Checks the play against the whole volume of the board. Letters seen next to
+// each other from the side can lie on different depths, then they do not make
+// a word in the cube.
+func (gc *gameController) checkPlayInCube(
+	rules *model.GameRules,
+	sideInt int,
+	straightAxisId int,
+	fields *[]model.Field,
+	fieldsData *[]dto.FieldData,
+	word string,
+) error {
+	b, err := board.FromFields(rules.BoardSize, *fields)
+	if err != nil {
+		return err
+	}
+	// Letters of a word in a static column go down the rows
+	columnAxis, rowAxis := board.FaceAxes(sideInt)
+	axis := rowAxis
+	if straightAxisId == 1 {
+		axis = columnAxis
+	}
+
+	tiles := make([]board.Tile, 0, len(*fieldsData))
+	for _, fieldData := range *fieldsData {
+		tiles = append(tiles, board.Tile{Pos: fieldData.Pos, Value: fieldData.Value})
+	}
+	cubeWord, err := b.CheckPlay(sideInt, axis, tiles)
+	if err != nil {
+		return err
+	}
+	if cubeWord != word {
+		return fmt.Errorf("word %v seen from the side is %v in the cube", word, cubeWord)
+	}
+	return nil
 }
 
 // Check if characters are in available characters
@@ -413,6 +469,7 @@ func (gc *gameController) areCharsInAvChars(
 }
 
 func (gc *gameController) checkChars(
+	rules *model.GameRules,
 	playerUUID uuid.UUID,
 	chars *[]dto.Char,
 ) error {
@@ -427,16 +484,23 @@ func (gc *gameController) checkChars(
 	}
 	for _, char := range *chars {
 		switch { // Check field value
-		case len(char.Value) != 1:
+		case utf8.RuneCountInString(char.Value) != 1:
 			return fmt.Errorf(
 				"field.value length should be 1, not %v",
-				len(char.Value),
+				utf8.RuneCountInString(char.Value),
 			)
-		case !strings.Contains(cfg.ALLOWED_CHARACTERS, char.Value):
+		case !strings.Contains(rules.Alphabet, char.Value):
 			return fmt.Errorf(
 				"field.value ('%v') should be allowed character; allowed characters: %v",
 				char.Value,
-				cfg.ALLOWED_CHARACTERS,
+				rules.Alphabet,
+			)
+		}
+		if !rules.IsOnBoard(char.Position[:]...) {
+			return fmt.Errorf(
+				"char %v position %v is outside of the board",
+				char.HtmlIdentifier,
+				char.Position,
 			)
 		}
 		for i := range 2 {
@@ -463,7 +527,26 @@ func (gc *gameController) checkChars(
 	return nil
 }
 
-func (gc *gameController) buildHtmlFields(fields *[]model.Field) ([]byte, error) {
+// Maps players of the game by their UUID
+func (gc *gameController) getPlayersMap(
+	gameUUID uuid.UUID,
+) (map[uuid.UUID]model.Player, error) {
+	players, err := gc.playerService.GetWithGameUUID(gameUUID)
+	if err != nil {
+		return nil, err
+	}
+	playersMap := make(map[uuid.UUID]model.Player, len(*players))
+	for _, player := range *players {
+		playersMap[player.UUID] = player
+	}
+	return playersMap, nil
+}
+
+func (gc *gameController) buildHtmlFields(
+	rules *model.GameRules,
+	fields *[]model.Field,
+	players map[uuid.UUID]model.Player,
+) ([]byte, error) {
 	var htmlContent bytes.Buffer
 	tmpl, err := template.ParseFiles("views/game/field.html")
 	if err != nil {
@@ -472,13 +555,18 @@ func (gc *gameController) buildHtmlFields(fields *[]model.Field) ([]byte, error)
 
 	for _, field := range *fields {
 		data := dto.NewHtmlFieldData(
+			rules,
 			field.Value,
 			field.PosX,
 			field.PosY,
 			field.PosZ,
 		)
+		if player, ok := players[field.PlayerUUID]; ok {
+			data.PlayerName = player.Name
+			data.PlayerColor = player.Color
+		}
 
-		err = tmpl.Execute(&htmlContent, data)
+		err = gc.execute(tmpl, &htmlContent, data)
 		if err != nil {
 			return nil, err
 		}
@@ -494,7 +582,7 @@ func (gc *gameController) buildHtmlAvChars(avChars *[]model.AvChar) ([]byte, err
 	if err != nil {
 		return nil, err
 	}
-	err = tmpl.Execute(&htmlContent, nil)
+	err = gc.execute(tmpl, &htmlContent, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -515,7 +603,7 @@ func (gc *gameController) buildHtmlAvChars(avChars *[]model.AvChar) ([]byte, err
 			return nil, err
 		}
 
-		err = tmpl.Execute(&htmlContent, data)
+		err = gc.execute(tmpl, &htmlContent, data)
 		if err != nil {
 			return nil, err
 		}
@@ -525,6 +613,293 @@ func (gc *gameController) buildHtmlAvChars(avChars *[]model.AvChar) ([]byte, err
 	return result, nil
 }
 
+// Remaining time rounded up to whole seconds, so 0:00 is shown only when the
+// time is up
+func (gc *gameController) formatClock(left time.Duration) string {
+	seconds := int64((left + time.Second - 1) / time.Second)
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// Clock of the player shown at 'now'. The current player sees the time left in
+// the turn, others the time left in their bank.
+func (gc *gameController) playerClock(
+	game *model.Game,
+	player *model.Player,
+	current *model.Player,
+	now time.Time,
+) string {
+	if player.UUID == current.UUID {
+		left, limited := game.TurnTimeLeft(player, now)
+		if !limited {
+			return ""
+		}
+		return gc.formatClock(left)
+	}
+	if game.Rules.TimeBank > 0 {
+		return gc.formatClock(player.TimeLeft)
+	}
+	return ""
+}
+
+func (gc *gameController) buildHtmlPlayers(
+	game *model.Game,
+	players *[]model.Player,
+	current *model.Player,
+) ([]byte, error) {
+	var htmlContent bytes.Buffer
+
+	tmpl, err := template.ParseFiles("views/game/players.html")
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	data := make([]dto.HtmlPlayerData, 0, len(*players))
+	for _, player := range *players {
+		data = append(data, dto.HtmlPlayerData{
+			Name:    player.Name,
+			Color:   player.Color,
+			Points:  player.Points,
+			HasTurn: player.UUID == current.UUID,
+			Clock:   gc.playerClock(game, &player, current, now),
+		})
+	}
+
+	err = gc.execute(tmpl, &htmlContent, data)
+	if err != nil {
+		return nil, err
+	}
+	return htmlContent.Bytes(), nil
+}
+
+// Nothing changes in games that are won or forfeited
+func (gc *gameController) checkNotFinished(game *model.Game) error {
+	if game.IsFinished() {
+		return errors.New("game is finished")
+	}
+	return nil
+}
+
+// Only the player that has the turn can make a play, as long as the game and
+// the time are not up
+func (gc *gameController) checkTurn(ctx *dto.WsContext) error {
+	if err := gc.checkNotFinished(ctx.Game); err != nil {
+		return err
+	}
+	current, err := gc.gameService.CurrentPlayer(ctx.Game)
+	if err != nil {
+		return err
+	}
+	if current.UUID != ctx.Player.UUID {
+		return fmt.Errorf("it is %v's turn", current.Name)
+	}
+	left, limited := ctx.Game.TurnTimeLeft(current, time.Now())
+	if limited && left <= 0 {
+		return errors.New("time for the turn is up")
+	}
+	return nil
+}
+
+func (gc *gameController) acceptLastPlay(gameUUID uuid.UUID) error {
+	play, err := gc.playService.GetLast(gameUUID)
+	if errors.Is(err, repo.ErrNotExists) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return gc.playService.Accept(play)
+}
+
+// Takes fields of the play off the board, puts the tiles back on the rack of
+// the player and takes back the points. Returns html that removes the fields.
+func (gc *gameController) removePlay(
+	game *model.Game,
+	play *model.Play,
+) ([]byte, error) {
+	fields, err := gc.fieldService.DeleteWithAppendNum(
+		game.UUID,
+		play.PlayerUUID,
+		play.AppendNum,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	player, err := gc.playerService.GetWithUUID(play.PlayerUUID)
+	if err != nil {
+		return nil, err
+	}
+	player.Points -= play.Points
+	if err := gc.playerService.Update(player); err != nil {
+		return nil, err
+	}
+
+	values := make([]string, 0, len(*fields))
+	for _, field := range *fields {
+		values = append(values, field.Value)
+	}
+	_, err = gc.avCharService.ReturnMany(player, values, game.Rules.RackSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := gc.playService.Delete(play); err != nil {
+		return nil, err
+	}
+	return gc.buildHtmlRemovedFields(&game.Rules, fields)
+}
+
+// Passes the turn. Players of correspondence games are away, so the next one
+// gets a notification. Failed notifications do not stop the game.
+func (gc *gameController) passTurn(game *model.Game) error {
+	if err := gc.gameService.NextTurn(game); err != nil {
+		return err
+	}
+	if !game.Rules.Correspondence {
+		return nil
+	}
+	next, err := gc.gameService.CurrentPlayer(game)
+	if err != nil {
+		return err
+	}
+	notification := notify.New(notify.EventTurn, game, next, "It is your turn")
+	if err := gc.notifier.Notify(notification); err != nil {
+		gc.logger.Warn(
+			"turn notification",
+			logging.KeyGame, game.UUID,
+			logging.KeyPlayer, next.UUID,
+			logging.KeyErr, err,
+		)
+	}
+	return nil
+}
+
+// Player that challenged a valid word loses the current turn, or the next one
+// when it is someone else's turn
+func (gc *gameController) loseTurn(ctx *dto.WsContext) error {
+	current, err := gc.gameService.CurrentPlayer(ctx.Game)
+	if err != nil {
+		return err
+	}
+	if current.UUID == ctx.Player.UUID {
+		return gc.passTurn(ctx.Game)
+	}
+	ctx.Player.SkipTurn = true
+	return gc.playerService.Update(ctx.Player)
+}
+
+// Sends the notification to every player of the game. Failed notifications do
+// not stop the game.
+func (gc *gameController) notifyPlayers(
+	game *model.Game,
+	event notify.Event,
+	message string,
+) error {
+	players, err := gc.gameService.TurnOrder(game.UUID)
+	if err != nil {
+		return err
+	}
+	for _, player := range players {
+		notification := notify.New(event, game, &player, message)
+		if err := gc.notifier.Notify(notification); err != nil {
+			gc.logger.Warn(
+				string(event)+" notification",
+				logging.KeyGame, game.UUID,
+				logging.KeyPlayer, player.UUID,
+				logging.KeyErr, err,
+			)
+		}
+	}
+	return nil
+}
+
+// Finishes the game won by the player. Returns html of the notice for all
+// players, players of correspondence games are notified.
+func (gc *gameController) finish(game *model.Game, winner *model.Player) ([]byte, error) {
+	if err := gc.gameService.Finish(game); err != nil {
+		return nil, err
+	}
+	message := fmt.Sprintf("%v won the game with %v points", winner.Name, winner.Points)
+	if game.Rules.Correspondence {
+		if err := gc.notifyPlayers(game, notify.EventWin, message); err != nil {
+			return nil, err
+		}
+	}
+	return gc.BuildNotice(message)
+}
+
+func (gc *gameController) buildHtmlRemovedFields(
+	rules *model.GameRules,
+	fields *[]model.Field,
+) ([]byte, error) {
+	var htmlContent bytes.Buffer
+	tmpl, err := template.ParseFiles("views/game/field-removed.html")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range *fields {
+		data := dto.NewHtmlFieldData(
+			rules,
+			field.Value,
+			field.PosX,
+			field.PosY,
+			field.PosZ,
+		)
+		err = gc.execute(tmpl, &htmlContent, data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return htmlContent.Bytes(), nil
+}
+
+// Removes the play and gives the turn back to its author
+func (gc *gameController) undo(ctx *dto.WsContext, play *model.Play) ([]byte, error) {
+	author, err := gc.playerService.GetWithUUID(play.PlayerUUID)
+	if err != nil {
+		return nil, err
+	}
+	response, err := gc.removePlay(ctx.Game, play)
+	if err != nil {
+		return nil, err
+	}
+	if err := gc.gameService.UndoTurn(ctx.Game, author); err != nil {
+		return nil, err
+	}
+
+	playersResponse, err := gc.GetPlayers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	notice, err := gc.BuildNotice(
+		fmt.Sprintf("%v took back %v", author.Name, play.Word),
+	)
+	if err != nil {
+		return nil, err
+	}
+	response = append(response, playersResponse...)
+	return append(response, notice...), nil
+}
+
+func (gc *gameController) buildHtmlUndoRequest(
+	player *model.Player,
+	play *model.Play,
+) ([]byte, error) {
+	var htmlContent bytes.Buffer
+	tmpl, err := template.ParseFiles("views/game/undo-request.html")
+	if err != nil {
+		return nil, err
+	}
+	data := struct{ PlayerName, Word string }{player.Name, play.Word}
+	err = gc.execute(tmpl, &htmlContent, data)
+	if err != nil {
+		return nil, err
+	}
+	return htmlContent.Bytes(), nil
+}
+
 // |PUBLIC| //
 
 func (gc *gameController) GetCurrentFields(ctx *dto.WsContext) ([]byte, error) {
@@ -533,7 +908,26 @@ func (gc *gameController) GetCurrentFields(ctx *dto.WsContext) ([]byte, error) {
 		return nil, err
 	}
 
-	response, err := gc.buildHtmlFields(fields)
+	players, err := gc.getPlayersMap(ctx.Game.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := gc.buildHtmlFields(&ctx.Game.Rules, fields, players)
+	return response, err
+}
+
+func (gc *gameController) GetPlayers(ctx *dto.WsContext) ([]byte, error) {
+	players, err := gc.playerService.GetWithGameUUID(ctx.Game.UUID)
+	if err != nil {
+		return nil, err
+	}
+	current, err := gc.gameService.CurrentPlayer(ctx.Game)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := gc.buildHtmlPlayers(ctx.Game, players, current)
 	return response, err
 }
 
@@ -543,9 +937,17 @@ func (gc *gameController) GetAvaibleChars(ctx *dto.WsContext) ([]byte, error) {
 		return nil, err
 	}
 
-	if len(*avChars) < cfg.AVAILABLE_CHARACTERS_NUMBER {
-		n := cfg.AVAILABLE_CHARACTERS_NUMBER - len(*avChars)
-		createdAvChars, err := gc.avCharService.CreateMany(ctx.Player, n)
+	if len(*avChars) < ctx.Game.Rules.RackSize {
+		pack, err := gc.packs.Get(ctx.Game.Rules.Language)
+		if err != nil {
+			return nil, err
+		}
+		n := ctx.Game.Rules.RackSize - len(*avChars)
+		createdAvChars, err := gc.avCharService.CreateMany(
+			ctx.Player,
+			n,
+			pack.Distribution,
+		)
 		if err != nil {
 			return nil, err
 		}
@@ -577,21 +979,67 @@ func (gc *gameController) RemoveChars(ctx *dto.WsContext, chars *[]dto.Char) err
 
 func (gc *gameController) ReceiveChars(
 	ctx *dto.WsContext, playData *dto.PlayData,
-) ([]byte, []byte, error) {
-	err := gc.checkChars(ctx.Player.UUID, &playData.Chars)
+) (broadcastResponse []byte, senderResponse []byte, err error) {
+	// Stage of checking the play, counted when the play is rejected
+	reason := metrics.ReasonInternal
+	defer func() {
+		if err != nil {
+			gc.metrics.PlayRejected(reason)
+		} else {
+			gc.metrics.PlayAccepted()
+		}
+	}()
+
+	pack, err := gc.packs.Get(ctx.Game.Rules.Language)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Letters with diacritics can be sent in decomposed form
+	for i := range playData.Chars {
+		playData.Chars[i].Value = lang.Normalize(playData.Chars[i].Value)
+	}
+
+	reason = metrics.ReasonTurn
+	if err := gc.checkTurn(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	reason = metrics.ReasonChars
+	err = gc.checkChars(&ctx.Game.Rules, ctx.Player.UUID, &playData.Chars)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	word, fieldsData, err := gc.obtainWordAndFieldsData(ctx.Game.UUID, playData)
+	reason = metrics.ReasonPlacement
+	word, fieldsData, err := gc.obtainWordAndFieldsData(
+		ctx.Game,
+		playData,
+		gc.contextLogger(ctx),
+	)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	err = gc.wordsController.CheckWord(word)
+	// In challenge mode words are looked up only when challenged. The word
+	// that wins the game cannot be challenged after it, so it is looked up.
+	points := pack.Score(word)
+	winning := ctx.Game.Rules.IsWinning(ctx.Player.Points + points)
+	provisional := ctx.Game.Rules.ChallengeMode && !winning
+	reason = metrics.ReasonWord
+	if provisional {
+		err = gc.wordsController.CheckWordLen(&ctx.Game.Rules, word)
+	} else {
+		err = gc.checkWord(ctx.Game, word)
+	}
 	if err != nil {
 		return nil, nil, err
 	}
+	reason = metrics.ReasonInternal
+
+	// Previous word cannot be challenged after the next play
+	if err := gc.acceptLastPlay(ctx.Game.UUID); err != nil {
+		return nil, nil, err
+	}
 
 	newFields, err := gc.fieldService.CreateMany(
 		ctx.Game.UUID,
@@ -603,13 +1051,40 @@ func (gc *gameController) ReceiveChars(
 		return nil, nil, err
 	}
 
+	_, err = gc.playService.Create(
+		ctx.Player,
+		ctx.Player.Appends,
+		word,
+		points,
+		provisional,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	ctx.Player.Appends += 1
+	ctx.Player.Points += points
 	err = gc.playerService.Update(ctx.Player)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	response, err := gc.buildHtmlFields(newFields)
+	var notice []byte
+	if winning {
+		notice, err = gc.finish(ctx.Game, ctx.Player)
+	} else {
+		err = gc.passTurn(ctx.Game)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	players, err := gc.getPlayersMap(ctx.Game.UUID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response, err := gc.buildHtmlFields(&ctx.Game.Rules, newFields, players)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -619,6 +1094,13 @@ func (gc *gameController) ReceiveChars(
 		return nil, nil, err
 	}
 
+	playersResponse, err := gc.GetPlayers(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	response = append(response, playersResponse...)
+	response = append(response, notice...)
+
 	avCharsResponse, err := gc.GetAvaibleChars(ctx)
 	if err != nil {
 		return nil, nil, err
@@ -626,3 +1108,231 @@ func (gc *gameController) ReceiveChars(
 
 	return response, avCharsResponse, nil
 }
+
+func (gc *gameController) Challenge(
+	ctx *dto.WsContext,
+) ([]byte, []byte, *model.Play, error) {
+	if err := gc.checkNotFinished(ctx.Game); err != nil {
+		return nil, nil, nil, err
+	}
+	if !ctx.Game.Rules.ChallengeMode {
+		return nil, nil, nil, errors.New("words cannot be challenged in this game")
+	}
+	play, err := gc.playService.GetLast(ctx.Game.UUID)
+	if errors.Is(err, repo.ErrNotExists) {
+		return nil, nil, nil, errors.New("there is no word to challenge")
+	}
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if !play.Provisional {
+		return nil, nil, nil, fmt.Errorf("word %v cannot be challenged anymore", play.Word)
+	}
+	if play.PlayerUUID == ctx.Player.UUID {
+		return nil, nil, nil, errors.New("you cannot challenge your own word")
+	}
+
+	checkErr := gc.checkWord(ctx.Game, play.Word)
+	if checkErr == nil {
+		if err := gc.playService.Accept(play); err != nil {
+			return nil, nil, nil, err
+		}
+		if err := gc.loseTurn(ctx); err != nil {
+			return nil, nil, nil, err
+		}
+		response, err := gc.GetPlayers(ctx)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		notice, err := gc.BuildNotice(fmt.Sprintf(
+			"%v challenged %v and lost a turn, the word stays",
+			ctx.Player.Name, play.Word,
+		))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return append(response, notice...), nil, nil, nil
+	}
+
+	response, err := gc.removePlay(ctx.Game, play)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	playersResponse, err := gc.GetPlayers(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	notice, err := gc.BuildNotice(fmt.Sprintf(
+		"%v challenged %v and the word was taken off the board; %v",
+		ctx.Player.Name, play.Word, checkErr,
+	))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	response = append(response, playersResponse...)
+	return append(response, notice...), nil, play, nil
+}
+
+func (gc *gameController) UndoPlay(
+	ctx *dto.WsContext,
+) ([]byte, []byte, *model.Play, error) {
+	if err := gc.checkNotFinished(ctx.Game); err != nil {
+		return nil, nil, nil, err
+	}
+	play, err := gc.playService.GetLast(ctx.Game.UUID)
+	if errors.Is(err, repo.ErrNotExists) {
+		return nil, nil, nil, errors.New("there is no play to take back")
+	}
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if play.PlayerUUID != ctx.Player.UUID {
+		return nil, nil, nil, errors.New("only the last play can be taken back by its author")
+	}
+
+	players, err := gc.gameService.TurnOrder(ctx.Game.UUID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(players) == 1 {
+		response, err := gc.undo(ctx, play)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return response, nil, play, nil
+	}
+
+	if err := gc.playService.SetUndoRequested(play, true); err != nil {
+		return nil, nil, nil, err
+	}
+	response, err := gc.buildHtmlUndoRequest(ctx.Player, play)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	senderResponse, err := gc.BuildNotice(
+		fmt.Sprintf("Waiting for opponents to accept taking back %v", play.Word),
+	)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return response, senderResponse, nil, nil
+}
+
+// A single opponent decides about the undo
+func (gc *gameController) AnswerUndo(
+	ctx *dto.WsContext,
+	accept bool,
+) ([]byte, []byte, *model.Play, error) {
+	if err := gc.checkNotFinished(ctx.Game); err != nil {
+		return nil, nil, nil, err
+	}
+	play, err := gc.playService.GetLast(ctx.Game.UUID)
+	if err != nil && !errors.Is(err, repo.ErrNotExists) {
+		return nil, nil, nil, err
+	}
+	if err != nil || !play.UndoRequested {
+		return nil, nil, nil, errors.New("no play waits to be taken back")
+	}
+	if play.PlayerUUID == ctx.Player.UUID {
+		return nil, nil, nil, errors.New("opponents have to answer your request")
+	}
+
+	if accept {
+		response, err := gc.undo(ctx, play)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return response, nil, play, nil
+	}
+
+	if err := gc.playService.SetUndoRequested(play, false); err != nil {
+		return nil, nil, nil, err
+	}
+	response, err := gc.BuildNotice(fmt.Sprintf(
+		"%v declined taking back %v", ctx.Player.Name, play.Word,
+	))
+	return response, nil, nil, err
+}
+
+func (gc *gameController) Tick(game *model.Game, now time.Time) (
+	[]byte, *model.Player, error,
+) {
+	if game.IsFinished() || !game.Rules.HasClock() {
+		return nil, nil, nil
+	}
+	current, err := gc.gameService.CurrentPlayer(game)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var passed *model.Player
+	var notice []byte
+	left, _ := game.TurnTimeLeft(current, now)
+	if left <= 0 {
+		if err := gc.passTurn(game); err != nil {
+			return nil, nil, err
+		}
+		passed = current
+		notice, err = gc.BuildNotice(
+			fmt.Sprintf("%v ran out of time", current.Name),
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	response, err := gc.GetPlayers(&dto.WsContext{Game: game})
+	if err != nil {
+		return nil, nil, err
+	}
+	return append(response, notice...), passed, nil
+}
+
+func (gc *gameController) Forfeit(
+	game *model.Game,
+	now time.Time,
+	after time.Duration,
+) (*model.Player, error) {
+	if !game.Rules.Correspondence || game.IsFinished() || after <= 0 {
+		return nil, nil
+	}
+	current, err := gc.gameService.CurrentPlayer(game)
+	if err != nil {
+		return nil, err
+	}
+	// Turns passed on timeout start again, so inactivity is counted from the
+	// last play, or from joining before anyone played
+	lastActivity := current.CreateDate
+	play, err := gc.playService.GetLast(game.UUID)
+	if err != nil && !errors.Is(err, repo.ErrNotExists) {
+		return nil, err
+	}
+	if err == nil && play.CreateDate.After(lastActivity) {
+		lastActivity = play.CreateDate
+	}
+	if now.Sub(lastActivity) < after {
+		return nil, nil
+	}
+	if err := gc.gameService.Forfeit(game, current); err != nil {
+		return nil, err
+	}
+
+	message := fmt.Sprintf("%v did not play for %v and forfeited", current.Name, after)
+	if err := gc.notifyPlayers(game, notify.EventForfeit, message); err != nil {
+		return nil, err
+	}
+	return current, nil
+}
+
+func (gc *gameController) BuildNotice(message string) ([]byte, error) {
+	var htmlContent bytes.Buffer
+	tmpl, err := template.ParseFiles("views/game/notice.html")
+	if err != nil {
+		return nil, err
+	}
+	err = gc.execute(tmpl, &htmlContent, struct{ Message string }{message})
+	if err != nil {
+		return nil, err
+	}
+	return htmlContent.Bytes(), nil
+}
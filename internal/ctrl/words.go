@@ -4,52 +4,126 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"scrable3/internal/cfg"
-	"strings"
+	"scrable3/internal/lang"
+	"scrable3/internal/model"
+	"scrable3/internal/svc"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
 )
 
+// Checks words against layered dictionaries. The words list of the game
+// language is the base layer, house rule words of the game are put on top of it
+// and can allow words missing from the base list or block the ones on it.
 type WordsController interface {
-	WordsNumber() int
-	CheckWord(word string) error
+	WordsNumber(language string) int
+	CheckWord(game *model.Game, word string) error
+	// Checks only the length of the word, used for words accepted without
+	// looking them up
+	CheckWordLen(rules *model.GameRules, word string) error
 }
 
 type wordsController struct {
-	words *map[string]bool
+	// Normalized words by language code
+	words           map[string]map[string]bool
+	gameWordService svc.GameWordService
+}
+
+func NewWordsController(
+	packs lang.Packs,
+	gameWordService svc.GameWordService,
+) (WordsController, error) {
+	wordsController := wordsController{
+		words:           make(map[string]map[string]bool, len(packs)),
+		gameWordService: gameWordService,
+	}
+
+	for code, pack := range packs {
+		words, err := wordsController.loadWords(pack.WordsFile)
+		if err != nil {
+			return &wordsController, err
+		}
+		wordsController.words[code] = words
+	}
+	return &wordsController, nil
 }
 
-func NewWordsController(filePath string) (WordsController, error) {
-	wordsController := wordsController{}
-	wordMap := make(map[string]bool)
-	wordsController.words = &wordMap
+// |PRIVATE| //
+
+func (wc *wordsController) loadWords(filePath string) (map[string]bool, error) {
+	words := make(map[string]bool)
 
 	fl, err := os.Open(filePath)
 	if err != nil {
-		return &wordsController, err
+		return words, err
 	}
 	defer fl.Close()
 
 	scanner := bufio.NewScanner(fl)
 	for scanner.Scan() {
-		line := scanner.Text()
-		if len(line) < cfg.MIN_WORD_LEN {
+		line := lang.NormalizeWord(scanner.Text())
+		if line == "" {
 			continue
 		}
-		(*wordsController.words)[line] = true
+		words[line] = true
 	}
-	return &wordsController, nil
+	return words, scanner.Err()
 }
 
-func (wc *wordsController) WordsNumber() int {
-	return len(*wc.words)
+// Returns decision of the game layer about the normalized word, 'ok' is false
+// when the game does not mention the word
+func (wc *wordsController) checkGameLayer(
+	gameUUID uuid.UUID,
+	word string,
+) (allowed bool, ok bool, err error) {
+	gameWords, err := wc.gameWordService.GetWithGameUUID(gameUUID)
+	if err != nil {
+		return false, false, err
+	}
+	for _, gameWord := range *gameWords {
+		if gameWord.Word == word {
+			return gameWord.Allowed, true, nil
+		}
+	}
+	return false, false, nil
 }
 
-func (wc *wordsController) CheckWord(word string) error {
-	if len(word) < cfg.MIN_WORD_LEN {
-		return fmt.Errorf("word %v is too short", word)
+// |PUBLIC| //
+
+func (wc *wordsController) WordsNumber(language string) int {
+	return len(wc.words[language])
+}
+
+func (wc *wordsController) CheckWord(game *model.Game, word string) error {
+	rules := &game.Rules
+	words, ok := wc.words[rules.Language]
+	if !ok {
+		return fmt.Errorf("no words list for language %v", rules.Language)
+	}
+	if err := wc.CheckWordLen(rules, word); err != nil {
+		return err
+	}
+	normalized := lang.NormalizeWord(word)
+
+	allowed, ok, err := wc.checkGameLayer(game.UUID, normalized)
+	if err != nil {
+		return err
 	}
-	_, ok := (*wc.words)[strings.ToLower(word)]
 	if !ok {
+		allowed = words[normalized]
+	}
+	switch {
+	case !allowed && ok:
+		return fmt.Errorf("word %v is blocked in this game", word)
+	case !allowed:
 		return fmt.Errorf("word %v is not on words list", word)
 	}
 	return nil
 }
+
+func (wc *wordsController) CheckWordLen(rules *model.GameRules, word string) error {
+	if utf8.RuneCountInString(lang.NormalizeWord(word)) < rules.MinWordLen {
+		return fmt.Errorf("word %v is too short", word)
+	}
+	return nil
+}
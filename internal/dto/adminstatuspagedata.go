@@ -0,0 +1,37 @@
+package dto
+
+type AdminStatusPageData struct {
+	Title       string
+	StartDate   string
+	Uptime      string
+	Connections int
+	Games       []AdminStatusGame
+	Memory      AdminStatusMemory
+	Build       AdminStatusBuild
+}
+
+// Game with players connected
+type AdminStatusGame struct {
+	GameUUID    string
+	Language    string
+	Connections int
+	Players     []HtmlPlayerData
+}
+
+type AdminStatusMemory struct {
+	// Bytes of allocated heap objects
+	HeapAlloc uint64
+	// Bytes obtained from the system
+	Sys        uint64
+	NumGC      uint32
+	Goroutines int
+}
+
+type AdminStatusBuild struct {
+	GoVersion    string
+	Path         string
+	Version      string
+	Revision     string
+	RevisionTime string
+	Modified     bool
+}
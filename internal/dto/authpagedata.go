@@ -0,0 +1,7 @@
+package dto
+
+type AuthPageData struct {
+	Title string
+	// Path the form is posted to, "/login" or "/register"
+	Action string
+}
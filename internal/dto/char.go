@@ -17,13 +17,15 @@ type Char struct {
 	Position [2]int `json:"pos"`
 }
 
+// Upper case letter of any alphabet followed by the available character ID
+var charIDRegexp = regexp.MustCompile(`^char-(\p{Lu})(\d+)$`)
+
 func (d *Char) ParseID() (int64, error) {
 	if !strings.HasPrefix(d.HtmlIdentifier, "char-") {
 		return 0, fmt.Errorf("char id must start with 'char-'")
 	}
 
-	re := regexp.MustCompile(`^char-([A-Z])(\d+)$`)
-	matches := re.FindStringSubmatch(d.HtmlIdentifier)
+	matches := charIDRegexp.FindStringSubmatch(d.HtmlIdentifier)
 	if len(matches) != 3 {
 		return 0, fmt.Errorf("invalid char id format")
 	}
@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"scrable3/internal/model"
 	"testing"
 )
 
@@ -32,6 +33,14 @@ func TestValidParseID(t *testing.T) {
 			},
 			expected: 123,
 		},
+		{
+			name: "letter with diacritic",
+			char: Char{
+				Value:          "Ż",
+				HtmlIdentifier: "char-Ż42",
+			},
+			expected: 42,
+		},
 		{
 			name: "large number",
 			char: Char{
@@ -101,6 +110,14 @@ func TestInvalidParseID(t *testing.T) {
 			},
 			expectedErr: "invalid char id format",
 		},
+		{
+			name: "lowercase letter with diacritic",
+			char: Char{
+				Value:          "Ż",
+				HtmlIdentifier: "char-ż1",
+			},
+			expectedErr: "invalid char id format",
+		},
 		{
 			name: "empty identifier",
 			char: Char{
@@ -132,3 +149,47 @@ func TestInvalidParseID(t *testing.T) {
 		})
 	}
 }
+
+func TestNewHtmlFieldData(t *testing.T) {
+	testCases := []struct {
+		name      string
+		boardSize int
+		pos       [3]int
+		expected  HtmlFieldData
+	}{
+		{
+			name:      "nearest field of default board",
+			boardSize: 15,
+			pos:       [3]int{7, 7, 0},
+			expected:  HtmlFieldData{Repr: "A070700", Value: "A", X: 420, Y: 420, Z: 420},
+		},
+		{
+			name:      "farthest field of default board",
+			boardSize: 15,
+			pos:       [3]int{0, 14, 14},
+			expected:  HtmlFieldData{Repr: "A000E0E", Value: "A", X: 0, Y: 840, Z: -420},
+		},
+		{
+			name:      "middle of small board",
+			boardSize: 9,
+			pos:       [3]int{4, 4, 4},
+			expected:  HtmlFieldData{Repr: "A040404", Value: "A", X: 240, Y: 240, Z: 0},
+		},
+		{
+			name:      "even board",
+			boardSize: 10,
+			pos:       [3]int{9, 0, 9},
+			expected:  HtmlFieldData{Repr: "A090009", Value: "A", X: 540, Y: 0, Z: -270},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rules := &model.GameRules{BoardSize: tc.boardSize}
+			data := NewHtmlFieldData(rules, "A", tc.pos[0], tc.pos[1], tc.pos[2])
+			if *data != tc.expected {
+				t.Errorf("expected %+v, got %+v", tc.expected, *data)
+			}
+		})
+	}
+}
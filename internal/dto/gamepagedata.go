@@ -3,4 +3,13 @@ package dto
 type GamePageData struct {
 	Title    string
 	GameUUID string
+	// Number of fields along every edge of the cube
+	BoardSize int
+	// Words can be challenged instead of being checked on play
+	ChallengeMode bool
+	// Plays are posted from the page, there is no websocket
+	Correspondence bool
+	CSRFToken      string
+	// Picture of the board shown in link previews
+	SnapshotURL string
 }
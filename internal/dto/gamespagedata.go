@@ -0,0 +1,15 @@
+package dto
+
+type GamesPageData struct {
+	Title     string
+	CSRFToken string
+	Login     string
+	Active    []GamesPageEntry
+	Finished  []GamesPageEntry
+}
+
+type GamesPageEntry struct {
+	GameUUID   string
+	UpdateDate string
+	Players    []HtmlPlayerData
+}
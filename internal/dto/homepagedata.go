@@ -1,5 +1,16 @@
 package dto
 
+import "scrable3/internal/model"
+
 type HomePageData struct {
-	Title string
+	Title     string
+	CSRFToken string
+	// Default values of the create game form
+	Rules     model.GameRules
+	Languages []LanguageOption
+}
+
+type LanguageOption struct {
+	Code string
+	Name string
 }
@@ -2,6 +2,7 @@ package dto
 
 import (
 	"fmt"
+	"scrable3/internal/model"
 
 	"golang.org/x/exp/constraints"
 )
@@ -12,19 +13,31 @@ type HtmlFieldData struct {
 	X     int
 	Y     int
 	Z     int
+	// Name and colour of the player that placed the field
+	PlayerName  string
+	PlayerColor string
 }
 
-func NewHtmlFieldData[T constraints.Integer](value string, x T, y T, z T) *HtmlFieldData {
+// Size of a single field in pixels
+const fieldSize = 60
+
+func NewHtmlFieldData[T constraints.Integer](
+	rules *model.GameRules, value string, x T, y T, z T,
+) *HtmlFieldData {
+	// translateZ moves the field from the middle of the cube, the nearest
+	// field to the north side is half of the cube without half of the field
+	// away from it
+	nearestZ := (rules.BoardSize - 1) * fieldSize / 2
 	d := &HtmlFieldData{
 		Value: value,
-		X:     int(x) * 60,
-		Y:     int(y) * 60,
-		Z:     420 - (int(z) * 60),
+		X:     int(x) * fieldSize,
+		Y:     int(y) * fieldSize,
+		Z:     nearestZ - (int(z) * fieldSize),
 	}
 
-	d.Repr = d.Value +
-		fmt.Sprintf("%X", x) +
-		fmt.Sprintf("%X", y) +
-		fmt.Sprintf("%X", z)
+	// Two hex digits per position, boards larger than 16 fields have
+	// positions above F and single digits would make ids like "1110" stand
+	// for both 1, 1, 16 and 1, 17, 0
+	d.Repr = d.Value + fmt.Sprintf("%02X%02X%02X", x, y, z)
 	return d
 }
@@ -0,0 +1,11 @@
+package dto
+
+type HtmlGameWordsData struct {
+	GameUUID  string
+	CSRFToken string
+	Allowed   []string
+	Blocked   []string
+	// Only the game creator can edit the lists before the game starts
+	Editable bool
+	Error    string
+}
@@ -0,0 +1,11 @@
+package dto
+
+type HtmlPlayerData struct {
+	Name   string
+	Color  string
+	Points int64
+	// Player makes the next play
+	HasTurn bool
+	// Remaining time written as "m:ss", empty when the game has no clock
+	Clock string
+}
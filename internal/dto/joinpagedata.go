@@ -0,0 +1,13 @@
+package dto
+
+type JoinPageData struct {
+	Title    string
+	GameUUID string
+	// Sent back by the form in the X-CSRF-Token header
+	CSRFToken string
+	Name      string
+	Color     string
+	Error     string
+	// Picture of the board shown in link previews
+	SnapshotURL string
+}
@@ -3,8 +3,10 @@ package dto
 import "errors"
 
 type PlayData struct {
-	SideInt int    `json:"side"`
-	Chars   []Char `json:"chars"`
+	SideInt int `json:"side"`
+	// 90 when the cube is tilted to show the top, -90 for the bottom
+	Tilt  int    `json:"tilt"`
+	Chars []Char `json:"chars"`
 }
 
 func (d *PlayData) Validate() error {
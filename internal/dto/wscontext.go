@@ -1,8 +1,14 @@
 package dto
 
-import "scrable3/internal/model"
+import (
+	"log/slog"
+	"scrable3/internal/model"
+)
 
 type WsContext struct {
 	Game   *model.Game
 	Player *model.Player
+	// Logger of the request tagged with the game and the player, nil when the
+	// controller should use its own
+	Logger *slog.Logger
 }
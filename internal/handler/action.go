@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"scrable3/internal/ctrl"
+	"scrable3/internal/dto"
+	"scrable3/internal/logging"
+	"scrable3/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// Actions are sent as JSON over websocket in live games and posted from the
+// game page in correspondence games
+
+func unmarshalAndValidate(data []byte, v dto.Validatable) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("error unmarshaling data: %w", err)
+	}
+	return v.Validate()
+}
+
+func buildErrPopup(e error) ([]byte, error) {
+	var htmlContent []byte
+	tmpl, err := template.ParseFiles("views/game/error-popup.html")
+	if err != nil {
+		return htmlContent, err
+	}
+	type errorMessage struct{ Error string }
+	eMsg := errorMessage{e.Error()}
+
+	var buff bytes.Buffer
+	err = tmpl.Execute(&buff, eMsg)
+	if err != nil {
+		return htmlContent, err
+	}
+	htmlContent = buff.Bytes()
+	return htmlContent, nil
+}
+
+// Runs the action of the player. 'rackOwner' is the player whose tiles came
+// back from the board, uuid.Nil when racks did not change.
+func performAction(
+	gameController ctrl.GameController,
+	ctx *dto.WsContext,
+	p []byte,
+) (
+	broadcastResponse []byte,
+	senderResponse []byte,
+	rackOwner uuid.UUID,
+	err error,
+) {
+	action := &dto.ActionData{}
+	err = unmarshalAndValidate(p, action)
+	if err != nil {
+		return nil, nil, uuid.Nil, err
+	}
+
+	// Lines logged by the controller tell which action they belong to
+	actionCtx := *ctx
+	if actionCtx.Logger == nil {
+		actionCtx.Logger = slog.Default()
+	}
+	actionCtx.Logger = actionCtx.Logger.With(logging.KeyAction, action.Type)
+	ctx = &actionCtx
+	defer func() {
+		if err != nil {
+			ctx.Logger.Info("action rejected", logging.KeyErr, err)
+		} else {
+			ctx.Logger.Debug("action performed")
+		}
+	}()
+
+	switch action.Type {
+	case "addTest":
+		broadcastResponse, err = ctrl.GetRandomField()
+	case "raiseExampleError":
+		senderResponse, err = ctrl.GetExampleError()
+	case "dismissError":
+		senderResponse = []byte(`<div id="error-dialog"></div>`)
+	case "getChars":
+		senderResponse, err = gameController.GetAvaibleChars(ctx)
+	case "makePlay":
+		playData := &dto.PlayData{}
+		err = unmarshalAndValidate(p, playData)
+		if err != nil {
+			break
+		}
+		broadcastResponse, senderResponse, err = gameController.ReceiveChars(ctx, playData)
+		if err != nil {
+			break
+		}
+		senderResponse, err = gameController.GetAvaibleChars(ctx)
+	case "challenge":
+		var challenged *model.Play
+		broadcastResponse, senderResponse, challenged, err = gameController.Challenge(ctx)
+		if err == nil && challenged != nil {
+			rackOwner = challenged.PlayerUUID
+		}
+	case "undoPlay", "acceptUndo", "rejectUndo":
+		var undone *model.Play
+		if action.Type == "undoPlay" {
+			broadcastResponse, senderResponse, undone, err = gameController.UndoPlay(ctx)
+		} else {
+			accept := action.Type == "acceptUndo"
+			broadcastResponse, senderResponse, undone, err = gameController.AnswerUndo(ctx, accept)
+		}
+		if err == nil && undone != nil {
+			rackOwner = undone.PlayerUUID
+		}
+	}
+	return broadcastResponse, senderResponse, rackOwner, err
+}
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"html/template"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"scrable3/internal/dto"
+	"scrable3/internal/logging"
+	"scrable3/internal/svc"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Pages for users listed as admins in the configuration
+type adminHandler struct {
+	gameService    svc.GameService
+	playerService  svc.PlayerService
+	userService    svc.UserService
+	sessionService svc.SessionService
+	hub            Hub
+	adminLogins    []string
+	startDate      time.Time
+}
+
+func NewAdminHandler(
+	gameService svc.GameService,
+	playerService svc.PlayerService,
+	userService svc.UserService,
+	sessionService svc.SessionService,
+	hub Hub,
+	adminLogins []string,
+) http.Handler {
+	return &adminHandler{
+		gameService:    gameService,
+		playerService:  playerService,
+		userService:    userService,
+		sessionService: sessionService,
+		hub:            hub,
+		adminLogins:    adminLogins,
+		startDate:      time.Now(),
+	}
+}
+
+// |PRIVATE| //
+
+// Redirects anonymous users to log in and forbids users that are not admins.
+// Returns false when the request was answered.
+func (h *adminHandler) authorize(w http.ResponseWriter, r *http.Request) bool {
+	_, user, err := getSessionUser(r, h.sessionService, h.userService)
+	if err != nil || user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return false
+	}
+	if !slices.Contains(h.adminLogins, user.Login) {
+		requestLogger(r).Warn("admin page forbidden", "login", user.Login)
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+func (h *adminHandler) buildInfo() dto.AdminStatusBuild {
+	build := dto.AdminStatusBuild{GoVersion: runtime.Version()}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return build
+	}
+	build.Path = info.Main.Path
+	build.Version = info.Main.Version
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			build.Revision = setting.Value
+		case "vcs.time":
+			build.RevisionTime = setting.Value
+		case "vcs.modified":
+			build.Modified = setting.Value == "true"
+		}
+	}
+	return build
+}
+
+// Games with players connected, in a stable order
+func (h *adminHandler) liveGames(r *http.Request) ([]dto.AdminStatusGame, int) {
+	connections := h.hub.Connections()
+	gameUUIDs := make([]uuid.UUID, 0, len(connections))
+	for gameUUID := range connections {
+		gameUUIDs = append(gameUUIDs, gameUUID)
+	}
+	slices.SortFunc(gameUUIDs, func(a, b uuid.UUID) int {
+		return slices.Compare(a[:], b[:])
+	})
+
+	total := 0
+	games := make([]dto.AdminStatusGame, 0, len(gameUUIDs))
+	for _, gameUUID := range gameUUIDs {
+		total += connections[gameUUID]
+		entry := dto.AdminStatusGame{
+			GameUUID:    gameUUID.String(),
+			Connections: connections[gameUUID],
+		}
+		// Games deleted in the meantime are still listed with their connections
+		game, err := h.gameService.GetWithUUID(gameUUID)
+		if err != nil {
+			requestLogger(r).Warn("live game", logging.KeyGame, gameUUID, logging.KeyErr, err)
+			games = append(games, entry)
+			continue
+		}
+		entry.Language = game.Rules.Language
+		players, err := h.playerService.GetWithGameUUID(gameUUID)
+		if err != nil {
+			requestLogger(r).Warn("live game players", logging.KeyGame, gameUUID, logging.KeyErr, err)
+		} else {
+			for _, player := range *players {
+				entry.Players = append(entry.Players, dto.HtmlPlayerData{
+					Name:   player.Name,
+					Color:  player.Color,
+					Points: player.Points,
+				})
+			}
+		}
+		games = append(games, entry)
+	}
+	return games, total
+}
+
+func (h *adminHandler) getStatus(w http.ResponseWriter, r *http.Request) {
+	tmpl, err := template.ParseFiles("views/admin/status.html")
+	if err != nil {
+		requestLogger(r).Error("template", logging.KeyErr, err)
+		http.Error(w, err.Error(), 500)
+		return
+	}
+
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+
+	data := dto.AdminStatusPageData{
+		Title:     "Server status",
+		StartDate: h.startDate.Format(time.DateTime),
+		Uptime:    time.Since(h.startDate).Round(time.Second).String(),
+		Memory: dto.AdminStatusMemory{
+			HeapAlloc:  memStats.HeapAlloc,
+			Sys:        memStats.Sys,
+			NumGC:      memStats.NumGC,
+			Goroutines: runtime.NumGoroutine(),
+		},
+		Build: h.buildInfo(),
+	}
+	data.Games, data.Connections = h.liveGames(r)
+
+	err = tmpl.Execute(w, data)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+	}
+}
+
+// |PUBLIC| //
+
+func (h *adminHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !h.authorize(w, r) {
+		return
+	}
+	h.getStatus(w, r)
+}
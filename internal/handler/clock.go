@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"log/slog"
+	"scrable3/internal/ctrl"
+	"scrable3/internal/logging"
+	"scrable3/internal/svc"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// How often clocks of active games are checked and sent to players
+const clockInterval = time.Second
+
+// Runs a ticker for every game with a clock while its players are connected.
+// Clock state lives in the repository, so tickers pick up where they stopped
+// after a restart.
+type GameClocks interface {
+	// Starts the ticker of the game, does nothing when it already runs
+	Start(gameUUID uuid.UUID)
+	Stop(gameUUID uuid.UUID)
+	// Stops tickers of all games, used when the server shuts down
+	StopAll()
+}
+
+type gameClocks struct {
+	gameService    svc.GameService
+	gameController ctrl.GameController
+	hub            Hub
+	logger         *slog.Logger
+	mu             sync.Mutex
+	// Closing the channel stops the ticker of the game
+	tickers map[uuid.UUID]chan struct{}
+}
+
+func NewGameClocks(
+	gameService svc.GameService,
+	gameController ctrl.GameController,
+	hub Hub,
+	logger *slog.Logger,
+) GameClocks {
+	return &gameClocks{
+		gameService:    gameService,
+		gameController: gameController,
+		hub:            hub,
+		logger:         logger,
+		tickers:        make(map[uuid.UUID]chan struct{}),
+	}
+}
+
+// |PRIVATE| //
+
+func (c *gameClocks) run(gameUUID uuid.UUID, stop chan struct{}) {
+	ticker := time.NewTicker(clockInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			if !c.tick(gameUUID, now) {
+				c.remove(gameUUID, stop)
+				return
+			}
+		}
+	}
+}
+
+// Forgets the ticker that stopped on its own, unless the game got a new one
+func (c *gameClocks) remove(gameUUID uuid.UUID, stop chan struct{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.tickers[gameUUID] == stop {
+		delete(c.tickers, gameUUID)
+	}
+}
+
+// Returns false when the game no longer needs the clock
+func (c *gameClocks) tick(gameUUID uuid.UUID, now time.Time) bool {
+	unlock := c.hub.LockGame(gameUUID)
+	defer unlock()
+	logger := c.logger.With(logging.KeyGame, gameUUID)
+
+	game, err := c.gameService.GetWithUUID(gameUUID)
+	if err != nil {
+		logger.Error("clock game", logging.KeyErr, err)
+		return false
+	}
+	if game.IsFinished() || !game.Rules.HasClock() {
+		return false
+	}
+	response, passed, err := c.gameController.Tick(game, now)
+	if err != nil {
+		logger.Error("clock tick", logging.KeyErr, err)
+		return true
+	}
+	if passed != nil {
+		logger.Info("player ran out of time", logging.KeyPlayer, passed.UUID)
+	}
+	c.hub.Broadcast(gameUUID, response)
+	return true
+}
+
+// |PUBLIC| //
+
+func (c *gameClocks) Start(gameUUID uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.tickers[gameUUID]; ok {
+		return
+	}
+	stop := make(chan struct{})
+	c.tickers[gameUUID] = stop
+	go c.run(gameUUID, stop)
+}
+
+func (c *gameClocks) Stop(gameUUID uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if stop, ok := c.tickers[gameUUID]; ok {
+		close(stop)
+		delete(c.tickers, gameUUID)
+	}
+}
+
+func (c *gameClocks) StopAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for gameUUID, stop := range c.tickers {
+		close(stop)
+		delete(c.tickers, gameUUID)
+	}
+}
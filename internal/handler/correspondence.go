@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"log/slog"
+	"scrable3/internal/ctrl"
+	"scrable3/internal/logging"
+	"scrable3/internal/svc"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// How often correspondence games are checked for expired turns and inactivity
+const correspondenceInterval = time.Minute
+
+// Correspondence games have no players connected to run their clocks, so
+// their turns are checked in the background. Expired turns pass to the next
+// player and games nobody played for too long are forfeited.
+type CorrespondenceSweeper interface {
+	Start()
+	Stop()
+	// Checks every unfinished correspondence game once
+	Sweep(now time.Time)
+}
+
+type correspondenceSweeper struct {
+	gameService    svc.GameService
+	gameController ctrl.GameController
+	hub            Hub
+	forfeitAfter   time.Duration
+	logger         *slog.Logger
+	mu             sync.Mutex
+	// Closing the channel stops the sweeper, nil when it is not running
+	stop chan struct{}
+}
+
+func NewCorrespondenceSweeper(
+	gameService svc.GameService,
+	gameController ctrl.GameController,
+	hub Hub,
+	forfeitAfter time.Duration,
+	logger *slog.Logger,
+) CorrespondenceSweeper {
+	return &correspondenceSweeper{
+		gameService:    gameService,
+		gameController: gameController,
+		hub:            hub,
+		forfeitAfter:   forfeitAfter,
+		logger:         logger,
+	}
+}
+
+// |PRIVATE| //
+
+func (s *correspondenceSweeper) run(stop chan struct{}) {
+	ticker := time.NewTicker(correspondenceInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			s.Sweep(now)
+		}
+	}
+}
+
+func (s *correspondenceSweeper) sweepGame(gameUUID uuid.UUID, now time.Time) {
+	unlock := s.hub.LockGame(gameUUID)
+	defer unlock()
+	logger := s.logger.With(logging.KeyGame, gameUUID)
+
+	game, err := s.gameService.GetWithUUID(gameUUID)
+	if err != nil {
+		logger.Error("sweep game", logging.KeyErr, err)
+		return
+	}
+	forfeited, err := s.gameController.Forfeit(game, now, s.forfeitAfter)
+	if err != nil {
+		logger.Error("forfeit", logging.KeyErr, err)
+		return
+	}
+	if forfeited != nil {
+		logger.Info("player forfeited", logging.KeyPlayer, forfeited.UUID)
+		return
+	}
+
+	response, passed, err := s.gameController.Tick(game, now)
+	if err != nil {
+		logger.Error("sweep tick", logging.KeyErr, err)
+		return
+	}
+	if passed != nil {
+		logger.Info("player ran out of time", logging.KeyPlayer, passed.UUID)
+		s.hub.Broadcast(game.UUID, response)
+	}
+}
+
+// |PUBLIC| //
+
+func (s *correspondenceSweeper) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil {
+		return
+	}
+	s.stop = make(chan struct{})
+	go s.run(s.stop)
+}
+
+func (s *correspondenceSweeper) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil {
+		close(s.stop)
+		s.stop = nil
+	}
+}
+
+func (s *correspondenceSweeper) Sweep(now time.Time) {
+	games, err := s.gameService.GetUnfinished()
+	if err != nil {
+		s.logger.Error("unfinished games", logging.KeyErr, err)
+		return
+	}
+	for _, game := range *games {
+		if game.Rules.Correspondence {
+			s.sweepGame(game.UUID, now)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"scrable3/internal/auth"
+)
+
+const (
+	csrfCookieName = "csrf-token"
+	csrfHeaderName = "X-CSRF-Token"
+)
+
+var ErrCSRFTokenMismatch = errors.New("csrf token missing or invalid")
+
+// Protects state changing requests with double submit tokens. Pages embed the
+// token in hx-headers, so htmx sends it back in the X-CSRF-Token header.
+type CSRFProtection interface {
+	// Returns token of the request, issuing a new cookie when there is none
+	Token(w http.ResponseWriter, r *http.Request) (string, error)
+	Verify(r *http.Request) error
+}
+
+type csrfProtection struct {
+	originPolicy auth.OriginPolicy
+	secure       bool
+}
+
+func NewCSRFProtection(originPolicy auth.OriginPolicy, secure bool) CSRFProtection {
+	return &csrfProtection{
+		originPolicy: originPolicy,
+		secure:       secure,
+	}
+}
+
+func (c *csrfProtection) Token(w http.ResponseWriter, r *http.Request) (string, error) {
+	if cookie, err := r.Cookie(csrfCookieName); err == nil && len(cookie.Value) == 64 {
+		return cookie.Value, nil
+	}
+
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(tokenBytes)
+	http.SetCookie(w, &http.Cookie{
+		Name:     csrfCookieName,
+		Value:    token,
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+		Secure:   c.secure,
+	})
+	return token, nil
+}
+
+func (c *csrfProtection) Verify(r *http.Request) error {
+	if err := c.originPolicy.Check(r); err != nil {
+		return err
+	}
+
+	cookie, err := r.Cookie(csrfCookieName)
+	if err != nil {
+		return fmt.Errorf("%w: no %v cookie", ErrCSRFTokenMismatch, csrfCookieName)
+	}
+	header := r.Header.Get(csrfHeaderName)
+	if header == "" {
+		return fmt.Errorf("%w: no %v header", ErrCSRFTokenMismatch, csrfHeaderName)
+	}
+	if subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(header)) != 1 {
+		return fmt.Errorf("%w: header does not match cookie", ErrCSRFTokenMismatch)
+	}
+	return nil
+}
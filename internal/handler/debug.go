@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"scrable3/internal/logging"
+	"scrable3/internal/renderer"
+	"scrable3/internal/repo"
+	"scrable3/internal/svc"
+
+	"github.com/google/uuid"
+)
+
+// Text view of the board for debugging, shows only what the game page shows
+type debugHandler struct {
+	gameService  svc.GameService
+	fieldService svc.FieldService
+}
+
+func NewDebugHandler(
+	gameService svc.GameService,
+	fieldService svc.FieldService,
+) http.Handler {
+	return &debugHandler{
+		gameService:  gameService,
+		fieldService: fieldService,
+	}
+}
+
+// |PUBLIC| //
+
+func (h *debugHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	gameUUID, err := uuid.Parse(r.PathValue("gameUUID"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	game, err := h.gameService.GetWithUUID(gameUUID)
+	if errors.Is(err, repo.ErrNotExists) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		requestLogger(r).Error("game", logging.KeyErr, err)
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	fields, err := h.fieldService.GetWithGameUUID(gameUUID)
+	if err != nil {
+		requestLogger(r).Error("fields", logging.KeyErr, err)
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	text, err := renderer.NewText(game.Rules.BoardSize, *fields)
+	if err != nil {
+		requestLogger(r).Error("render board", logging.KeyGame, gameUUID, logging.KeyErr, err)
+		http.Error(w, err.Error(), 500)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	fmt.Fprintf(w, "game %v, turn %v, %v fields\n\n%v",
+		game.UUID, game.Turn, len(*fields), text)
+}
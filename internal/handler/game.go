@@ -1,97 +1,205 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"html/template"
 	"net/http"
 	"scrable3/internal/dto"
+	"scrable3/internal/lang"
+	"scrable3/internal/logging"
 	"scrable3/internal/model"
+	"scrable3/internal/repo"
 	"scrable3/internal/svc"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type gameHandler struct {
-	gameService   svc.GameService
-	playerService svc.PlayerService
-	fieldService  svc.FieldService
+	gameService    svc.GameService
+	playerService  svc.PlayerService
+	fieldService   svc.FieldService
+	userService    svc.UserService
+	sessionService svc.SessionService
+	playerCookies  PlayerCookies
+	csrfProtection CSRFProtection
+	// Send the session cookie only over HTTPS
+	secureCookies bool
+	// Address board previews are linked from, empty leaves them out
+	publicURL string
 }
 
-func NewGameHandler(gameService svc.GameService, playerService svc.PlayerService, fieldService svc.FieldService) http.Handler {
+func NewGameHandler(
+	gameService svc.GameService,
+	playerService svc.PlayerService,
+	fieldService svc.FieldService,
+	userService svc.UserService,
+	sessionService svc.SessionService,
+	playerCookies PlayerCookies,
+	csrfProtection CSRFProtection,
+	secureCookies bool,
+	publicURL string,
+) http.Handler {
 	return &gameHandler{
-		gameService:   gameService,
-		playerService: playerService,
-		fieldService:  fieldService,
+		gameService:    gameService,
+		playerService:  playerService,
+		fieldService:   fieldService,
+		userService:    userService,
+		sessionService: sessionService,
+		playerCookies:  playerCookies,
+		csrfProtection: csrfProtection,
+		secureCookies:  secureCookies,
+		publicURL:      publicURL,
 	}
 }
 
 // |PRIVATE| //
 
-func (h *gameHandler) setCookieWithPlayerUUID(
-	w http.ResponseWriter, game *model.Game, player *model.Player,
-) {
-	http.SetCookie(w, &http.Cookie{
-		Name:     "player-uuid-" + game.UUID.String(),
-		Value:    player.UUID.String(),
-		Path:     "/",
-		Expires:  time.Now().Add(24 * time.Hour),
-		HttpOnly: true,
-		SameSite: http.SameSiteNoneMode,
-		Secure:   true,
-	})
-}
-
 func (h *gameHandler) getGame(w http.ResponseWriter, r *http.Request) {
 	tmpl, err := template.ParseFiles("views/game/game.html")
 	if err != nil {
-		fmt.Printf(": %v", err)
+		requestLogger(r).Error("template", logging.KeyErr, err)
 		http.Error(w, err.Error(), 500)
 		return
 	}
 	gameUUID, err := uuid.Parse(r.PathValue("gameUUID"))
 	if err != nil {
-		fmt.Printf(": %v", err)
+		requestLogger(r).Error("game uuid", logging.KeyErr, err)
 		http.Error(w, err.Error(), 500)
 		return
 	}
 	game, err := h.gameService.GetWithUUID(gameUUID)
 	if err != nil {
-		fmt.Printf("game: %v", err)
+		requestLogger(r).Error("game", logging.KeyErr, err)
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
+	_, user, err := getSessionUser(r, h.sessionService, h.userService)
+	if err != nil {
+		requestLogger(r).Error("session", logging.KeyErr, err)
+		clearSessionCookie(w, h.secureCookies)
+	}
+
 	var player *model.Player
-	playerUUIDCookie, err := r.Cookie("player-uuid-" + gameUUID.String())
+	playerUUID, err := h.playerCookies.GetPlayerUUID(r, gameUUID)
 	if err != nil {
-		player, err = h.playerService.Create(game)
+		if !errors.Is(err, http.ErrNoCookie) {
+			requestLogger(r).Error("player token", logging.KeyErr, err)
+		}
+		player, err = h.getOrCreatePlayer(game, user)
 		if err != nil {
-			fmt.Printf("player: %v", err)
+			requestLogger(r).Error("player", logging.KeyErr, err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		h.setCookieWithPlayerUUID(w, game, player)
 	} else {
-		playerUUID, err := uuid.Parse(playerUUIDCookie.Value)
+		player, err = h.playerService.GetWithUUID(playerUUID)
 		if err != nil {
-			fmt.Printf(": %v", err)
+			requestLogger(r).Error("player", logging.KeyErr, err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if player.GameUUID != game.UUID {
+		http.Error(w, "player is not linked to this game", http.StatusUnauthorized)
+		return
+	}
+	// Renew the token on every visit
+	if err := h.playerCookies.Set(w, game, player); err != nil {
+		requestLogger(r).Error("player token", logging.KeyErr, err)
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	// Anonymous players that log in later claim their player
+	if user != nil && !player.UserUUID.Valid {
+		if err := h.playerService.LinkUser(player, user); err != nil {
+			requestLogger(r).Error("player", logging.KeyErr, err)
 			http.Error(w, err.Error(), 500)
 			return
 		}
+	}
 
-		player, err = h.playerService.GetWithUUID(playerUUID)
+	if player.Name == "" {
+		h.getJoinForm(w, r, game)
+		return
+	}
+
+	data := dto.GamePageData{
+		Title:          "Game",
+		GameUUID:       game.UUID.String(),
+		BoardSize:      game.Rules.BoardSize,
+		ChallengeMode:  game.Rules.ChallengeMode,
+		Correspondence: game.Rules.Correspondence,
+		SnapshotURL:    snapshotURL(h.publicURL, game.UUID),
+	}
+	// Correspondence games post plays instead of sending them over websocket
+	if game.Rules.Correspondence {
+		data.CSRFToken, err = h.csrfProtection.Token(w, r)
 		if err != nil {
-			fmt.Printf("player: %v", err)
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			http.Error(w, err.Error(), 500)
 			return
 		}
 	}
-	if player.GameUUID != game.UUID {
-		http.Error(w, "player is not linked to this game", http.StatusUnauthorized)
+
+	err = tmpl.Execute(w, data)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+	}
+}
+
+// Returns the player of the logged in user, so the game can be continued from
+// another device. Anonymous users and users new to the game get a new player.
+func (h *gameHandler) getOrCreatePlayer(
+	game *model.Game, user *model.User,
+) (*model.Player, error) {
+	if user != nil {
+		player, err := h.playerService.GetWithGameAndUser(game.UUID, user)
+		if err == nil {
+			return player, nil
+		}
+		if !errors.Is(err, repo.ErrNotExists) {
+			return nil, err
+		}
+	}
+
+	player, err := h.playerService.Create(game)
+	if err != nil {
+		return nil, err
+	}
+	if user != nil {
+		err = h.playerService.LinkUser(player, user)
+	}
+	return player, err
+}
+
+// Asks the player for a display name before letting them into the game
+func (h *gameHandler) getJoinForm(
+	w http.ResponseWriter,
+	r *http.Request,
+	game *model.Game,
+) {
+	tmpl, err := template.ParseFiles("views/game/join.html")
+	if err != nil {
+		requestLogger(r).Error("template", logging.KeyErr, err)
+		http.Error(w, err.Error(), 500)
+		return
+	}
+
+	csrfToken, err := h.csrfProtection.Token(w, r)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
 		return
 	}
 
-	data := dto.GamePageData{Title: "Game", GameUUID: game.UUID.String()}
+	data := dto.JoinPageData{
+		Title:       "Join game",
+		GameUUID:    game.UUID.String(),
+		CSRFToken:   csrfToken,
+		SnapshotURL: snapshotURL(h.publicURL, game.UUID),
+	}
 
 	err = tmpl.Execute(w, data)
 	if err != nil {
@@ -99,33 +207,146 @@ func (h *gameHandler) getGame(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (h *gameHandler) createGameInitialData(gameUUID uuid.UUID, playerUUID uuid.UUID) error {
-	_, err := h.fieldService.Create(gameUUID, playerUUID, 0, "A", [3]int{7, 7, 7})
+// Places the first character in the middle of the cube
+func (h *gameHandler) createGameInitialData(game *model.Game, playerUUID uuid.UUID) error {
+	center := game.Rules.BoardSize / 2
+	_, err := h.fieldService.Create(
+		game.UUID,
+		playerUUID,
+		model.InitialAppendNum,
+		game.Rules.FirstChar(),
+		[3]int{center, center, center},
+	)
 	return err
 }
 
-func (h *gameHandler) createGame(w http.ResponseWriter) {
-	game, err := h.gameService.Create()
+// Overrides default rules with the ones sent in the create game form. Fields
+// missing from the form keep their default values.
+func (h *gameHandler) parseGameRules(r *http.Request) (model.GameRules, error) {
+	rules := h.gameService.DefaultRules()
+	if err := r.ParseForm(); err != nil {
+		return rules, err
+	}
+
+	intFields := []struct {
+		name  string
+		value *int
+	}{
+		{"board_size", &rules.BoardSize},
+		{"rack_size", &rules.RackSize},
+		{"min_word_len", &rules.MinWordLen},
+	}
+	for _, field := range intFields {
+		if value := r.PostForm.Get(field.name); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				return rules, fmt.Errorf("%v: %w", field.name, err)
+			}
+			*field.value = parsed
+		}
+	}
+	if value := r.PostForm.Get("points_to_win"); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return rules, fmt.Errorf("points_to_win: %w", err)
+		}
+		rules.PointsToWin = parsed
+	}
+	durationFields := []struct {
+		name  string
+		value *time.Duration
+	}{
+		{"time_control", &rules.TimeControl},
+		{"time_bank", &rules.TimeBank},
+		{"time_increment", &rules.TimeIncrement},
+	}
+	for _, field := range durationFields {
+		if value := r.PostForm.Get(field.name); value != "" {
+			parsed, err := time.ParseDuration(value)
+			if err != nil {
+				return rules, fmt.Errorf("%v: %w", field.name, err)
+			}
+			*field.value = parsed
+		}
+	}
+	if value := r.PostForm.Get("language"); value != "" {
+		rules.Language = value
+	}
+	boolFields := []struct {
+		name  string
+		value *bool
+	}{
+		{"challenge_mode", &rules.ChallengeMode},
+		{"correspondence", &rules.Correspondence},
+	}
+	for _, field := range boolFields {
+		if value := r.PostForm.Get(field.name); value != "" {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				return rules, fmt.Errorf("%v: %w", field.name, err)
+			}
+			*field.value = parsed
+		}
+	}
+
+	return rules, rules.Validate()
+}
+
+func (h *gameHandler) createGame(w http.ResponseWriter, r *http.Request) {
+	if err := h.csrfProtection.Verify(r); err != nil {
+		requestLogger(r).Warn(
+			"game creation rejected",
+			"remote_addr", r.RemoteAddr,
+			logging.KeyErr, err,
+		)
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	_, user, err := getSessionUser(r, h.sessionService, h.userService)
+	if err != nil {
+		requestLogger(r).Error("session", logging.KeyErr, err)
+		clearSessionCookie(w, h.secureCookies)
+	}
+
+	rules, err := h.parseGameRules(r)
 	if err != nil {
-		fmt.Printf("h.GameService.Create(): %v", err)
+		requestLogger(r).Error("game rules", logging.KeyErr, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	game, err := h.gameService.Create(rules)
+	if errors.Is(err, lang.ErrUnknownLanguage) {
+		requestLogger(r).Error("game create", logging.KeyErr, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		requestLogger(r).Error("game create", logging.KeyErr, err)
 		http.Error(w, err.Error(), 500)
 		return
 	}
-	player, err := h.playerService.Create(game)
+	player, err := h.getOrCreatePlayer(game, user)
 	if err != nil {
-		fmt.Printf("h.PlayerService.Create(game): %v", err)
+		requestLogger(r).Error("player create", logging.KeyErr, err)
 		http.Error(w, err.Error(), 500)
 		return
 	}
 
-	err = h.createGameInitialData(game.UUID, player.UUID)
+	err = h.createGameInitialData(game, player.UUID)
 	if err != nil {
-		fmt.Printf("h.createGameInitialData: %v", err)
+		requestLogger(r).Error("game initial data", logging.KeyErr, err)
 		http.Error(w, err.Error(), 500)
 		return
 	}
 
-	h.setCookieWithPlayerUUID(w, game, player)
+	err = h.playerCookies.Set(w, game, player)
+	if err != nil {
+		requestLogger(r).Error("player token", logging.KeyErr, err)
+		http.Error(w, err.Error(), 500)
+		return
+	}
 	redirectURL := "/game/" + game.UUID.String()
 	w.Header().Set("HX-Redirect", redirectURL)
 	w.WriteHeader(http.StatusOK)
@@ -140,6 +361,6 @@ func (h *gameHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			h.getGame(w, r)
 		}
 	case http.MethodPost:
-		h.createGame(w)
+		h.createGame(w, r)
 	}
 }
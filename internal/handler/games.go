@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"html/template"
+	"net/http"
+	"scrable3/internal/dto"
+	"scrable3/internal/logging"
+	"scrable3/internal/svc"
+	"time"
+)
+
+type gamesHandler struct {
+	gameService    svc.GameService
+	playerService  svc.PlayerService
+	userService    svc.UserService
+	sessionService svc.SessionService
+	csrfProtection CSRFProtection
+}
+
+func NewGamesHandler(
+	gameService svc.GameService,
+	playerService svc.PlayerService,
+	userService svc.UserService,
+	sessionService svc.SessionService,
+	csrfProtection CSRFProtection,
+) http.Handler {
+	return &gamesHandler{
+		gameService:    gameService,
+		playerService:  playerService,
+		userService:    userService,
+		sessionService: sessionService,
+		csrfProtection: csrfProtection,
+	}
+}
+
+// |PRIVATE| //
+
+// Lists active and finished games of the logged in user
+func (h *gamesHandler) getGames(w http.ResponseWriter, r *http.Request) {
+	_, user, err := getSessionUser(r, h.sessionService, h.userService)
+	if err != nil || user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	tmpl, err := template.ParseFiles("views/user/games.html")
+	if err != nil {
+		requestLogger(r).Error("template", logging.KeyErr, err)
+		http.Error(w, err.Error(), 500)
+		return
+	}
+
+	games, err := h.gameService.GetWithUserUUID(user.UUID)
+	if err != nil {
+		requestLogger(r).Error("games", logging.KeyErr, err)
+		http.Error(w, err.Error(), 500)
+		return
+	}
+
+	csrfToken, err := h.csrfProtection.Token(w, r)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+
+	data := dto.GamesPageData{
+		Title:     "My games",
+		CSRFToken: csrfToken,
+		Login:     user.Login,
+	}
+	for _, game := range *games {
+		players, err := h.playerService.GetWithGameUUID(game.UUID)
+		if err != nil {
+			requestLogger(r).Error("players", logging.KeyErr, err)
+			http.Error(w, err.Error(), 500)
+			return
+		}
+
+		entry := dto.GamesPageEntry{
+			GameUUID:   game.UUID.String(),
+			UpdateDate: game.UpdateDate.Format(time.DateTime),
+		}
+		for _, player := range *players {
+			entry.Players = append(entry.Players, dto.HtmlPlayerData{
+				Name:   player.Name,
+				Color:  player.Color,
+				Points: player.Points,
+			})
+		}
+
+		if game.IsFinished() {
+			data.Finished = append(data.Finished, entry)
+		} else {
+			data.Active = append(data.Active, entry)
+		}
+	}
+
+	err = tmpl.Execute(w, data)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+	}
+}
+
+// |PUBLIC| //
+
+func (h *gamesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.getGames(w, r)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"scrable3/internal/dto"
+	"scrable3/internal/logging"
+	"scrable3/internal/model"
+	"scrable3/internal/svc"
+
+	"github.com/google/uuid"
+)
+
+type gameWordHandler struct {
+	gameService     svc.GameService
+	playerService   svc.PlayerService
+	gameWordService svc.GameWordService
+	playerCookies   PlayerCookies
+	csrfProtection  CSRFProtection
+}
+
+func NewGameWordHandler(
+	gameService svc.GameService,
+	playerService svc.PlayerService,
+	gameWordService svc.GameWordService,
+	playerCookies PlayerCookies,
+	csrfProtection CSRFProtection,
+) http.Handler {
+	return &gameWordHandler{
+		gameService:     gameService,
+		playerService:   playerService,
+		gameWordService: gameWordService,
+		playerCookies:   playerCookies,
+		csrfProtection:  csrfProtection,
+	}
+}
+
+// |PRIVATE| //
+
+// Returns the game from the path and the player identified by the signed game
+// cookie
+func (h *gameWordHandler) getGameAndPlayer(
+	r *http.Request,
+) (*model.Game, *model.Player, int, error) {
+	gameUUID, err := uuid.Parse(r.PathValue("gameUUID"))
+	if err != nil {
+		return nil, nil, http.StatusBadRequest, err
+	}
+	game, err := h.gameService.GetWithUUID(gameUUID)
+	if err != nil {
+		return nil, nil, http.StatusBadRequest, err
+	}
+
+	playerUUID, err := h.playerCookies.GetPlayerUUID(r, gameUUID)
+	if err != nil {
+		return nil, nil, http.StatusUnauthorized, err
+	}
+	player, err := h.playerService.GetWithUUID(playerUUID)
+	if err != nil {
+		return nil, nil, http.StatusBadRequest, err
+	}
+	if player.GameUUID != gameUUID {
+		err = errors.New("player is not linked to this game")
+		return nil, nil, http.StatusUnauthorized, err
+	}
+	return game, player, http.StatusOK, nil
+}
+
+// Renders both lists of the game. 'message' is shown below the lists.
+func (h *gameWordHandler) renderWords(
+	w http.ResponseWriter,
+	r *http.Request,
+	game *model.Game,
+	player *model.Player,
+	message string,
+) {
+	tmpl, err := template.ParseFiles("views/game/words.html")
+	if err != nil {
+		requestLogger(r).Error("template", logging.KeyErr, err)
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	gameWords, err := h.gameWordService.GetWithGameUUID(game.UUID)
+	if err != nil {
+		requestLogger(r).Error("game words", logging.KeyErr, err)
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	csrfToken, err := h.csrfProtection.Token(w, r)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+
+	data := dto.HtmlGameWordsData{
+		GameUUID:  game.UUID.String(),
+		CSRFToken: csrfToken,
+		Editable:  h.gameWordService.CheckEditable(game, player) == nil,
+		Error:     message,
+	}
+	for _, gameWord := range *gameWords {
+		if gameWord.Allowed {
+			data.Allowed = append(data.Allowed, gameWord.Word)
+		} else {
+			data.Blocked = append(data.Blocked, gameWord.Word)
+		}
+	}
+
+	err = tmpl.Execute(w, data)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+	}
+}
+
+// Adds, moves or removes the word. Mistakes in the word are shown inside the
+// lists, so htmx can swap them in place.
+func (h *gameWordHandler) editWords(w http.ResponseWriter, r *http.Request) {
+	if err := h.csrfProtection.Verify(r); err != nil {
+		requestLogger(r).Warn(
+			"words list change rejected",
+			"remote_addr", r.RemoteAddr,
+			logging.KeyErr, err,
+		)
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	game, player, status, err := h.getGameAndPlayer(r)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	word := r.FormValue("word")
+	switch r.Method {
+	case http.MethodPost:
+		switch r.FormValue("list") {
+		case "allow":
+			_, err = h.gameWordService.Set(game, player, word, true)
+		case "block":
+			_, err = h.gameWordService.Set(game, player, word, false)
+		default:
+			err = fmt.Errorf("unknown list %q", r.FormValue("list"))
+		}
+	case http.MethodDelete:
+		err = h.gameWordService.Remove(game, player, word)
+	}
+	if errors.Is(err, svc.ErrNotGameCreator) || errors.Is(err, svc.ErrGameStarted) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	message := ""
+	if err != nil {
+		message = err.Error()
+	}
+	h.renderWords(w, r, game, player, message)
+}
+
+// |PUBLIC| //
+
+func (h *gameWordHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		game, player, status, err := h.getGameAndPlayer(r)
+		if err != nil {
+			http.Error(w, err.Error(), status)
+			return
+		}
+		h.renderWords(w, r, game, player, "")
+	case http.MethodPost, http.MethodDelete:
+		h.editWords(w, r)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
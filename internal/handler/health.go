@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"scrable3/internal/ctrl"
+	"scrable3/internal/logging"
+	"scrable3/internal/repo"
+	"scrable3/internal/svc"
+)
+
+// Probes of the process for orchestrators. /healthz answers as long as the
+// process serves requests, /readyz only when it can also play games.
+type healthHandler struct {
+	db              repo.DB
+	gameService     svc.GameService
+	wordsController ctrl.WordsController
+}
+
+func NewHealthHandler(
+	db repo.DB,
+	gameService svc.GameService,
+	wordsController ctrl.WordsController,
+) http.Handler {
+	return &healthHandler{
+		db:              db,
+		gameService:     gameService,
+		wordsController: wordsController,
+	}
+}
+
+// |PRIVATE| //
+
+// Returns every failed check, nil when the server is ready
+func (h *healthHandler) checkReady() error {
+	var errs []error
+	var one int
+	if err := h.db.QueryRow("SELECT 1").Scan(&one); err != nil {
+		errs = append(errs, fmt.Errorf("database: %w", err))
+	}
+	languages := h.gameService.Languages()
+	if len(languages) == 0 {
+		errs = append(errs, errors.New("no languages loaded"))
+	}
+	for _, pack := range languages {
+		if h.wordsController.WordsNumber(pack.Code) == 0 {
+			errs = append(errs, fmt.Errorf("dictionary %v is empty", pack.Code))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// |PUBLIC| //
+
+func (h *healthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+
+	if r.URL.Path == "/readyz" {
+		if err := h.checkReady(); err != nil {
+			requestLogger(r).Warn("not ready", logging.KeyErr, err)
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+	}
+	w.Write([]byte("ok\n"))
+}
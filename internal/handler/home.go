@@ -4,12 +4,22 @@ import (
 	"html/template"
 	"net/http"
 	"scrable3/internal/dto"
+	"scrable3/internal/svc"
 )
 
-type homeHandler struct{}
+type homeHandler struct {
+	gameService    svc.GameService
+	csrfProtection CSRFProtection
+}
 
-func NewHomeHandler() http.Handler {
-	return &homeHandler{}
+func NewHomeHandler(
+	gameService svc.GameService,
+	csrfProtection CSRFProtection,
+) http.Handler {
+	return &homeHandler{
+		gameService:    gameService,
+		csrfProtection: csrfProtection,
+	}
 }
 
 func (h *homeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -19,7 +29,23 @@ func (h *homeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	data := dto.HomePageData{Title: "Scrable3D home page"}
+	csrfToken, err := h.csrfProtection.Token(w, r)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+
+	data := dto.HomePageData{
+		Title:     "Scrable3D home page",
+		CSRFToken: csrfToken,
+		Rules:     h.gameService.DefaultRules(),
+	}
+	for _, pack := range h.gameService.Languages() {
+		data.Languages = append(data.Languages, dto.LanguageOption{
+			Code: pack.Code,
+			Name: pack.Name,
+		})
+	}
 
 	err = tmpl.Execute(w, data)
 	if err != nil {
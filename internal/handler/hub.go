@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"scrable3/internal/logging"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+// Sends messages to every player of the game connected with websocket
+type Broadcaster interface {
+	Broadcast(gameUUID uuid.UUID, message []byte)
+}
+
+// Websocket connections of players grouped by games. Connections are used by
+// request handlers and game clocks at the same time, so writes are
+// serialized per connection.
+type Hub interface {
+	Broadcaster
+	Add(gameUUID uuid.UUID, playerUUID uuid.UUID, conn *websocket.Conn)
+	// Removes the connection unless the player connected again in the meantime.
+	// Returns the number of connections left in the game.
+	Remove(gameUUID uuid.UUID, playerUUID uuid.UUID, conn *websocket.Conn) int
+	// Sends the message to the player, false when the player is not connected
+	Send(gameUUID uuid.UUID, playerUUID uuid.UUID, message []byte) bool
+	// Number of connections of every game with players connected
+	Connections() map[uuid.UUID]int
+	// Locks the game until 'unlock' is called, so changes of the game state
+	// made by players and clocks do not interleave
+	LockGame(gameUUID uuid.UUID) (unlock func())
+	// Closes connections of the game, used when the game is deleted
+	CloseGame(gameUUID uuid.UUID)
+	// Sends the message to every connection and closes it once the action
+	// in progress in its game is done, nil message closes connections
+	// without it. Returns when all connections are removed and nothing holds
+	// a game lock, or with the error of 'ctx'.
+	Shutdown(ctx context.Context, message []byte) error
+}
+
+type hubConn struct {
+	conn *websocket.Conn
+	mu   sync.Mutex
+}
+
+type hub struct {
+	logger *slog.Logger
+	mu     sync.Mutex
+	// connections[gameUUID][playerUUID] = conn
+	connections map[uuid.UUID]map[uuid.UUID]*hubConn
+	gameLocks   map[uuid.UUID]*sync.Mutex
+	// Closed when the last connection is removed during shutdown, nil before
+	drained chan struct{}
+}
+
+func NewHub(logger *slog.Logger) Hub {
+	return &hub{
+		logger:      logger,
+		connections: make(map[uuid.UUID]map[uuid.UUID]*hubConn),
+		gameLocks:   make(map[uuid.UUID]*sync.Mutex),
+	}
+}
+
+// |PRIVATE| //
+
+func (c *hubConn) write(message []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn.WriteMessage(websocket.TextMessage, message)
+}
+
+// Says goodbye with a close frame and closes the connection
+func (c *hubConn) close(code int, reason string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	closeMessage := websocket.FormatCloseMessage(code, reason)
+	c.conn.WriteControl(websocket.CloseMessage, closeMessage, time.Now().Add(time.Second))
+	c.conn.Close()
+}
+
+// Connections of the game, copied so they can be written without holding the
+// hub lock
+func (h *hub) gameConns(gameUUID uuid.UUID) []*hubConn {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	conns := make([]*hubConn, 0, len(h.connections[gameUUID]))
+	for _, c := range h.connections[gameUUID] {
+		conns = append(conns, c)
+	}
+	return conns
+}
+
+// |PUBLIC| //
+
+func (h *hub) Add(gameUUID uuid.UUID, playerUUID uuid.UUID, conn *websocket.Conn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.connections[gameUUID]; !ok {
+		h.connections[gameUUID] = make(map[uuid.UUID]*hubConn)
+	}
+	h.connections[gameUUID][playerUUID] = &hubConn{conn: conn}
+}
+
+func (h *hub) Remove(
+	gameUUID uuid.UUID,
+	playerUUID uuid.UUID,
+	conn *websocket.Conn,
+) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if c, ok := h.connections[gameUUID][playerUUID]; ok && c.conn == conn {
+		delete(h.connections[gameUUID], playerUUID)
+	}
+	left := len(h.connections[gameUUID])
+	if left == 0 {
+		delete(h.connections, gameUUID)
+	}
+	if h.drained != nil && len(h.connections) == 0 {
+		select {
+		case <-h.drained:
+		default:
+			close(h.drained)
+		}
+	}
+	return left
+}
+
+func (h *hub) Send(gameUUID uuid.UUID, playerUUID uuid.UUID, message []byte) bool {
+	h.mu.Lock()
+	c, ok := h.connections[gameUUID][playerUUID]
+	h.mu.Unlock()
+	if !ok {
+		return false
+	}
+	if err := c.write(message); err != nil {
+		h.logger.Info(
+			"websocket write",
+			logging.KeyGame, gameUUID,
+			logging.KeyPlayer, playerUUID,
+			logging.KeyErr, err,
+		)
+	}
+	return true
+}
+
+func (h *hub) Broadcast(gameUUID uuid.UUID, message []byte) {
+	for _, c := range h.gameConns(gameUUID) {
+		if err := c.write(message); err != nil {
+			h.logger.Info("websocket write", logging.KeyGame, gameUUID, logging.KeyErr, err)
+		}
+	}
+}
+
+func (h *hub) Connections() map[uuid.UUID]int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	connections := make(map[uuid.UUID]int, len(h.connections))
+	for gameUUID, conns := range h.connections {
+		connections[gameUUID] = len(conns)
+	}
+	return connections
+}
+
+func (h *hub) LockGame(gameUUID uuid.UUID) func() {
+	h.mu.Lock()
+	gameLock, ok := h.gameLocks[gameUUID]
+	if !ok {
+		gameLock = &sync.Mutex{}
+		h.gameLocks[gameUUID] = gameLock
+	}
+	h.mu.Unlock()
+
+	gameLock.Lock()
+	return gameLock.Unlock
+}
+
+func (h *hub) CloseGame(gameUUID uuid.UUID) {
+	// Closed connections end their read loops, which remove them from the hub
+	for _, c := range h.gameConns(gameUUID) {
+		c.close(websocket.CloseNormalClosure, "game deleted")
+	}
+}
+
+func (h *hub) Shutdown(ctx context.Context, message []byte) error {
+	h.mu.Lock()
+	h.drained = make(chan struct{})
+	if len(h.connections) == 0 {
+		close(h.drained)
+	}
+	gameUUIDs := make([]uuid.UUID, 0, len(h.connections))
+	for gameUUID := range h.connections {
+		gameUUIDs = append(gameUUIDs, gameUUID)
+	}
+	drained := h.drained
+	h.mu.Unlock()
+
+	// Closed connections end their read loops, which remove them from the hub
+	for _, gameUUID := range gameUUIDs {
+		unlock := h.LockGame(gameUUID)
+		for _, c := range h.gameConns(gameUUID) {
+			if message != nil {
+				if err := c.write(message); err != nil {
+					h.logger.Info("websocket write", logging.KeyGame, gameUUID, logging.KeyErr, err)
+				}
+			}
+			c.close(websocket.CloseServiceRestart, "server restarting")
+		}
+		unlock()
+	}
+
+	// Clocks and sweeps still running hold their game locks until they finish
+	idle := make(chan struct{})
+	go func() {
+		<-drained
+		h.mu.Lock()
+		gameLocks := make([]*sync.Mutex, 0, len(h.gameLocks))
+		for _, gameLock := range h.gameLocks {
+			gameLocks = append(gameLocks, gameLock)
+		}
+		h.mu.Unlock()
+		for _, gameLock := range gameLocks {
+			gameLock.Lock()
+			gameLock.Unlock()
+		}
+		close(idle)
+	}()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"scrable3/internal/dto"
+	"scrable3/internal/logging"
+)
+
+const requestIDHeaderName = "X-Request-ID"
+
+// Gives every request a logger tagged with a new request ID. The ID is sent
+// back in the X-Request-ID header, so reported errors can be found in the log.
+func NewRequestLogging(logger *slog.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := logging.NewRequestID()
+		w.Header().Set(requestIDHeaderName, requestID)
+		requestLogger := logger.With(logging.KeyRequestID, requestID)
+		requestLogger.Debug("request", "method", r.Method, "path", r.URL.Path)
+		next.ServeHTTP(w, r.WithContext(logging.NewContext(r.Context(), requestLogger)))
+	})
+}
+
+func requestLogger(r *http.Request) *slog.Logger {
+	return logging.FromContext(r.Context())
+}
+
+// Logger of the request tagged with the game and the player of the context
+func contextLogger(r *http.Request, ctx *dto.WsContext) *slog.Logger {
+	return requestLogger(r).With(
+		logging.KeyGame, ctx.Game.UUID,
+		logging.KeyPlayer, ctx.Player.UUID,
+	)
+}
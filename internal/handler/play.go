@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"scrable3/internal/ctrl"
+	"scrable3/internal/dto"
+	"scrable3/internal/logging"
+	"scrable3/internal/svc"
+
+	"github.com/google/uuid"
+)
+
+// Plays of correspondence games, made from the game page without websocket.
+// GET returns the board, rack and players when the page loads, POST takes the
+// same JSON actions as the websocket.
+type playHandler struct {
+	gameService    svc.GameService
+	playerService  svc.PlayerService
+	gameController ctrl.GameController
+	playerCookies  PlayerCookies
+	csrfProtection CSRFProtection
+	hub            Hub
+}
+
+func NewPlayHandler(
+	gameService svc.GameService,
+	playerService svc.PlayerService,
+	gameController ctrl.GameController,
+	playerCookies PlayerCookies,
+	csrfProtection CSRFProtection,
+	hub Hub,
+) http.Handler {
+	return &playHandler{
+		gameService:    gameService,
+		playerService:  playerService,
+		gameController: gameController,
+		playerCookies:  playerCookies,
+		csrfProtection: csrfProtection,
+		hub:            hub,
+	}
+}
+
+// |PRIVATE| //
+
+func (h *playHandler) createContext(r *http.Request) (*dto.WsContext, int, error) {
+	gameUUID, err := uuid.Parse(r.PathValue("gameUUID"))
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	game, err := h.gameService.GetWithUUID(gameUUID)
+	if err != nil {
+		return nil, http.StatusNotFound, err
+	}
+
+	playerUUID, err := h.playerCookies.GetPlayerUUID(r, game.UUID)
+	if err != nil {
+		return nil, http.StatusUnauthorized, err
+	}
+	player, err := h.playerService.GetWithUUID(playerUUID)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if player.GameUUID != game.UUID {
+		err = errors.New("player is not linked to this game")
+		return nil, http.StatusUnauthorized, err
+	}
+	ctx := &dto.WsContext{Game: game, Player: player}
+	ctx.Logger = contextLogger(r, ctx)
+	return ctx, http.StatusOK, nil
+}
+
+func (h *playHandler) getInitialData(w http.ResponseWriter, ctx *dto.WsContext) {
+	response, err := h.gameController.GetAvaibleChars(ctx)
+	if err != nil {
+		ctx.Logger.Error("rack", logging.KeyErr, err)
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	fields, err := h.gameController.GetCurrentFields(ctx)
+	if err != nil {
+		ctx.Logger.Error("fields", logging.KeyErr, err)
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	players, err := h.gameController.GetPlayers(ctx)
+	if err != nil {
+		ctx.Logger.Error("players", logging.KeyErr, err)
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	response = append(response, fields...)
+	w.Write(append(response, players...))
+}
+
+// Mistakes are shown in the error dialog, so the response is always swapped
+// in by htmx
+func (h *playHandler) postAction(
+	w http.ResponseWriter,
+	r *http.Request,
+	ctx *dto.WsContext,
+) {
+	p, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	broadcastResponse, senderResponse, _, err := performAction(
+		h.gameController,
+		ctx,
+		p,
+	)
+	if err != nil {
+		senderResponse, err = buildErrPopup(err)
+		if err != nil {
+			ctx.Logger.Error("error popup", logging.KeyErr, err)
+			http.Error(w, err.Error(), 500)
+			return
+		}
+		w.Write(senderResponse)
+		return
+	}
+
+	// Players watching the game live see the play at once
+	if broadcastResponse != nil {
+		h.hub.Broadcast(ctx.Game.UUID, broadcastResponse)
+	}
+	w.Write(append(broadcastResponse, senderResponse...))
+}
+
+// |PUBLIC| //
+
+func (h *playHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if r.Method == http.MethodPost {
+		if err := h.csrfProtection.Verify(r); err != nil {
+			requestLogger(r).Warn(
+				"play rejected",
+				"remote_addr", r.RemoteAddr,
+				logging.KeyErr, err,
+			)
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+	}
+	ctx, status, err := h.createContext(r)
+	if err != nil {
+		requestLogger(r).Info("play context", logging.KeyErr, err)
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	// Plays and clocks of the game take turns
+	unlock := h.hub.LockGame(ctx.Game.UUID)
+	defer unlock()
+	if err := h.gameService.Refresh(ctx.Game); err != nil {
+		ctx.Logger.Error("refresh", logging.KeyErr, err)
+		http.Error(w, err.Error(), 500)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.getInitialData(w, ctx)
+	case http.MethodPost:
+		h.postAction(w, r, ctx)
+	}
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"net/http"
+	"scrable3/internal/logging"
+	"scrable3/internal/svc"
+
+	"github.com/google/uuid"
+)
+
+type playerHandler struct {
+	playerService  svc.PlayerService
+	playerCookies  PlayerCookies
+	csrfProtection CSRFProtection
+}
+
+func NewPlayerHandler(
+	playerService svc.PlayerService,
+	playerCookies PlayerCookies,
+	csrfProtection CSRFProtection,
+) http.Handler {
+	return &playerHandler{
+		playerService:  playerService,
+		playerCookies:  playerCookies,
+		csrfProtection: csrfProtection,
+	}
+}
+
+// |PRIVATE| //
+
+// Sets name and colour of the player identified by the signed game cookie. Errors are
+// returned as plain text, so htmx can put them inside the join form.
+func (h *playerHandler) updatePlayer(w http.ResponseWriter, r *http.Request) {
+	if err := h.csrfProtection.Verify(r); err != nil {
+		requestLogger(r).Warn(
+			"player update rejected",
+			"remote_addr", r.RemoteAddr,
+			logging.KeyErr, err,
+		)
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	gameUUID, err := uuid.Parse(r.PathValue("gameUUID"))
+	if err != nil {
+		requestLogger(r).Error("game uuid", logging.KeyErr, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	playerUUID, err := h.playerCookies.GetPlayerUUID(r, gameUUID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	player, err := h.playerService.GetWithUUID(playerUUID)
+	if err != nil {
+		requestLogger(r).Error("player", logging.KeyErr, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if player.GameUUID != gameUUID {
+		http.Error(w, "player is not linked to this game", http.StatusUnauthorized)
+		return
+	}
+
+	err = h.playerService.Rename(player, r.FormValue("name"), r.FormValue("color"))
+	if err != nil {
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.Header().Set("HX-Redirect", "/game/"+gameUUID.String())
+	w.WriteHeader(http.StatusOK)
+}
+
+// |PUBLIC| //
+
+func (h *playerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.updatePlayer(w, r)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
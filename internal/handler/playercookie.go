@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"net/http"
+	"scrable3/internal/auth"
+	"scrable3/internal/model"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Carries player identity between requests as a signed token in a per game
+// cookie
+type PlayerCookies interface {
+	Set(w http.ResponseWriter, game *model.Game, player *model.Player) error
+	GetPlayerUUID(r *http.Request, gameUUID uuid.UUID) (uuid.UUID, error)
+}
+
+type playerCookies struct {
+	signer auth.PlayerTokenSigner
+	// Lifetime of tokens of correspondence games, players come back to them
+	// after days
+	correspondenceTTL time.Duration
+	// Send the cookie only over HTTPS
+	secure bool
+}
+
+func NewPlayerCookies(
+	signer auth.PlayerTokenSigner,
+	correspondenceTTL time.Duration,
+	secure bool,
+) PlayerCookies {
+	return &playerCookies{
+		signer:            signer,
+		correspondenceTTL: correspondenceTTL,
+		secure:            secure,
+	}
+}
+
+func (c *playerCookies) name(gameUUID uuid.UUID) string {
+	return "player-uuid-" + gameUUID.String()
+}
+
+func (c *playerCookies) Set(
+	w http.ResponseWriter, game *model.Game, player *model.Player,
+) error {
+	// Other games use the default lifetime of the signer
+	var ttl time.Duration
+	if game.Rules.Correspondence {
+		ttl = c.correspondenceTTL
+	}
+	token, expireDate, err := c.signer.SignFor(game.UUID, player.UUID, ttl)
+	if err != nil {
+		return err
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     c.name(game.UUID),
+		Value:    token,
+		Path:     "/",
+		Expires:  expireDate,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+		Secure:   c.secure,
+	})
+	return nil
+}
+
+func (c *playerCookies) GetPlayerUUID(
+	r *http.Request, gameUUID uuid.UUID,
+) (uuid.UUID, error) {
+	cookie, err := r.Cookie(c.name(gameUUID))
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return c.signer.Verify(cookie.Value, gameUUID)
+}
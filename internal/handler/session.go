@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"net/http"
+	"scrable3/internal/model"
+	"scrable3/internal/svc"
+	"time"
+)
+
+const sessionCookieName = "session"
+
+// Resolves the user logged in with the session cookie. Returns nil session and
+// user for anonymous requests.
+func getSessionUser(
+	r *http.Request,
+	sessionService svc.SessionService,
+	userService svc.UserService,
+) (*model.Session, *model.User, error) {
+	sessionCookie, err := r.Cookie(sessionCookieName)
+	if err != nil {
+		return nil, nil, nil
+	}
+	session, err := sessionService.GetWithToken(sessionCookie.Value)
+	if err != nil {
+		return nil, nil, err
+	}
+	user, err := userService.GetWithUUID(session.UserUUID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return session, user, nil
+}
+
+// 'secure' sends the cookie only over HTTPS
+func setSessionCookie(
+	w http.ResponseWriter, session *model.Session, token string, secure bool,
+) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    token,
+		Path:     "/",
+		Expires:  session.ExpireDate,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+		Secure:   secure,
+	})
+}
+
+func clearSessionCookie(w http.ResponseWriter, secure bool) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    "",
+		Path:     "/",
+		Expires:  time.Unix(0, 0),
+		MaxAge:   -1,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+		Secure:   secure,
+	})
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"scrable3/internal/logging"
+	"scrable3/internal/renderer"
+	"scrable3/internal/repo"
+	"scrable3/internal/svc"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Picture of the board seen from a side, /snapshot.svg?side=90 or
+// /snapshot.png for link previews that do not show SVG
+type snapshotHandler struct {
+	gameService   svc.GameService
+	playerService svc.PlayerService
+	fieldService  svc.FieldService
+}
+
+func NewSnapshotHandler(
+	gameService svc.GameService,
+	playerService svc.PlayerService,
+	fieldService svc.FieldService,
+) http.Handler {
+	return &snapshotHandler{
+		gameService:   gameService,
+		playerService: playerService,
+		fieldService:  fieldService,
+	}
+}
+
+// |PRIVATE| //
+
+// Absolute address of the PNG snapshot, link previews do not follow relative
+// ones. The Host header is chosen by the client, so the address is built from
+// the configured public URL and left empty without it.
+func snapshotURL(publicURL string, gameUUID uuid.UUID) string {
+	if publicURL == "" {
+		return ""
+	}
+	return strings.TrimSuffix(publicURL, "/") +
+		"/game/" + gameUUID.String() + "/snapshot.png"
+}
+
+func (h *snapshotHandler) buildSnapshot(
+	gameUUID uuid.UUID,
+	side int,
+) (*renderer.Snapshot, int, error) {
+	game, err := h.gameService.GetWithUUID(gameUUID)
+	if errors.Is(err, repo.ErrNotExists) {
+		return nil, http.StatusNotFound, err
+	}
+	if err != nil {
+		return nil, 500, err
+	}
+	players, err := h.playerService.GetWithGameUUID(gameUUID)
+	if err != nil {
+		return nil, 500, err
+	}
+	colors := make(map[uuid.UUID]string, len(*players))
+	for _, player := range *players {
+		colors[player.UUID] = player.Color
+	}
+	fields, err := h.fieldService.GetWithGameUUID(gameUUID)
+	if err != nil {
+		return nil, 500, err
+	}
+	snapshot, err := renderer.NewSnapshot(game.Rules.BoardSize, *fields, side, colors)
+	if err != nil {
+		return nil, 500, err
+	}
+	return snapshot, http.StatusOK, nil
+}
+
+// |PUBLIC| //
+
+func (h *snapshotHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	gameUUID, err := uuid.Parse(r.PathValue("gameUUID"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	side := 0
+	if value := r.URL.Query().Get("side"); value != "" {
+		side, err = strconv.Atoi(value)
+		if err != nil {
+			http.Error(w, "side should be a number", http.StatusBadRequest)
+			return
+		}
+	}
+	if !slices.Contains(renderer.Sides, side) {
+		http.Error(w, "unknown side", http.StatusBadRequest)
+		return
+	}
+
+	snapshot, status, err := h.buildSnapshot(gameUUID, side)
+	if status == http.StatusNotFound {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		requestLogger(r).Error("snapshot", logging.KeyGame, gameUUID, logging.KeyErr, err)
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	// Previews fetch the picture again now and then, so it changes with the game
+	w.Header().Set("Cache-Control", "public, max-age=60")
+	if strings.HasSuffix(r.URL.Path, ".png") {
+		w.Header().Set("Content-Type", "image/png")
+		err = snapshot.WritePNG(w)
+	} else {
+		w.Header().Set("Content-Type", "image/svg+xml")
+		err = snapshot.WriteSVG(w)
+	}
+	if err != nil {
+		requestLogger(r).Error("snapshot write", logging.KeyGame, gameUUID, logging.KeyErr, err)
+	}
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"html/template"
+	"net/http"
+	"scrable3/internal/dto"
+	"scrable3/internal/logging"
+	"scrable3/internal/model"
+	"scrable3/internal/svc"
+)
+
+type userHandler struct {
+	userService    svc.UserService
+	sessionService svc.SessionService
+	// Send the session cookie only over HTTPS
+	secureCookies bool
+}
+
+func NewUserHandler(
+	userService svc.UserService,
+	sessionService svc.SessionService,
+	secureCookies bool,
+) http.Handler {
+	return &userHandler{
+		userService:    userService,
+		sessionService: sessionService,
+		secureCookies:  secureCookies,
+	}
+}
+
+// |PRIVATE| //
+
+func (h *userHandler) getForm(w http.ResponseWriter, r *http.Request) {
+	tmpl, err := template.ParseFiles("views/user/auth.html")
+	if err != nil {
+		requestLogger(r).Error("template", logging.KeyErr, err)
+		http.Error(w, err.Error(), 500)
+		return
+	}
+
+	data := dto.AuthPageData{Title: "Log in", Action: r.URL.Path}
+	if r.URL.Path == "/register" {
+		data.Title = "Register"
+	}
+
+	err = tmpl.Execute(w, data)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+	}
+}
+
+// Starts a session for the user and redirects to the list of their games.
+// Errors are returned as plain text, so htmx can put them inside the form.
+func (h *userHandler) postForm(w http.ResponseWriter, r *http.Request) {
+	login := r.FormValue("login")
+	password := r.FormValue("password")
+
+	var user *model.User
+	var err error
+	switch r.URL.Path {
+	case "/register":
+		user, err = h.userService.Register(login, password)
+	case "/login":
+		user, err = h.userService.Authenticate(login, password)
+	}
+	if err != nil {
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	session, token, err := h.sessionService.Create(user)
+	if err != nil {
+		requestLogger(r).Error("session create", logging.KeyErr, err)
+		http.Error(w, err.Error(), 500)
+		return
+	}
+
+	setSessionCookie(w, session, token, h.secureCookies)
+	w.Header().Set("HX-Redirect", "/games")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *userHandler) logout(w http.ResponseWriter, r *http.Request) {
+	session, _, err := getSessionUser(r, h.sessionService, h.userService)
+	if err == nil && session != nil {
+		if err := h.sessionService.Delete(session); err != nil {
+			requestLogger(r).Error("session delete", logging.KeyErr, err)
+		}
+	}
+
+	clearSessionCookie(w, h.secureCookies)
+	w.Header().Set("HX-Redirect", "/")
+	w.WriteHeader(http.StatusOK)
+}
+
+// |PUBLIC| //
+
+func (h *userHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/logout" && r.Method == http.MethodPost:
+		h.logout(w, r)
+	case r.URL.Path == "/logout":
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	case r.Method == http.MethodGet:
+		h.getForm(w, r)
+	case r.Method == http.MethodPost:
+		h.postForm(w, r)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
@@ -1,14 +1,13 @@
 package handler
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
-	"html/template"
-	"log"
+	"errors"
 	"net/http"
+	"scrable3/internal/auth"
 	"scrable3/internal/ctrl"
 	"scrable3/internal/dto"
+	"scrable3/internal/logging"
+	"scrable3/internal/metrics"
 	"scrable3/internal/svc"
 
 	"github.com/google/uuid"
@@ -19,58 +18,54 @@ type websocketHandler struct {
 	gameService    svc.GameService
 	playerService  svc.PlayerService
 	gameController ctrl.GameController
+	playerCookies  PlayerCookies
+	originPolicy   auth.OriginPolicy
 	upgrader       websocket.Upgrader
-	// hashmap of websocket connections grouped with sessionUUID (gameUUID) and
-	// connUUID (playerUUID)
-	//  connections[sessionUUID][connUUID] = conn
-	connections map[string]map[string]*websocket.Conn
+	hub            Hub
+	gameClocks     GameClocks
+	metrics        *metrics.Metrics
 }
 
 func NewWebsocketHandler(
 	gameService svc.GameService,
 	playerService svc.PlayerService,
 	gameController ctrl.GameController,
-
+	playerCookies PlayerCookies,
+	originPolicy auth.OriginPolicy,
+	hub Hub,
+	gameClocks GameClocks,
+	metrics *metrics.Metrics,
 ) http.Handler {
-	return &websocketHandler{
+	h := &websocketHandler{
 		gameService:    gameService,
 		playerService:  playerService,
 		gameController: gameController,
-		upgrader: websocket.Upgrader{
-			ReadBufferSize:  1024,
-			WriteBufferSize: 1024,
-		},
-		connections: make(map[string]map[string]*websocket.Conn),
-	}
+		playerCookies:  playerCookies,
+		originPolicy:   originPolicy,
+		hub:            hub,
+		gameClocks:     gameClocks,
+		metrics:        metrics,
+	}
+	h.upgrader = websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     h.checkOrigin,
+	}
+	return h
 }
 
 // |PRIVATE| //
 
-func (h *websocketHandler) unmarshalAndValidate(
-	data []byte, v dto.Validatable,
-) error {
-	if err := json.Unmarshal(data, v); err != nil {
-		return fmt.Errorf("error unmarshaling data: %w", err)
-	}
-	return v.Validate()
-}
-
-func (h *websocketHandler) buildErrPopup(e error) ([]byte, error) {
-	var htmlContent []byte
-	tmpl, err := template.ParseFiles("views/game/error-popup.html")
-	if err != nil {
-		return htmlContent, err
-	}
-	type errorMessage struct{ Error string }
-	eMsg := errorMessage{e.Error()}
-
-	var buff bytes.Buffer
-	err = tmpl.Execute(&buff, eMsg)
-	if err != nil {
-		return htmlContent, err
+func (h *websocketHandler) checkOrigin(r *http.Request) bool {
+	if err := h.originPolicy.Check(r); err != nil {
+		requestLogger(r).Warn(
+			"websocket upgrade rejected",
+			"remote_addr", r.RemoteAddr,
+			logging.KeyErr, err,
+		)
+		return false
 	}
-	htmlContent = buff.Bytes()
-	return htmlContent, nil
+	return true
 }
 
 func (h *websocketHandler) createContext(
@@ -91,12 +86,7 @@ func (h *websocketHandler) createContext(
 	ctx.Game = game
 
 	// Get Player
-	playerUUIDCookie, err := r.Cookie(
-		"player-uuid-" + ctx.Game.UUID.String())
-	if err != nil {
-		return &ctx, err
-	}
-	playerUUID, err := uuid.Parse(playerUUIDCookie.Value)
+	playerUUID, err := h.playerCookies.GetPlayerUUID(r, ctx.Game.UUID)
 	if err != nil {
 		return &ctx, err
 	}
@@ -104,7 +94,11 @@ func (h *websocketHandler) createContext(
 	if err != nil {
 		return &ctx, err
 	}
+	if player.GameUUID != ctx.Game.UUID {
+		return &ctx, errors.New("player is not linked to this game")
+	}
 	ctx.Player = player
+	ctx.Logger = contextLogger(r, &ctx)
 
 	return &ctx, nil
 }
@@ -119,125 +113,129 @@ func (h *websocketHandler) refreshContext(ctx *dto.WsContext) error {
 	return nil
 }
 
-func (h *websocketHandler) sendInitialData(
-	ctx *dto.WsContext,
-	conn *websocket.Conn,
-) error {
+func (h *websocketHandler) sendInitialData(ctx *dto.WsContext) error {
 	resultChars, err := h.gameController.GetAvaibleChars(ctx)
 	if err != nil {
-		log.Println(err)
 		return err
 	}
 	resultFields, err := h.gameController.GetCurrentFields(ctx)
 	if err != nil {
-		log.Println(err)
 		return err
 	}
 	initialResult := append(resultChars, resultFields...)
-	fmt.Println(string(initialResult))
-	if err := conn.WriteMessage(websocket.TextMessage, initialResult); err != nil {
-		log.Println(err)
-		return err
-	}
+	ctx.Logger.Debug("initial data sent", "bytes", len(initialResult))
+	h.hub.Send(ctx.Game.UUID, ctx.Player.UUID, initialResult)
 	return nil
 }
 
-// |PUBLIC| //
+// Sends the rack to the player, used when tiles come back from the board
+func (h *websocketHandler) sendAvaibleChars(ctx *dto.WsContext, playerUUID uuid.UUID) {
+	player, err := h.playerService.GetWithUUID(playerUUID)
+	if err != nil {
+		ctx.Logger.Error("rack owner", logging.KeyErr, err)
+		return
+	}
+	ownerCtx := &dto.WsContext{Game: ctx.Game, Player: player, Logger: ctx.Logger}
+	response, err := h.gameController.GetAvaibleChars(ownerCtx)
+	if err != nil {
+		ctx.Logger.Error("rack", logging.KeyErr, err)
+		return
+	}
+	h.hub.Send(ctx.Game.UUID, playerUUID, response)
+}
 
-func (h *websocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	// Create connection
-	conn, err := h.upgrader.Upgrade(w, r, nil)
+// Handles a single message of the player. The game is locked for the time of
+// the action, so clocks see the turn either before or after the play.
+func (h *websocketHandler) handleMessage(ctx *dto.WsContext, p []byte) {
+	unlock := h.hub.LockGame(ctx.Game.UUID)
+	defer unlock()
+
+	err := h.refreshContext(ctx)
 	if err != nil {
-		log.Println(err)
-		http.Error(w, err.Error(), http.StatusUpgradeRequired) // 426
+		ctx.Logger.Error("refresh", logging.KeyErr, err)
 		return
 	}
-	defer conn.Close()
+	broadcastResponse, senderResponse, rackOwner, err := performAction(
+		h.gameController,
+		ctx,
+		p,
+	)
+	if err == nil && rackOwner != uuid.Nil {
+		h.sendAvaibleChars(ctx, rackOwner)
+	}
 
+	if err != nil {
+		broadcastResponse = nil
+		senderResponse, err = buildErrPopup(err)
+		if err != nil {
+			ctx.Logger.Error("error popup", logging.KeyErr, err)
+			return
+		}
+	}
+
+	if broadcastResponse != nil {
+		h.hub.Broadcast(ctx.Game.UUID, broadcastResponse)
+	}
+	if senderResponse != nil {
+		h.hub.Send(ctx.Game.UUID, ctx.Player.UUID, senderResponse)
+	}
+}
+
+// |PUBLIC| //
+
+func (h *websocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Authenticate before upgrading, so errors can still be sent as HTTP
 	ctx, err := h.createContext(r)
 	if err != nil {
-		log.Println(err)
+		requestLogger(r).Info("websocket rejected", logging.KeyErr, err)
 		http.Error(w, err.Error(), http.StatusNotFound) // 404
 		return
 	}
 
-	sessionUUID := ctx.Game.UUID.String()
-	connUUID := ctx.Player.UUID.String()
-	_, ok := h.connections[sessionUUID]
-	if !ok {
-		h.connections[sessionUUID] = map[string]*websocket.Conn{}
+	// Create connection
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		ctx.Logger.Info("websocket upgrade", logging.KeyErr, err)
+		return
 	}
-	h.connections[sessionUUID][connUUID] = conn
-
-	h.sendInitialData(ctx, conn)
+	ctx.Logger.Debug("player connected")
+	defer conn.Close()
 
-	for {
-		messageType, p, err := conn.ReadMessage()
-		log.Println(string(p))
-		if err != nil {
-			log.Println(err)
-			delete(h.connections[sessionUUID], connUUID)
-			return
-		}
-		err = h.refreshContext(ctx)
-		if err != nil {
-			log.Println(err)
-			continue
-		}
-		action := &dto.ActionData{}
-		err = h.unmarshalAndValidate(p, action)
-		if err != nil {
-			// TODO add here msg or smth
-			log.Println(err)
-			continue
+	gameUUID := ctx.Game.UUID
+	playerUUID := ctx.Player.UUID
+	h.hub.Add(gameUUID, playerUUID, conn)
+	h.metrics.ConnectionOpened(gameUUID)
+	defer h.metrics.ConnectionClosed(gameUUID)
+	defer func() {
+		if h.hub.Remove(gameUUID, playerUUID, conn) == 0 {
+			h.gameClocks.Stop(gameUUID)
 		}
+	}()
 
-		var broadcastResponse []byte
-		var senderResponse []byte
-		switch action.Type {
-		case "addTest":
-			broadcastResponse, err = ctrl.GetRandomField()
-		case "raiseExampleError":
-			senderResponse, err = ctrl.GetExampleError()
-		case "dismissError":
-			senderResponse = []byte(`<div id="error-dialog"></div>`)
-		case "getChars":
-			senderResponse, err = h.gameController.GetAvaibleChars(ctx)
-		case "makePlay":
-			playData := &dto.PlayData{}
-			err = h.unmarshalAndValidate(p, playData)
-			if err != nil {
-				break
-			}
-			broadcastResponse, senderResponse, err = h.gameController.ReceiveChars(ctx, playData)
-			if err != nil {
-				break
-			}
-			senderResponse, err = h.gameController.GetAvaibleChars(ctx)
-		}
+	unlock := h.hub.LockGame(gameUUID)
+	if err := h.sendInitialData(ctx); err != nil {
+		ctx.Logger.Error("initial data", logging.KeyErr, err)
+	}
 
-		if err != nil {
-			broadcastResponse = nil
-			senderResponse, err = h.buildErrPopup(err)
-			if err != nil {
-				log.Println(err)
-				continue
-			}
-		}
+	// Let everyone in the game know about the new player
+	playersResponse, err := h.gameController.GetPlayers(ctx)
+	if err != nil {
+		ctx.Logger.Error("players", logging.KeyErr, err)
+	} else {
+		h.hub.Broadcast(gameUUID, playersResponse)
+	}
+	unlock()
+	if ctx.Game.Rules.HasClock() && !ctx.Game.IsFinished() {
+		h.gameClocks.Start(gameUUID)
+	}
 
-		// fmt.Println(string(broadcastResponse))
-		// fmt.Println(string(senderResponse))
-		if broadcastResponse != nil {
-			for _, c := range h.connections[sessionUUID] {
-				if err := c.WriteMessage(messageType, broadcastResponse); err != nil {
-					log.Println(err)
-				}
-			}
-		}
-		if senderResponse != nil {
-			if err := conn.WriteMessage(messageType, senderResponse); err != nil {
-				log.Println(err)
-			}
+	for {
+		_, p, err := conn.ReadMessage()
+		if err != nil {
+			ctx.Logger.Debug("player disconnected", logging.KeyErr, err)
+			return
 		}
+		ctx.Logger.Debug("message received", "message", string(p))
+		h.handleMessage(ctx, p)
 	}
 }
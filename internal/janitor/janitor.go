@@ -0,0 +1,177 @@
+package janitor
+
+import (
+	"context"
+	"log/slog"
+	"scrable3/internal/cfg"
+	"scrable3/internal/handler"
+	"scrable3/internal/logging"
+	"scrable3/internal/model"
+	"scrable3/internal/svc"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// How often games are checked for deletion
+const interval = time.Hour
+
+// Deletes games that are not needed anymore in the background. Players,
+// fields, racks, words and plays go with the game through the cascades of the
+// database, connections of the game are closed and its clock stopped.
+type Janitor interface {
+	Start()
+	// Stops the janitor and waits for the cleaning in progress
+	Stop()
+	// Deletes abandoned and old finished games once, returns how many of each
+	// were deleted
+	Clean(now time.Time) (abandoned int, finished int)
+}
+
+type janitor struct {
+	gameService svc.GameService
+	hub         handler.Hub
+	gameClocks  handler.GameClocks
+	config      cfg.CleanupConfig
+	logger      *slog.Logger
+	mu          sync.Mutex
+	// Closing the channel stops the janitor, nil when it is not running
+	stop chan struct{}
+	// Closed when the janitor stopped
+	done chan struct{}
+}
+
+func New(
+	gameService svc.GameService,
+	hub handler.Hub,
+	gameClocks handler.GameClocks,
+	config cfg.CleanupConfig,
+	logger *slog.Logger,
+) Janitor {
+	return &janitor{
+		gameService: gameService,
+		hub:         hub,
+		gameClocks:  gameClocks,
+		config:      config,
+		logger:      logger,
+	}
+}
+
+// |PRIVATE| //
+
+func (j *janitor) run(stop chan struct{}, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	// Cleaning right away, so frequent restarts do not postpone it
+	j.Clean(time.Now())
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			j.Clean(now)
+		}
+	}
+}
+
+// Unfinished games count as abandoned when nobody updated them for the
+// configured time, finished ones expire after the retention window
+func (j *janitor) isAbandoned(game *model.Game, now time.Time) bool {
+	after := time.Duration(j.config.AbandonedAfter)
+	return after > 0 && !game.IsFinished() && now.Sub(game.UpdateDate) > after
+}
+
+func (j *janitor) isExpired(game *model.Game, now time.Time) bool {
+	retention := time.Duration(j.config.FinishedRetention)
+	return retention > 0 && game.IsFinished() && now.Sub(game.FinishDate) > retention
+}
+
+// Deletes the game while holding its lock, so players and clocks do not
+// change it at the same time. The game is checked again, it could have been
+// played since it was listed.
+func (j *janitor) deleteGame(gameUUID uuid.UUID, now time.Time) (
+	deleted bool, abandoned bool, err error,
+) {
+	unlock := j.hub.LockGame(gameUUID)
+	defer unlock()
+
+	game, err := j.gameService.GetWithUUID(gameUUID)
+	if err != nil {
+		return false, false, err
+	}
+	abandoned = j.isAbandoned(game, now)
+	if !abandoned && !j.isExpired(game, now) {
+		return false, false, nil
+	}
+	if err := j.gameService.Delete(game); err != nil {
+		return false, false, err
+	}
+	j.gameClocks.Stop(gameUUID)
+	j.hub.CloseGame(gameUUID)
+	return true, abandoned, nil
+}
+
+// |PUBLIC| //
+
+func (j *janitor) Start() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.stop != nil {
+		return
+	}
+	j.stop = make(chan struct{})
+	j.done = make(chan struct{})
+	go j.run(j.stop, j.done)
+}
+
+func (j *janitor) Stop() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.stop != nil {
+		close(j.stop)
+		<-j.done
+		j.stop, j.done = nil, nil
+	}
+}
+
+func (j *janitor) Clean(now time.Time) (abandoned int, finished int) {
+	if j.config.AbandonedAfter == 0 && j.config.FinishedRetention == 0 {
+		return 0, 0
+	}
+	games, err := j.gameService.GetAll()
+	if err != nil {
+		j.logger.Error("cleanup games", logging.KeyErr, err)
+		return 0, 0
+	}
+
+	for _, game := range *games {
+		if !j.isAbandoned(&game, now) && !j.isExpired(&game, now) {
+			continue
+		}
+		deleted, isAbandoned, err := j.deleteGame(game.UUID, now)
+		if err != nil {
+			j.logger.Error("cleanup game", logging.KeyGame, game.UUID, logging.KeyErr, err)
+			continue
+		}
+		if !deleted {
+			continue
+		}
+		if isAbandoned {
+			abandoned++
+		} else {
+			finished++
+		}
+	}
+
+	level := slog.LevelDebug
+	if abandoned > 0 || finished > 0 {
+		level = slog.LevelInfo
+	}
+	j.logger.Log(
+		context.Background(), level, "games cleaned",
+		"abandoned", abandoned, "finished", finished,
+	)
+	return abandoned, finished
+}
@@ -0,0 +1,238 @@
+package janitor
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"scrable3/internal/cfg"
+	"scrable3/internal/handler"
+	"scrable3/internal/lang"
+	"scrable3/internal/logging"
+	"scrable3/internal/metrics"
+	"scrable3/internal/model"
+	"scrable3/internal/repo"
+	"scrable3/internal/svc"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+// Remembers games whose clocks were stopped
+type stoppedClocks struct {
+	stopped []uuid.UUID
+}
+
+func (c *stoppedClocks) Start(gameUUID uuid.UUID) {}
+
+func (c *stoppedClocks) Stop(gameUUID uuid.UUID) {
+	c.stopped = append(c.stopped, gameUUID)
+}
+
+func (c *stoppedClocks) StopAll() {}
+
+// Connects a websocket client to the game through the hub
+func connectToGame(
+	t *testing.T,
+	hub handler.Hub,
+	gameUUID uuid.UUID,
+) *websocket.Conn {
+	var upgrader websocket.Upgrader
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			conn, err := upgrader.Upgrade(w, r, nil)
+			if err != nil {
+				return
+			}
+			hub.Add(gameUUID, uuid.New(), conn)
+		},
+	))
+	t.Cleanup(server.Close)
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("connecting failed; %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// Repository kept in memory, gone when the test ends
+func newMemoryRepository(t *testing.T) repo.Repository {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("opening database failed; %v", err)
+	}
+	// Every connection opens a separate in-memory database
+	db.SetMaxOpenConns(1)
+	repository, err := repo.NewSqlite3Connection(db, logging.Discard(), metrics.New())
+	if err != nil {
+		t.Fatalf("creating repository failed; %v", err)
+	}
+	t.Cleanup(func() { repository.CloseConn() })
+	return repository
+}
+
+func insertGame(
+	t *testing.T,
+	repository repo.Repository,
+	updateDate time.Time,
+	finishDate time.Time,
+) *model.Game {
+	game := &model.Game{
+		UUID:       uuid.New(),
+		CreateDate: updateDate,
+		UpdateDate: updateDate,
+		FinishDate: finishDate,
+		Rules: model.GameRules{
+			BoardSize:   9,
+			RackSize:    7,
+			MinWordLen:  3,
+			PointsToWin: 20,
+			Language:    "en",
+			Alphabet:    "ABC",
+		},
+	}
+	if err := repository.InsertGame(game); err != nil {
+		t.Fatalf("inserting game failed; %v", err)
+	}
+	player := &model.Player{
+		UUID:       uuid.New(),
+		CreateDate: updateDate,
+		UpdateDate: updateDate,
+		GameUUID:   game.UUID,
+		Name:       "Ala",
+	}
+	if err := repository.InsertPlayer(player); err != nil {
+		t.Fatalf("inserting player failed; %v", err)
+	}
+	field := &model.Field{
+		GameUUID:   game.UUID,
+		PlayerUUID: player.UUID,
+		AppendNum:  model.InitialAppendNum,
+		Value:      "A",
+	}
+	if err := repository.InsertField(field); err != nil {
+		t.Fatalf("inserting field failed; %v", err)
+	}
+	return game
+}
+
+func TestClean(t *testing.T) {
+	repository := newMemoryRepository(t)
+	gameService := svc.NewGameService(repository, cfg.Default().Game, lang.Packs{})
+	now := time.Now()
+	day := 24 * time.Hour
+
+	active := insertGame(t, repository, now.Add(-day), time.Time{})
+	abandoned := insertGame(t, repository, now.Add(-40*day), time.Time{})
+	recentlyFinished := insertGame(t, repository, now.Add(-40*day), now.Add(-40*day))
+	oldFinished := insertGame(t, repository, now.Add(-100*day), now.Add(-100*day))
+
+	hub := handler.NewHub(logging.Discard())
+	conn := connectToGame(t, hub, abandoned.UUID)
+	gameClocks := &stoppedClocks{}
+
+	j := New(gameService, hub, gameClocks, cfg.Default().Cleanup, logging.Discard())
+	abandonedCount, finishedCount := j.Clean(now)
+	if abandonedCount != 1 || finishedCount != 1 {
+		t.Errorf(
+			"expected 1 abandoned and 1 finished game deleted, got %v and %v",
+			abandonedCount, finishedCount,
+		)
+	}
+
+	if len(gameClocks.stopped) != 2 {
+		t.Errorf("expected clocks of 2 deleted games stopped, got %v", gameClocks.stopped)
+	}
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	_, _, err := conn.ReadMessage()
+	if !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+		t.Errorf("expected connection of deleted game closed, got %v", err)
+	}
+
+	testCases := []struct {
+		name    string
+		game    *model.Game
+		deleted bool
+	}{
+		{"active", active, false},
+		{"abandoned", abandoned, true},
+		{"recently finished", recentlyFinished, false},
+		{"old finished", oldFinished, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := repository.SelectGameByUUID(tc.game.UUID)
+			if deleted := errors.Is(err, repo.ErrNotExists); deleted != tc.deleted {
+				t.Errorf("expected deleted %v, got error %v", tc.deleted, err)
+			}
+			players, err := repository.SelectPlayersByGameID(tc.game.UUID)
+			if err != nil {
+				t.Fatalf("selecting players failed; %v", err)
+			}
+			if (len(*players) == 0) != tc.deleted {
+				t.Errorf("expected players deleted %v, got %v players", tc.deleted, len(*players))
+			}
+			fields, err := repository.SelectFieldsByGameID(tc.game.UUID)
+			if err != nil {
+				t.Fatalf("selecting fields failed; %v", err)
+			}
+			if (len(*fields) == 0) != tc.deleted {
+				t.Errorf("expected fields deleted %v, got %v fields", tc.deleted, len(*fields))
+			}
+		})
+	}
+}
+
+func TestCleanDisabled(t *testing.T) {
+	repository := newMemoryRepository(t)
+	gameService := svc.NewGameService(repository, cfg.Default().Game, lang.Packs{})
+	now := time.Now()
+	insertGame(t, repository, now.Add(-1000*24*time.Hour), time.Time{})
+
+	j := New(
+		gameService,
+		handler.NewHub(logging.Discard()),
+		&stoppedClocks{},
+		cfg.CleanupConfig{},
+		logging.Discard(),
+	)
+	if abandoned, finished := j.Clean(now); abandoned != 0 || finished != 0 {
+		t.Errorf("expected nothing deleted, got %v and %v", abandoned, finished)
+	}
+}
+
+func TestStopWaitsForCleaning(t *testing.T) {
+	repository := newMemoryRepository(t)
+	gameService := svc.NewGameService(repository, cfg.Default().Game, lang.Packs{})
+	game := insertGame(t, repository, time.Now().Add(-1000*24*time.Hour), time.Time{})
+	hub := handler.NewHub(logging.Discard())
+
+	// Cleaning waits for the game lock held by the test
+	unlock := hub.LockGame(game.UUID)
+	j := New(gameService, hub, &stoppedClocks{}, cfg.Default().Cleanup, logging.Discard())
+	j.Start()
+	stopped := make(chan struct{})
+	go func() {
+		j.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		t.Fatal("stop returned while cleaning was in progress")
+	case <-time.After(50 * time.Millisecond):
+	}
+	unlock()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("stop did not return after cleaning finished")
+	}
+	if _, err := repository.SelectGameByUUID(game.UUID); !errors.Is(err, repo.ErrNotExists) {
+		t.Errorf("expected game deleted before stop returned, got %v", err)
+	}
+}
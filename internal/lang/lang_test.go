@@ -0,0 +1,91 @@
+package lang
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestLoadBundledPacks(t *testing.T) {
+	packs, err := LoadPacks(filepath.Join("..", "..", "langs"))
+	if err != nil {
+		t.Fatalf("loading packs failed; %v", err)
+	}
+
+	expectedLetters := map[string]int{"en": 26, "pl": 32}
+	for code, letters := range expectedLetters {
+		pack, err := packs.Get(code)
+		if err != nil {
+			t.Errorf("pack %v missing; %v", code, err)
+			continue
+		}
+		if utf8.RuneCountInString(pack.Alphabet) != letters {
+			t.Errorf("pack %v; expected %v letters, got %v", code, letters, pack.Alphabet)
+		}
+		tiles := 0
+		for _, count := range pack.Distribution {
+			tiles += count
+		}
+		if tiles != 98 {
+			t.Errorf("pack %v; expected 98 tiles, got %v", code, tiles)
+		}
+		if filepath.Dir(pack.WordsFile) != filepath.Join("..", "..", "words") {
+			t.Errorf("pack %v; words file not relative to the pack; %v", code, pack.WordsFile)
+		}
+	}
+
+	if _, err := packs.Get("xx"); !errors.Is(err, ErrUnknownLanguage) {
+		t.Errorf("expected ErrUnknownLanguage, got %v", err)
+	}
+}
+
+func TestInvalidPacks(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+	}{
+		{"unknown field", `{"code": "x", "alphabet": "A", "words_file": "w", "values": {"A": 1}, "distribution": {"A": 1}, "extra": 1}`},
+		{"no code", `{"alphabet": "A", "words_file": "w", "values": {"A": 1}, "distribution": {"A": 1}}`},
+		{"lower case letter", `{"code": "x", "alphabet": "a", "words_file": "w", "values": {"a": 1}, "distribution": {"a": 1}}`},
+		{"repeated letter", `{"code": "x", "alphabet": "AA", "words_file": "w", "values": {"A": 1}, "distribution": {"A": 1}}`},
+		{"letter without value", `{"code": "x", "alphabet": "AB", "words_file": "w", "values": {"A": 1}, "distribution": {"A": 1, "B": 1}}`},
+		{"letter without tiles", `{"code": "x", "alphabet": "AB", "words_file": "w", "values": {"A": 1, "B": 1}, "distribution": {"A": 1}}`},
+		{"tiles outside alphabet", `{"code": "x", "alphabet": "A", "words_file": "w", "values": {"A": 1}, "distribution": {"A": 1, "B": 1}}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "pack.json")
+			if err := os.WriteFile(filePath, []byte(tc.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := LoadPack(filePath); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestNormalizeAndScore(t *testing.T) {
+	// Decomposed letters are written as base letter and combining mark
+	decomposed := "Z\u0307O\u0301ŁW"
+	if Normalize(decomposed) != "ŻÓŁW" {
+		t.Errorf("expected ŻÓŁW, got %q", Normalize(decomposed))
+	}
+	if NormalizeWord(" "+decomposed+"\n") != "żółw" {
+		t.Errorf("expected żółw, got %q", NormalizeWord(decomposed))
+	}
+
+	pack := &Pack{Values: map[string]int64{"Ż": 5, "Ó": 5, "Ł": 3, "W": 1}}
+	if score := pack.Score(decomposed); score != 14 {
+		t.Errorf("expected 14 points, got %v", score)
+	}
+	if score := pack.Score("żółw"); score != 14 {
+		t.Errorf("expected 14 points for lower case word, got %v", score)
+	}
+	if score := pack.Score("XYZ"); score != 0 {
+		t.Errorf("expected no points for letters outside alphabet, got %v", score)
+	}
+}
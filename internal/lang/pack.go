@@ -0,0 +1,168 @@
+package lang
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Language pack, read from a JSON file in the packs directory
+type Pack struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+	// Upper case letters that can be placed on the board
+	Alphabet string `json:"alphabet"`
+	// Word list with one word per line, relative to the pack file
+	WordsFile string `json:"words_file"`
+	// Points for every letter of the alphabet
+	Values map[string]int64 `json:"values"`
+	// Number of tiles of every letter in the bag
+	Distribution map[string]int `json:"distribution"`
+}
+
+// Language packs by their code
+type Packs map[string]*Pack
+
+var ErrUnknownLanguage = errors.New("unknown language")
+
+// |PRIVATE| //
+
+func (p *Pack) validate() error {
+	var errs []error
+	if p.Code == "" {
+		errs = append(errs, errors.New("code cannot be empty"))
+	}
+	if p.Alphabet == "" {
+		errs = append(errs, errors.New("alphabet cannot be empty"))
+	}
+	if p.WordsFile == "" {
+		errs = append(errs, errors.New("words_file cannot be empty"))
+	}
+	for i, letter := range p.Alphabet {
+		key := string(letter)
+		if !unicode.IsUpper(letter) {
+			errs = append(errs, fmt.Errorf("letter %q is not upper case", letter))
+		}
+		if strings.ContainsRune(p.Alphabet[:i], letter) {
+			errs = append(errs, fmt.Errorf("letter %q is repeated", letter))
+		}
+		if _, ok := p.Values[key]; !ok {
+			errs = append(errs, fmt.Errorf("letter %q has no value", letter))
+		}
+		if p.Distribution[key] < 1 {
+			errs = append(errs, fmt.Errorf("letter %q has no tiles", letter))
+		}
+	}
+	for key := range p.Distribution {
+		if !strings.Contains(p.Alphabet, key) {
+			errs = append(errs, fmt.Errorf("tiles of %q are not in alphabet", key))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// |PUBLIC| //
+
+// Normalizes text written in any Unicode form, so letters with diacritics
+// compare equal however they were composed
+func Normalize(s string) string {
+	return norm.NFC.String(s)
+}
+
+// Normalizes word for dictionary lookup
+func NormalizeWord(word string) string {
+	return strings.ToLower(Normalize(strings.TrimSpace(word)))
+}
+
+func LoadPack(filePath string) (*Pack, error) {
+	fl, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer fl.Close()
+
+	var pack Pack
+	decoder := json.NewDecoder(fl)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&pack); err != nil {
+		return nil, fmt.Errorf("language pack %v: %w", filePath, err)
+	}
+
+	pack.Alphabet = Normalize(pack.Alphabet)
+	values := make(map[string]int64, len(pack.Values))
+	for letter, value := range pack.Values {
+		values[Normalize(letter)] = value
+	}
+	pack.Values = values
+	distribution := make(map[string]int, len(pack.Distribution))
+	for letter, count := range pack.Distribution {
+		distribution[Normalize(letter)] = count
+	}
+	pack.Distribution = distribution
+	if !filepath.IsAbs(pack.WordsFile) {
+		pack.WordsFile = filepath.Join(filepath.Dir(filePath), pack.WordsFile)
+	}
+
+	if err := pack.validate(); err != nil {
+		return nil, fmt.Errorf("language pack %v: %w", filePath, err)
+	}
+	return &pack, nil
+}
+
+// Loads every *.json language pack from the directory
+func LoadPacks(dir string) (Packs, error) {
+	filePaths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	packs := make(Packs)
+	for _, filePath := range filePaths {
+		pack, err := LoadPack(filePath)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := packs[pack.Code]; ok {
+			return nil, fmt.Errorf("language pack %v: code %v repeated", filePath, pack.Code)
+		}
+		packs[pack.Code] = pack
+	}
+	return packs, nil
+}
+
+func (packs Packs) Get(code string) (*Pack, error) {
+	pack, ok := packs[code]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownLanguage, code)
+	}
+	return pack, nil
+}
+
+// Packs sorted by their names
+func (packs Packs) Sorted() []*Pack {
+	sorted := make([]*Pack, 0, len(packs))
+	for _, pack := range packs {
+		sorted = append(sorted, pack)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// Sums values of the word letters, letters outside of the alphabet are worth
+// nothing
+func (p *Pack) Score(word string) int64 {
+	var score int64
+	for _, letter := range Normalize(word) {
+		score += p.Values[string(unicode.ToUpper(letter))]
+	}
+	return score
+}
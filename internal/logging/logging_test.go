@@ -0,0 +1,60 @@
+package logging
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, "info", "json")
+	if err != nil {
+		t.Fatalf("creating logger failed; %v", err)
+	}
+	logger = logger.With(KeyRequestID, "abc")
+	logger.Debug("hidden")
+	logger.Info("shown", KeyGame, "game")
+
+	scanner := bufio.NewScanner(&buf)
+	var lines []map[string]any
+	for scanner.Scan() {
+		var line map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("line is not JSON; %v", err)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 line, got %v", len(lines))
+	}
+	if lines[0]["msg"] != "shown" || lines[0][KeyRequestID] != "abc" || lines[0][KeyGame] != "game" {
+		t.Errorf("wrong line %v", lines[0])
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	if _, err := New(&bytes.Buffer{}, "verbose", "text"); err == nil {
+		t.Error("expected error for unknown level, got nil")
+	}
+	if _, err := New(&bytes.Buffer{}, "info", "xml"); err == nil {
+		t.Error("expected error for unknown format, got nil")
+	}
+}
+
+func TestContext(t *testing.T) {
+	if FromContext(context.Background()) != slog.Default() {
+		t.Error("expected default logger without one in the context")
+	}
+	logger := Discard()
+	ctx := NewContext(context.Background(), logger)
+	if FromContext(ctx) != logger {
+		t.Error("expected logger stored in the context")
+	}
+	if NewRequestID() == NewRequestID() {
+		t.Error("expected different request IDs")
+	}
+}
@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reasons plays are rejected for, one for every stage of checking the play
+const (
+	ReasonTurn      = "turn"
+	ReasonChars     = "chars"
+	ReasonPlacement = "placement"
+	ReasonWord      = "word"
+	ReasonInternal  = "internal"
+)
+
+// Metrics of the server exposed at /metrics
+type Metrics struct {
+	*Registry
+	connections       *GaugeVec
+	plays             *CounterVec
+	dictionaryLookups *CounterVec
+	queryDuration     *HistogramVec
+	renderDuration    *HistogramVec
+}
+
+func New() *Metrics {
+	r := NewRegistry()
+	m := &Metrics{Registry: r}
+	m.connections = r.NewGaugeVec(
+		"scrable3_websocket_connections",
+		"Open websocket connections per game.",
+		"game_uuid",
+	)
+	r.NewGaugeFunc(
+		"scrable3_active_games",
+		"Games with at least one open websocket connection.",
+		func() float64 { return float64(m.connections.Len()) },
+	)
+	m.plays = r.NewCounterVec(
+		"scrable3_plays_total",
+		"Plays by result, rejected plays by the reason.",
+		"result", "reason",
+	)
+	m.dictionaryLookups = r.NewCounterVec(
+		"scrable3_dictionary_lookups_total",
+		"Words looked up in the dictionary by result.",
+		"result",
+	)
+	m.queryDuration = r.NewHistogramVec(
+		"scrable3_repository_query_duration_seconds",
+		"Time of repository queries by method.",
+		DefaultBuckets,
+		"method",
+	)
+	m.renderDuration = r.NewHistogramVec(
+		"scrable3_template_render_duration_seconds",
+		"Time of rendering templates by template.",
+		DefaultBuckets,
+		"template",
+	)
+	return m
+}
+
+func (m *Metrics) ConnectionOpened(gameUUID uuid.UUID) {
+	m.connections.Add(1, gameUUID.String())
+}
+
+func (m *Metrics) ConnectionClosed(gameUUID uuid.UUID) {
+	m.connections.Add(-1, gameUUID.String())
+}
+
+func (m *Metrics) PlayAccepted() {
+	m.plays.Inc("accepted", "")
+}
+
+func (m *Metrics) PlayRejected(reason string) {
+	m.plays.Inc("rejected", reason)
+}
+
+func (m *Metrics) DictionaryLookup(found bool) {
+	result := "found"
+	if !found {
+		result = "missing"
+	}
+	m.dictionaryLookups.Inc(result)
+}
+
+// Records time since 'start', meant to be deferred at the start of the method
+func (m *Metrics) ObserveQuery(method string, start time.Time) {
+	m.queryDuration.Observe(time.Since(start).Seconds(), method)
+}
+
+// Records time since 'start' of rendering the template
+func (m *Metrics) ObserveRender(template string, start time.Time) {
+	m.renderDuration.Observe(time.Since(start).Seconds(), template)
+}
@@ -0,0 +1,112 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestWriteText(t *testing.T) {
+	r := NewRegistry()
+	counter := r.NewCounterVec("test_total", "Test counter.", "result")
+	histogram := r.NewHistogramVec("test_seconds", "Test histogram.", []float64{0.1, 1}, "method")
+	r.NewGaugeFunc("test_gauge", "Test gauge.", func() float64 { return 3 })
+
+	counter.Inc("ok")
+	counter.Inc("ok")
+	counter.Inc("failed")
+	histogram.Observe(0.05, "Select")
+	histogram.Observe(0.5, "Select")
+	histogram.Observe(2, "Select")
+
+	var buf strings.Builder
+	if err := r.WriteText(&buf); err != nil {
+		t.Fatalf("writing failed; %v", err)
+	}
+	expected := `# HELP test_total Test counter.
+# TYPE test_total counter
+test_total{result="failed"} 1
+test_total{result="ok"} 2
+# HELP test_seconds Test histogram.
+# TYPE test_seconds histogram
+test_seconds_bucket{method="Select",le="0.1"} 1
+test_seconds_bucket{method="Select",le="1"} 2
+test_seconds_bucket{method="Select",le="+Inf"} 3
+test_seconds_sum{method="Select"} 2.55
+test_seconds_count{method="Select"} 3
+# HELP test_gauge Test gauge.
+# TYPE test_gauge gauge
+test_gauge 3
+`
+	if buf.String() != expected {
+		t.Errorf("expected\n%v\ngot\n%v", expected, buf.String())
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	m := New()
+	game := uuid.New()
+	m.ConnectionOpened(game)
+	m.ConnectionOpened(game)
+	m.ConnectionOpened(uuid.New())
+	m.ConnectionClosed(game)
+	m.PlayAccepted()
+	m.PlayRejected(ReasonWord)
+	m.DictionaryLookup(false)
+	m.ObserveQuery("SelectGameByUUID", time.Now())
+	m.ObserveRender("fields.html", time.Now())
+
+	if m.plays.Value("rejected", ReasonWord) != 1 {
+		t.Error("expected one rejected play")
+	}
+
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := rec.Body.String()
+	for _, line := range []string{
+		"scrable3_active_games 2",
+		`scrable3_websocket_connections{game_uuid="` + game.String() + `"} 1`,
+		`scrable3_plays_total{result="accepted",reason=""} 1`,
+		`scrable3_dictionary_lookups_total{result="missing"} 1`,
+		`scrable3_repository_query_duration_seconds_count{method="SelectGameByUUID"} 1`,
+		`scrable3_template_render_duration_seconds_count{template="fields.html"} 1`,
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("missing line %q in\n%v", line, body)
+		}
+	}
+
+	m.ConnectionClosed(game)
+	if m.connections.Len() != 1 {
+		t.Errorf("expected closed game to be removed, got %v games", m.connections.Len())
+	}
+}
+
+func TestLabelValues(t *testing.T) {
+	r := NewRegistry()
+	counter := r.NewCounterVec("test_total", "Test \\ counter\nwith lines.", "first", "second")
+	// Values that a separator would split differently
+	counter.Inc("a\x00b", "c")
+	counter.Inc("a", "b\x00c")
+	counter.Inc(`back\slash "quoted"`, "line\nfeed é")
+
+	if counter.Value("a\x00b", "c") != 1 || counter.Value("a", "b\x00c") != 1 {
+		t.Error("expected separate series for values with zero bytes")
+	}
+	var buf strings.Builder
+	if err := r.WriteText(&buf); err != nil {
+		t.Fatalf("writing failed; %v", err)
+	}
+	expected := "# HELP test_total Test \\\\ counter\\nwith lines.\n" +
+		"# TYPE test_total counter\n" +
+		"test_total{first=\"a\",second=\"b\x00c\"} 1\n" +
+		"test_total{first=\"a\x00b\",second=\"c\"} 1\n" +
+		`test_total{first="back\\slash \"quoted\"",second="line\nfeed é"} 1` + "\n"
+	if buf.String() != expected {
+		t.Errorf("expected\n%q\ngot\n%q", expected, buf.String())
+	}
+}
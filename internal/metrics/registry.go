@@ -0,0 +1,310 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Content type of the Prometheus text exposition format
+const textContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Upper bounds in seconds of histogram buckets, from a tenth of a millisecond
+// to a second
+var DefaultBuckets = []float64{
+	0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1,
+}
+
+// Metric written in the text exposition format
+type family interface {
+	write(w *bufio.Writer)
+}
+
+// Metrics exposed together, in order of registration
+type Registry struct {
+	mu       sync.Mutex
+	families []family
+}
+
+// Labelled series of one metric. Keys are label values each preceded by its
+// length, so values can hold any text.
+type series[T any] struct {
+	name   string
+	help   string
+	labels []string
+	mu     sync.Mutex
+	values map[string]T
+	// Label values of every key in values
+	labelValues map[string][]string
+}
+
+type CounterVec struct {
+	series[float64]
+}
+
+type GaugeVec struct {
+	series[float64]
+}
+
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+type HistogramVec struct {
+	series[*histogram]
+	buckets []float64
+}
+
+// Gauge read when metrics are written
+type GaugeFunc struct {
+	name string
+	help string
+	fn   func() float64
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// |PRIVATE| //
+
+func newSeries[T any](name string, help string, labels []string) series[T] {
+	return series[T]{
+		name:        name,
+		help:        help,
+		labels:      labels,
+		values:      make(map[string]T),
+		labelValues: make(map[string][]string),
+	}
+}
+
+// Label values escape only backslash, double quote and line feed
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// Help text escapes backslash and line feed
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+func (r *Registry) register(f family) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.families = append(r.families, f)
+}
+
+func (s *series[T]) key(labelValues []string) string {
+	if len(labelValues) != len(s.labels) {
+		panic(fmt.Sprintf(
+			"metric %v has labels %v, got values %v", s.name, s.labels, labelValues,
+		))
+	}
+	var b strings.Builder
+	for _, value := range labelValues {
+		b.WriteString(strconv.Itoa(len(value)))
+		b.WriteByte(':')
+		b.WriteString(value)
+	}
+	return b.String()
+}
+
+// Remembers label values of the key, called before the series gets a value
+func (s *series[T]) keep(key string, labelValues []string) {
+	if _, ok := s.labelValues[key]; !ok {
+		s.labelValues[key] = slices.Clone(labelValues)
+	}
+}
+
+// Label set written as {name="value",...}, with 'extra' appended as is
+func (s *series[T]) labelSet(key string, extra string) string {
+	var pairs []string
+	for i, value := range s.labelValues[key] {
+		pairs = append(pairs, fmt.Sprintf(
+			`%v="%v"`, s.labels[i], labelValueEscaper.Replace(value)))
+	}
+	if extra != "" {
+		pairs = append(pairs, extra)
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// Keys of the series in a stable order
+func (s *series[T]) sortedKeys() []string {
+	keys := make([]string, 0, len(s.values))
+	for key := range s.values {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a string, b string) int {
+		return slices.Compare(s.labelValues[a], s.labelValues[b])
+	})
+	return keys
+}
+
+func writeHeader(w *bufio.Writer, name string, help string, kind string) {
+	fmt.Fprintf(w, "# HELP %v %v\n", name, helpEscaper.Replace(help))
+	fmt.Fprintf(w, "# TYPE %v %v\n", name, kind)
+}
+
+func formatFloat(v float64) string {
+	if math.IsInf(v, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func (s *series[T]) writeValues(w *bufio.Writer, kind string, value func(T) float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeHeader(w, s.name, s.help, kind)
+	for _, key := range s.sortedKeys() {
+		fmt.Fprintf(w, "%v%v %v\n",
+			s.name, s.labelSet(key, ""), formatFloat(value(s.values[key])))
+	}
+}
+
+func (c *CounterVec) write(w *bufio.Writer) {
+	c.writeValues(w, "counter", func(v float64) float64 { return v })
+}
+
+func (g *GaugeVec) write(w *bufio.Writer) {
+	g.writeValues(w, "gauge", func(v float64) float64 { return v })
+}
+
+func (h *HistogramVec) write(w *bufio.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	writeHeader(w, h.name, h.help, "histogram")
+	for _, key := range h.sortedKeys() {
+		hist := h.values[key]
+		var cumulative uint64
+		for i, bound := range h.buckets {
+			cumulative += hist.counts[i]
+			le := fmt.Sprintf("le=%q", formatFloat(bound))
+			fmt.Fprintf(w, "%v_bucket%v %v\n", h.name, h.labelSet(key, le), cumulative)
+		}
+		fmt.Fprintf(w, "%v_bucket%v %v\n", h.name, h.labelSet(key, `le="+Inf"`), hist.count)
+		fmt.Fprintf(w, "%v_sum%v %v\n", h.name, h.labelSet(key, ""), formatFloat(hist.sum))
+		fmt.Fprintf(w, "%v_count%v %v\n", h.name, h.labelSet(key, ""), hist.count)
+	}
+}
+
+func (g *GaugeFunc) write(w *bufio.Writer) {
+	writeHeader(w, g.name, g.help, "gauge")
+	fmt.Fprintf(w, "%v %v\n", g.name, formatFloat(g.fn()))
+}
+
+// |PUBLIC| //
+
+func (r *Registry) NewCounterVec(name string, help string, labels ...string) *CounterVec {
+	c := &CounterVec{newSeries[float64](name, help, labels)}
+	r.register(c)
+	return c
+}
+
+func (r *Registry) NewGaugeVec(name string, help string, labels ...string) *GaugeVec {
+	g := &GaugeVec{newSeries[float64](name, help, labels)}
+	r.register(g)
+	return g
+}
+
+func (r *Registry) NewHistogramVec(
+	name string,
+	help string,
+	buckets []float64,
+	labels ...string,
+) *HistogramVec {
+	h := &HistogramVec{newSeries[*histogram](name, help, labels), buckets}
+	r.register(h)
+	return h
+}
+
+func (r *Registry) NewGaugeFunc(name string, help string, fn func() float64) *GaugeFunc {
+	g := &GaugeFunc{name: name, help: help, fn: fn}
+	r.register(g)
+	return g
+}
+
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := c.key(labelValues)
+	c.keep(key, labelValues)
+	c.values[key]++
+}
+
+// Returns the current value of the series, zero when it was never counted
+func (c *CounterVec) Value(labelValues ...string) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.values[c.key(labelValues)]
+}
+
+// Changes the gauge by 'delta' and returns the new value. Series that drop to
+// zero are removed, so labels of finished games do not pile up.
+func (g *GaugeVec) Add(delta float64, labelValues ...string) float64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	key := g.key(labelValues)
+	g.keep(key, labelValues)
+	g.values[key] += delta
+	value := g.values[key]
+	if value == 0 {
+		delete(g.values, key)
+		delete(g.labelValues, key)
+	}
+	return value
+}
+
+// Number of series with a value
+func (g *GaugeVec) Len() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return len(g.values)
+}
+
+func (h *HistogramVec) Observe(value float64, labelValues ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	key := h.key(labelValues)
+	hist, ok := h.values[key]
+	if !ok {
+		hist = &histogram{counts: make([]uint64, len(h.buckets))}
+		h.keep(key, labelValues)
+		h.values[key] = hist
+	}
+	if i, _ := slices.BinarySearch(h.buckets, value); i < len(h.buckets) {
+		hist.counts[i]++
+	}
+	hist.count++
+	hist.sum += value
+}
+
+// Writes all metrics in the text exposition format
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mu.Lock()
+	families := slices.Clone(r.families)
+	r.mu.Unlock()
+
+	bw := bufio.NewWriter(w)
+	for _, f := range families {
+		f.write(bw)
+	}
+	return bw.Flush()
+}
+
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", textContentType)
+	r.WriteText(w)
+}
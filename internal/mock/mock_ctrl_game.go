@@ -12,6 +12,8 @@ package mock
 import (
 	reflect "reflect"
 	dto "scrable3/internal/dto"
+	model "scrable3/internal/model"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -40,6 +42,70 @@ func (m *MockGameController) EXPECT() *MockGameControllerMockRecorder {
 	return m.recorder
 }
 
+// AnswerUndo mocks base method.
+func (m *MockGameController) AnswerUndo(ctx *dto.WsContext, accept bool) ([]byte, []byte, *model.Play, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnswerUndo", ctx, accept)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(*model.Play)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// AnswerUndo indicates an expected call of AnswerUndo.
+func (mr *MockGameControllerMockRecorder) AnswerUndo(ctx, accept any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnswerUndo", reflect.TypeOf((*MockGameController)(nil).AnswerUndo), ctx, accept)
+}
+
+// BuildNotice mocks base method.
+func (m *MockGameController) BuildNotice(message string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BuildNotice", message)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BuildNotice indicates an expected call of BuildNotice.
+func (mr *MockGameControllerMockRecorder) BuildNotice(message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildNotice", reflect.TypeOf((*MockGameController)(nil).BuildNotice), message)
+}
+
+// Challenge mocks base method.
+func (m *MockGameController) Challenge(ctx *dto.WsContext) ([]byte, []byte, *model.Play, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Challenge", ctx)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(*model.Play)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// Challenge indicates an expected call of Challenge.
+func (mr *MockGameControllerMockRecorder) Challenge(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Challenge", reflect.TypeOf((*MockGameController)(nil).Challenge), ctx)
+}
+
+// Forfeit mocks base method.
+func (m *MockGameController) Forfeit(game *model.Game, now time.Time, after time.Duration) (*model.Player, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Forfeit", game, now, after)
+	ret0, _ := ret[0].(*model.Player)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Forfeit indicates an expected call of Forfeit.
+func (mr *MockGameControllerMockRecorder) Forfeit(game, now, after any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Forfeit", reflect.TypeOf((*MockGameController)(nil).Forfeit), game, now, after)
+}
+
 // GetAvaibleChars mocks base method.
 func (m *MockGameController) GetAvaibleChars(ctx *dto.WsContext) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -70,6 +136,21 @@ func (mr *MockGameControllerMockRecorder) GetCurrentFields(ctx any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentFields", reflect.TypeOf((*MockGameController)(nil).GetCurrentFields), ctx)
 }
 
+// GetPlayers mocks base method.
+func (m *MockGameController) GetPlayers(ctx *dto.WsContext) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlayers", ctx)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlayers indicates an expected call of GetPlayers.
+func (mr *MockGameControllerMockRecorder) GetPlayers(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlayers", reflect.TypeOf((*MockGameController)(nil).GetPlayers), ctx)
+}
+
 // ReceiveChars mocks base method.
 func (m *MockGameController) ReceiveChars(ctx *dto.WsContext, p *dto.PlayData) ([]byte, []byte, error) {
 	m.ctrl.T.Helper()
@@ -85,3 +166,36 @@ func (mr *MockGameControllerMockRecorder) ReceiveChars(ctx, p any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveChars", reflect.TypeOf((*MockGameController)(nil).ReceiveChars), ctx, p)
 }
+
+// Tick mocks base method.
+func (m *MockGameController) Tick(game *model.Game, now time.Time) ([]byte, *model.Player, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tick", game, now)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(*model.Player)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Tick indicates an expected call of Tick.
+func (mr *MockGameControllerMockRecorder) Tick(game, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tick", reflect.TypeOf((*MockGameController)(nil).Tick), game, now)
+}
+
+// UndoPlay mocks base method.
+func (m *MockGameController) UndoPlay(ctx *dto.WsContext) ([]byte, []byte, *model.Play, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UndoPlay", ctx)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(*model.Play)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// UndoPlay indicates an expected call of UndoPlay.
+func (mr *MockGameControllerMockRecorder) UndoPlay(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UndoPlay", reflect.TypeOf((*MockGameController)(nil).UndoPlay), ctx)
+}
@@ -11,6 +11,7 @@ package mock
 
 import (
 	reflect "reflect"
+	model "scrable3/internal/model"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -40,29 +41,43 @@ func (m *MockWordsController) EXPECT() *MockWordsControllerMockRecorder {
 }
 
 // CheckWord mocks base method.
-func (m *MockWordsController) CheckWord(word string) error {
+func (m *MockWordsController) CheckWord(game *model.Game, word string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CheckWord", word)
+	ret := m.ctrl.Call(m, "CheckWord", game, word)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CheckWord indicates an expected call of CheckWord.
-func (mr *MockWordsControllerMockRecorder) CheckWord(word any) *gomock.Call {
+func (mr *MockWordsControllerMockRecorder) CheckWord(game, word any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckWord", reflect.TypeOf((*MockWordsController)(nil).CheckWord), word)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckWord", reflect.TypeOf((*MockWordsController)(nil).CheckWord), game, word)
+}
+
+// CheckWordLen mocks base method.
+func (m *MockWordsController) CheckWordLen(rules *model.GameRules, word string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckWordLen", rules, word)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckWordLen indicates an expected call of CheckWordLen.
+func (mr *MockWordsControllerMockRecorder) CheckWordLen(rules, word any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckWordLen", reflect.TypeOf((*MockWordsController)(nil).CheckWordLen), rules, word)
 }
 
 // WordsNumber mocks base method.
-func (m *MockWordsController) WordsNumber() int {
+func (m *MockWordsController) WordsNumber(language string) int {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WordsNumber")
+	ret := m.ctrl.Call(m, "WordsNumber", language)
 	ret0, _ := ret[0].(int)
 	return ret0
 }
 
 // WordsNumber indicates an expected call of WordsNumber.
-func (mr *MockWordsControllerMockRecorder) WordsNumber() *gomock.Call {
+func (mr *MockWordsControllerMockRecorder) WordsNumber(language any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WordsNumber", reflect.TypeOf((*MockWordsController)(nil).WordsNumber))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WordsNumber", reflect.TypeOf((*MockWordsController)(nil).WordsNumber), language)
 }
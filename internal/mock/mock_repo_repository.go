@@ -97,6 +97,48 @@ func (mr *MockRepositoryMockRecorder) DeleteGame(game any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGame", reflect.TypeOf((*MockRepository)(nil).DeleteGame), game)
 }
 
+// DeleteGameWord mocks base method.
+func (m *MockRepository) DeleteGameWord(gameWord *model.GameWord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGameWord", gameWord)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGameWord indicates an expected call of DeleteGameWord.
+func (mr *MockRepositoryMockRecorder) DeleteGameWord(gameWord any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGameWord", reflect.TypeOf((*MockRepository)(nil).DeleteGameWord), gameWord)
+}
+
+// DeletePlay mocks base method.
+func (m *MockRepository) DeletePlay(play *model.Play) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePlay", play)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePlay indicates an expected call of DeletePlay.
+func (mr *MockRepositoryMockRecorder) DeletePlay(play any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlay", reflect.TypeOf((*MockRepository)(nil).DeletePlay), play)
+}
+
+// DeleteSession mocks base method.
+func (m *MockRepository) DeleteSession(session *model.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSession", session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSession indicates an expected call of DeleteSession.
+func (mr *MockRepositoryMockRecorder) DeleteSession(session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockRepository)(nil).DeleteSession), session)
+}
+
 // InsertAvChar mocks base method.
 func (m *MockRepository) InsertAvChar(avChar *model.AvChar) error {
 	m.ctrl.T.Helper()
@@ -139,6 +181,34 @@ func (mr *MockRepositoryMockRecorder) InsertGame(game any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertGame", reflect.TypeOf((*MockRepository)(nil).InsertGame), game)
 }
 
+// InsertGameWord mocks base method.
+func (m *MockRepository) InsertGameWord(gameWord *model.GameWord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertGameWord", gameWord)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertGameWord indicates an expected call of InsertGameWord.
+func (mr *MockRepositoryMockRecorder) InsertGameWord(gameWord any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertGameWord", reflect.TypeOf((*MockRepository)(nil).InsertGameWord), gameWord)
+}
+
+// InsertPlay mocks base method.
+func (m *MockRepository) InsertPlay(play *model.Play) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertPlay", play)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertPlay indicates an expected call of InsertPlay.
+func (mr *MockRepositoryMockRecorder) InsertPlay(play any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertPlay", reflect.TypeOf((*MockRepository)(nil).InsertPlay), play)
+}
+
 // InsertPlayer mocks base method.
 func (m *MockRepository) InsertPlayer(player *model.Player) error {
 	m.ctrl.T.Helper()
@@ -153,6 +223,34 @@ func (mr *MockRepositoryMockRecorder) InsertPlayer(player any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertPlayer", reflect.TypeOf((*MockRepository)(nil).InsertPlayer), player)
 }
 
+// InsertSession mocks base method.
+func (m *MockRepository) InsertSession(session *model.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertSession", session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertSession indicates an expected call of InsertSession.
+func (mr *MockRepositoryMockRecorder) InsertSession(session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertSession", reflect.TypeOf((*MockRepository)(nil).InsertSession), session)
+}
+
+// InsertUser mocks base method.
+func (m *MockRepository) InsertUser(user *model.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertUser", user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertUser indicates an expected call of InsertUser.
+func (mr *MockRepositoryMockRecorder) InsertUser(user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUser", reflect.TypeOf((*MockRepository)(nil).InsertUser), user)
+}
+
 // Migrate mocks base method.
 func (m *MockRepository) Migrate() error {
 	m.ctrl.T.Helper()
@@ -167,6 +265,21 @@ func (mr *MockRepositoryMockRecorder) Migrate() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Migrate", reflect.TypeOf((*MockRepository)(nil).Migrate))
 }
 
+// SelectAvCharsByGameID mocks base method.
+func (m *MockRepository) SelectAvCharsByGameID(gameUUID uuid.UUID) (*[]model.AvChar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectAvCharsByGameID", gameUUID)
+	ret0, _ := ret[0].(*[]model.AvChar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectAvCharsByGameID indicates an expected call of SelectAvCharsByGameID.
+func (mr *MockRepositoryMockRecorder) SelectAvCharsByGameID(gameUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAvCharsByGameID", reflect.TypeOf((*MockRepository)(nil).SelectAvCharsByGameID), gameUUID)
+}
+
 // SelectAvCharsByPlayerID mocks base method.
 func (m *MockRepository) SelectAvCharsByPlayerID(playerUUID uuid.UUID) (*[]model.AvChar, error) {
 	m.ctrl.T.Helper()
@@ -212,6 +325,81 @@ func (mr *MockRepositoryMockRecorder) SelectGameByUUID(gameUUID any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectGameByUUID", reflect.TypeOf((*MockRepository)(nil).SelectGameByUUID), gameUUID)
 }
 
+// SelectGameWordsByGameID mocks base method.
+func (m *MockRepository) SelectGameWordsByGameID(gameUUID uuid.UUID) (*[]model.GameWord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectGameWordsByGameID", gameUUID)
+	ret0, _ := ret[0].(*[]model.GameWord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectGameWordsByGameID indicates an expected call of SelectGameWordsByGameID.
+func (mr *MockRepositoryMockRecorder) SelectGameWordsByGameID(gameUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectGameWordsByGameID", reflect.TypeOf((*MockRepository)(nil).SelectGameWordsByGameID), gameUUID)
+}
+
+// SelectGames mocks base method.
+func (m *MockRepository) SelectGames() (*[]model.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectGames")
+	ret0, _ := ret[0].(*[]model.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectGames indicates an expected call of SelectGames.
+func (mr *MockRepositoryMockRecorder) SelectGames() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectGames", reflect.TypeOf((*MockRepository)(nil).SelectGames))
+}
+
+// SelectGamesByUserID mocks base method.
+func (m *MockRepository) SelectGamesByUserID(userUUID uuid.UUID) (*[]model.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectGamesByUserID", userUUID)
+	ret0, _ := ret[0].(*[]model.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectGamesByUserID indicates an expected call of SelectGamesByUserID.
+func (mr *MockRepositoryMockRecorder) SelectGamesByUserID(userUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectGamesByUserID", reflect.TypeOf((*MockRepository)(nil).SelectGamesByUserID), userUUID)
+}
+
+// SelectLastPlayByGameID mocks base method.
+func (m *MockRepository) SelectLastPlayByGameID(gameUUID uuid.UUID) (*model.Play, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectLastPlayByGameID", gameUUID)
+	ret0, _ := ret[0].(*model.Play)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectLastPlayByGameID indicates an expected call of SelectLastPlayByGameID.
+func (mr *MockRepositoryMockRecorder) SelectLastPlayByGameID(gameUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectLastPlayByGameID", reflect.TypeOf((*MockRepository)(nil).SelectLastPlayByGameID), gameUUID)
+}
+
+// SelectPlayerByGameAndUserID mocks base method.
+func (m *MockRepository) SelectPlayerByGameAndUserID(gameUUID, userUUID uuid.UUID) (*model.Player, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectPlayerByGameAndUserID", gameUUID, userUUID)
+	ret0, _ := ret[0].(*model.Player)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectPlayerByGameAndUserID indicates an expected call of SelectPlayerByGameAndUserID.
+func (mr *MockRepositoryMockRecorder) SelectPlayerByGameAndUserID(gameUUID, userUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectPlayerByGameAndUserID", reflect.TypeOf((*MockRepository)(nil).SelectPlayerByGameAndUserID), gameUUID, userUUID)
+}
+
 // SelectPlayerByUUID mocks base method.
 func (m *MockRepository) SelectPlayerByUUID(playerUUID uuid.UUID) (*model.Player, error) {
 	m.ctrl.T.Helper()
@@ -227,6 +415,96 @@ func (mr *MockRepositoryMockRecorder) SelectPlayerByUUID(playerUUID any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectPlayerByUUID", reflect.TypeOf((*MockRepository)(nil).SelectPlayerByUUID), playerUUID)
 }
 
+// SelectPlayersByGameID mocks base method.
+func (m *MockRepository) SelectPlayersByGameID(gameUUID uuid.UUID) (*[]model.Player, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectPlayersByGameID", gameUUID)
+	ret0, _ := ret[0].(*[]model.Player)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectPlayersByGameID indicates an expected call of SelectPlayersByGameID.
+func (mr *MockRepositoryMockRecorder) SelectPlayersByGameID(gameUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectPlayersByGameID", reflect.TypeOf((*MockRepository)(nil).SelectPlayersByGameID), gameUUID)
+}
+
+// SelectPlaysByGameID mocks base method.
+func (m *MockRepository) SelectPlaysByGameID(gameUUID uuid.UUID) (*[]model.Play, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectPlaysByGameID", gameUUID)
+	ret0, _ := ret[0].(*[]model.Play)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectPlaysByGameID indicates an expected call of SelectPlaysByGameID.
+func (mr *MockRepositoryMockRecorder) SelectPlaysByGameID(gameUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectPlaysByGameID", reflect.TypeOf((*MockRepository)(nil).SelectPlaysByGameID), gameUUID)
+}
+
+// SelectSessionByTokenHash mocks base method.
+func (m *MockRepository) SelectSessionByTokenHash(tokenHash []byte) (*model.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectSessionByTokenHash", tokenHash)
+	ret0, _ := ret[0].(*model.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectSessionByTokenHash indicates an expected call of SelectSessionByTokenHash.
+func (mr *MockRepositoryMockRecorder) SelectSessionByTokenHash(tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectSessionByTokenHash", reflect.TypeOf((*MockRepository)(nil).SelectSessionByTokenHash), tokenHash)
+}
+
+// SelectUnfinishedGames mocks base method.
+func (m *MockRepository) SelectUnfinishedGames() (*[]model.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectUnfinishedGames")
+	ret0, _ := ret[0].(*[]model.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectUnfinishedGames indicates an expected call of SelectUnfinishedGames.
+func (mr *MockRepositoryMockRecorder) SelectUnfinishedGames() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectUnfinishedGames", reflect.TypeOf((*MockRepository)(nil).SelectUnfinishedGames))
+}
+
+// SelectUserByLogin mocks base method.
+func (m *MockRepository) SelectUserByLogin(login string) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectUserByLogin", login)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectUserByLogin indicates an expected call of SelectUserByLogin.
+func (mr *MockRepositoryMockRecorder) SelectUserByLogin(login any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectUserByLogin", reflect.TypeOf((*MockRepository)(nil).SelectUserByLogin), login)
+}
+
+// SelectUserByUUID mocks base method.
+func (m *MockRepository) SelectUserByUUID(userUUID uuid.UUID) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectUserByUUID", userUUID)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectUserByUUID indicates an expected call of SelectUserByUUID.
+func (mr *MockRepositoryMockRecorder) SelectUserByUUID(userUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectUserByUUID", reflect.TypeOf((*MockRepository)(nil).SelectUserByUUID), userUUID)
+}
+
 // UpdateGame mocks base method.
 func (m *MockRepository) UpdateGame(game *model.Game) error {
 	m.ctrl.T.Helper()
@@ -241,6 +519,20 @@ func (mr *MockRepositoryMockRecorder) UpdateGame(game any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGame", reflect.TypeOf((*MockRepository)(nil).UpdateGame), game)
 }
 
+// UpdatePlay mocks base method.
+func (m *MockRepository) UpdatePlay(play *model.Play) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePlay", play)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePlay indicates an expected call of UpdatePlay.
+func (mr *MockRepositoryMockRecorder) UpdatePlay(play any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlay", reflect.TypeOf((*MockRepository)(nil).UpdatePlay), play)
+}
+
 // UpdatePlayer mocks base method.
 func (m *MockRepository) UpdatePlayer(updatedPlayer *model.Player) error {
 	m.ctrl.T.Helper()
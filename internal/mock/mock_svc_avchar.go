@@ -42,18 +42,18 @@ func (m *MockAvCharService) EXPECT() *MockAvCharServiceMockRecorder {
 }
 
 // CreateMany mocks base method.
-func (m *MockAvCharService) CreateMany(player *model.Player, n int) (*[]model.AvChar, error) {
+func (m *MockAvCharService) CreateMany(player *model.Player, n int, distribution map[string]int) (*[]model.AvChar, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateMany", player, n)
+	ret := m.ctrl.Call(m, "CreateMany", player, n, distribution)
 	ret0, _ := ret[0].(*[]model.AvChar)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateMany indicates an expected call of CreateMany.
-func (mr *MockAvCharServiceMockRecorder) CreateMany(player, n any) *gomock.Call {
+func (mr *MockAvCharServiceMockRecorder) CreateMany(player, n, distribution any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMany", reflect.TypeOf((*MockAvCharService)(nil).CreateMany), player, n)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMany", reflect.TypeOf((*MockAvCharService)(nil).CreateMany), player, n, distribution)
 }
 
 // DeleteMany mocks base method.
@@ -84,3 +84,18 @@ func (mr *MockAvCharServiceMockRecorder) GetWithPlayerUUID(playerUUID any) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithPlayerUUID", reflect.TypeOf((*MockAvCharService)(nil).GetWithPlayerUUID), playerUUID)
 }
+
+// ReturnMany mocks base method.
+func (m *MockAvCharService) ReturnMany(player *model.Player, values []string, rackSize int) (*[]model.AvChar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReturnMany", player, values, rackSize)
+	ret0, _ := ret[0].(*[]model.AvChar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReturnMany indicates an expected call of ReturnMany.
+func (mr *MockAvCharServiceMockRecorder) ReturnMany(player, values, rackSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReturnMany", reflect.TypeOf((*MockAvCharService)(nil).ReturnMany), player, values, rackSize)
+}
@@ -86,6 +86,21 @@ func (mr *MockFieldServiceMockRecorder) Delete(field any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFieldService)(nil).Delete), field)
 }
 
+// DeleteWithAppendNum mocks base method.
+func (m *MockFieldService) DeleteWithAppendNum(gameUUID, playerUUID uuid.UUID, playerAppendNum int) (*[]model.Field, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWithAppendNum", gameUUID, playerUUID, playerAppendNum)
+	ret0, _ := ret[0].(*[]model.Field)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWithAppendNum indicates an expected call of DeleteWithAppendNum.
+func (mr *MockFieldServiceMockRecorder) DeleteWithAppendNum(gameUUID, playerUUID, playerAppendNum any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWithAppendNum", reflect.TypeOf((*MockFieldService)(nil).DeleteWithAppendNum), gameUUID, playerUUID, playerAppendNum)
+}
+
 // GetWithGameUUID mocks base method.
 func (m *MockFieldService) GetWithGameUUID(gameUUID uuid.UUID) (*[]model.Field, error) {
 	m.ctrl.T.Helper()
@@ -11,6 +11,7 @@ package mock
 
 import (
 	reflect "reflect"
+	lang "scrable3/internal/lang"
 	model "scrable3/internal/model"
 
 	uuid "github.com/google/uuid"
@@ -42,18 +43,47 @@ func (m *MockGameService) EXPECT() *MockGameServiceMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockGameService) Create() (*model.Game, error) {
+func (m *MockGameService) Create(rules model.GameRules) (*model.Game, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create")
+	ret := m.ctrl.Call(m, "Create", rules)
 	ret0, _ := ret[0].(*model.Game)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockGameServiceMockRecorder) Create() *gomock.Call {
+func (mr *MockGameServiceMockRecorder) Create(rules any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockGameService)(nil).Create))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockGameService)(nil).Create), rules)
+}
+
+// CurrentPlayer mocks base method.
+func (m *MockGameService) CurrentPlayer(game *model.Game) (*model.Player, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CurrentPlayer", game)
+	ret0, _ := ret[0].(*model.Player)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CurrentPlayer indicates an expected call of CurrentPlayer.
+func (mr *MockGameServiceMockRecorder) CurrentPlayer(game any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CurrentPlayer", reflect.TypeOf((*MockGameService)(nil).CurrentPlayer), game)
+}
+
+// DefaultRules mocks base method.
+func (m *MockGameService) DefaultRules() model.GameRules {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DefaultRules")
+	ret0, _ := ret[0].(model.GameRules)
+	return ret0
+}
+
+// DefaultRules indicates an expected call of DefaultRules.
+func (mr *MockGameServiceMockRecorder) DefaultRules() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DefaultRules", reflect.TypeOf((*MockGameService)(nil).DefaultRules))
 }
 
 // Delete mocks base method.
@@ -70,6 +100,64 @@ func (mr *MockGameServiceMockRecorder) Delete(game any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGameService)(nil).Delete), game)
 }
 
+// Finish mocks base method.
+func (m *MockGameService) Finish(game *model.Game) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Finish", game)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Finish indicates an expected call of Finish.
+func (mr *MockGameServiceMockRecorder) Finish(game any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finish", reflect.TypeOf((*MockGameService)(nil).Finish), game)
+}
+
+// Forfeit mocks base method.
+func (m *MockGameService) Forfeit(game *model.Game, player *model.Player) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Forfeit", game, player)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Forfeit indicates an expected call of Forfeit.
+func (mr *MockGameServiceMockRecorder) Forfeit(game, player any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Forfeit", reflect.TypeOf((*MockGameService)(nil).Forfeit), game, player)
+}
+
+// GetAll mocks base method.
+func (m *MockGameService) GetAll() (*[]model.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].(*[]model.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockGameServiceMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockGameService)(nil).GetAll))
+}
+
+// GetUnfinished mocks base method.
+func (m *MockGameService) GetUnfinished() (*[]model.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnfinished")
+	ret0, _ := ret[0].(*[]model.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnfinished indicates an expected call of GetUnfinished.
+func (mr *MockGameServiceMockRecorder) GetUnfinished() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnfinished", reflect.TypeOf((*MockGameService)(nil).GetUnfinished))
+}
+
 // GetWithUUID mocks base method.
 func (m *MockGameService) GetWithUUID(gameUUID uuid.UUID) (*model.Game, error) {
 	m.ctrl.T.Helper()
@@ -85,6 +173,49 @@ func (mr *MockGameServiceMockRecorder) GetWithUUID(gameUUID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithUUID", reflect.TypeOf((*MockGameService)(nil).GetWithUUID), gameUUID)
 }
 
+// GetWithUserUUID mocks base method.
+func (m *MockGameService) GetWithUserUUID(userUUID uuid.UUID) (*[]model.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithUserUUID", userUUID)
+	ret0, _ := ret[0].(*[]model.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWithUserUUID indicates an expected call of GetWithUserUUID.
+func (mr *MockGameServiceMockRecorder) GetWithUserUUID(userUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithUserUUID", reflect.TypeOf((*MockGameService)(nil).GetWithUserUUID), userUUID)
+}
+
+// Languages mocks base method.
+func (m *MockGameService) Languages() []*lang.Pack {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Languages")
+	ret0, _ := ret[0].([]*lang.Pack)
+	return ret0
+}
+
+// Languages indicates an expected call of Languages.
+func (mr *MockGameServiceMockRecorder) Languages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Languages", reflect.TypeOf((*MockGameService)(nil).Languages))
+}
+
+// NextTurn mocks base method.
+func (m *MockGameService) NextTurn(game *model.Game) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextTurn", game)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NextTurn indicates an expected call of NextTurn.
+func (mr *MockGameServiceMockRecorder) NextTurn(game any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextTurn", reflect.TypeOf((*MockGameService)(nil).NextTurn), game)
+}
+
 // Refresh mocks base method.
 func (m *MockGameService) Refresh(game *model.Game) error {
 	m.ctrl.T.Helper()
@@ -99,6 +230,35 @@ func (mr *MockGameServiceMockRecorder) Refresh(game any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockGameService)(nil).Refresh), game)
 }
 
+// TurnOrder mocks base method.
+func (m *MockGameService) TurnOrder(gameUUID uuid.UUID) ([]model.Player, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TurnOrder", gameUUID)
+	ret0, _ := ret[0].([]model.Player)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TurnOrder indicates an expected call of TurnOrder.
+func (mr *MockGameServiceMockRecorder) TurnOrder(gameUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TurnOrder", reflect.TypeOf((*MockGameService)(nil).TurnOrder), gameUUID)
+}
+
+// UndoTurn mocks base method.
+func (m *MockGameService) UndoTurn(game *model.Game, player *model.Player) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UndoTurn", game, player)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UndoTurn indicates an expected call of UndoTurn.
+func (mr *MockGameServiceMockRecorder) UndoTurn(game, player any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UndoTurn", reflect.TypeOf((*MockGameService)(nil).UndoTurn), game, player)
+}
+
 // Update mocks base method.
 func (m *MockGameService) Update(game *model.Game) error {
 	m.ctrl.T.Helper()
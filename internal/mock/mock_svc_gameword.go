@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/svc/gameword.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/svc/gameword.go -destination=internal/mock/mock_svc_gameword.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	model "scrable3/internal/model"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGameWordService is a mock of GameWordService interface.
+type MockGameWordService struct {
+	ctrl     *gomock.Controller
+	recorder *MockGameWordServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockGameWordServiceMockRecorder is the mock recorder for MockGameWordService.
+type MockGameWordServiceMockRecorder struct {
+	mock *MockGameWordService
+}
+
+// NewMockGameWordService creates a new mock instance.
+func NewMockGameWordService(ctrl *gomock.Controller) *MockGameWordService {
+	mock := &MockGameWordService{ctrl: ctrl}
+	mock.recorder = &MockGameWordServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGameWordService) EXPECT() *MockGameWordServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckEditable mocks base method.
+func (m *MockGameWordService) CheckEditable(game *model.Game, player *model.Player) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckEditable", game, player)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckEditable indicates an expected call of CheckEditable.
+func (mr *MockGameWordServiceMockRecorder) CheckEditable(game, player any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckEditable", reflect.TypeOf((*MockGameWordService)(nil).CheckEditable), game, player)
+}
+
+// GetWithGameUUID mocks base method.
+func (m *MockGameWordService) GetWithGameUUID(gameUUID uuid.UUID) (*[]model.GameWord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithGameUUID", gameUUID)
+	ret0, _ := ret[0].(*[]model.GameWord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWithGameUUID indicates an expected call of GetWithGameUUID.
+func (mr *MockGameWordServiceMockRecorder) GetWithGameUUID(gameUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithGameUUID", reflect.TypeOf((*MockGameWordService)(nil).GetWithGameUUID), gameUUID)
+}
+
+// Remove mocks base method.
+func (m *MockGameWordService) Remove(game *model.Game, player *model.Player, word string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", game, player, word)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockGameWordServiceMockRecorder) Remove(game, player, word any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockGameWordService)(nil).Remove), game, player, word)
+}
+
+// Set mocks base method.
+func (m *MockGameWordService) Set(game *model.Game, player *model.Player, word string, allowed bool) (*model.GameWord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", game, player, word, allowed)
+	ret0, _ := ret[0].(*model.GameWord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockGameWordServiceMockRecorder) Set(game, player, word, allowed any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockGameWordService)(nil).Set), game, player, word, allowed)
+}
@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/svc/play.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/svc/play.go -destination=internal/mock/mock_svc_play.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	model "scrable3/internal/model"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPlayService is a mock of PlayService interface.
+type MockPlayService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPlayServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockPlayServiceMockRecorder is the mock recorder for MockPlayService.
+type MockPlayServiceMockRecorder struct {
+	mock *MockPlayService
+}
+
+// NewMockPlayService creates a new mock instance.
+func NewMockPlayService(ctrl *gomock.Controller) *MockPlayService {
+	mock := &MockPlayService{ctrl: ctrl}
+	mock.recorder = &MockPlayServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPlayService) EXPECT() *MockPlayServiceMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockPlayService) Accept(play *model.Play) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", play)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockPlayServiceMockRecorder) Accept(play any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockPlayService)(nil).Accept), play)
+}
+
+// Create mocks base method.
+func (m *MockPlayService) Create(player *model.Player, playerAppendNum int, word string, points int64, provisional bool) (*model.Play, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", player, playerAppendNum, word, points, provisional)
+	ret0, _ := ret[0].(*model.Play)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPlayServiceMockRecorder) Create(player, playerAppendNum, word, points, provisional any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPlayService)(nil).Create), player, playerAppendNum, word, points, provisional)
+}
+
+// Delete mocks base method.
+func (m *MockPlayService) Delete(play *model.Play) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", play)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPlayServiceMockRecorder) Delete(play any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPlayService)(nil).Delete), play)
+}
+
+// GetLast mocks base method.
+func (m *MockPlayService) GetLast(gameUUID uuid.UUID) (*model.Play, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLast", gameUUID)
+	ret0, _ := ret[0].(*model.Play)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLast indicates an expected call of GetLast.
+func (mr *MockPlayServiceMockRecorder) GetLast(gameUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLast", reflect.TypeOf((*MockPlayService)(nil).GetLast), gameUUID)
+}
+
+// SetUndoRequested mocks base method.
+func (m *MockPlayService) SetUndoRequested(play *model.Play, requested bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUndoRequested", play, requested)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUndoRequested indicates an expected call of SetUndoRequested.
+func (mr *MockPlayServiceMockRecorder) SetUndoRequested(play, requested any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUndoRequested", reflect.TypeOf((*MockPlayService)(nil).SetUndoRequested), play, requested)
+}
@@ -56,6 +56,36 @@ func (mr *MockPlayerServiceMockRecorder) Create(game any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPlayerService)(nil).Create), game)
 }
 
+// GetWithGameAndUser mocks base method.
+func (m *MockPlayerService) GetWithGameAndUser(gameUUID uuid.UUID, user *model.User) (*model.Player, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithGameAndUser", gameUUID, user)
+	ret0, _ := ret[0].(*model.Player)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWithGameAndUser indicates an expected call of GetWithGameAndUser.
+func (mr *MockPlayerServiceMockRecorder) GetWithGameAndUser(gameUUID, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithGameAndUser", reflect.TypeOf((*MockPlayerService)(nil).GetWithGameAndUser), gameUUID, user)
+}
+
+// GetWithGameUUID mocks base method.
+func (m *MockPlayerService) GetWithGameUUID(gameUUID uuid.UUID) (*[]model.Player, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithGameUUID", gameUUID)
+	ret0, _ := ret[0].(*[]model.Player)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWithGameUUID indicates an expected call of GetWithGameUUID.
+func (mr *MockPlayerServiceMockRecorder) GetWithGameUUID(gameUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithGameUUID", reflect.TypeOf((*MockPlayerService)(nil).GetWithGameUUID), gameUUID)
+}
+
 // GetWithUUID mocks base method.
 func (m *MockPlayerService) GetWithUUID(playerUUID uuid.UUID) (*model.Player, error) {
 	m.ctrl.T.Helper()
@@ -71,6 +101,20 @@ func (mr *MockPlayerServiceMockRecorder) GetWithUUID(playerUUID any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithUUID", reflect.TypeOf((*MockPlayerService)(nil).GetWithUUID), playerUUID)
 }
 
+// LinkUser mocks base method.
+func (m *MockPlayerService) LinkUser(player *model.Player, user *model.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkUser", player, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkUser indicates an expected call of LinkUser.
+func (mr *MockPlayerServiceMockRecorder) LinkUser(player, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkUser", reflect.TypeOf((*MockPlayerService)(nil).LinkUser), player, user)
+}
+
 // Refresh mocks base method.
 func (m *MockPlayerService) Refresh(player *model.Player) error {
 	m.ctrl.T.Helper()
@@ -85,6 +129,20 @@ func (mr *MockPlayerServiceMockRecorder) Refresh(player any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockPlayerService)(nil).Refresh), player)
 }
 
+// Rename mocks base method.
+func (m *MockPlayerService) Rename(player *model.Player, name, color string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rename", player, name, color)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rename indicates an expected call of Rename.
+func (mr *MockPlayerServiceMockRecorder) Rename(player, name, color any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rename", reflect.TypeOf((*MockPlayerService)(nil).Rename), player, name, color)
+}
+
 // Update mocks base method.
 func (m *MockPlayerService) Update(player *model.Player) error {
 	m.ctrl.T.Helper()
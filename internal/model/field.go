@@ -6,6 +6,10 @@ import (
 	"github.com/google/uuid"
 )
 
+// Append number of the fields placed when the game is created, so they never
+// belong to any play
+const InitialAppendNum = -1
+
 type Field struct {
 	ID         int64
 	CreateDate time.Time
@@ -7,11 +7,47 @@ import (
 )
 
 type Game struct {
-	UUID        uuid.UUID
-	CreateDate  time.Time
-	UpdateDate  time.Time
-	Turn        int
-	PointsToWin int64
+	UUID       uuid.UUID
+	CreateDate time.Time
+	UpdateDate time.Time
+	// Number of turns played
+	Turn int
+	// Player that makes the next play, invalid until the first turn passes
+	TurnPlayerUUID uuid.NullUUID
+	// When the current turn began, the clock of the current player runs from
+	// this moment
+	TurnStartDate time.Time
+	Rules         GameRules
+	// Zero until one of the players reaches the points to win or the game is
+	// forfeited
+	FinishDate time.Time
+	// Player that lost the game by not playing for too long, invalid otherwise
+	ForfeitPlayerUUID uuid.NullUUID
+}
+
+func (g *Game) IsFinished() bool {
+	return !g.FinishDate.IsZero()
+}
+
+// Time the player has left in the current turn at 'now', the shorter of the
+// turn limit and the bank of the player. 'limited' is false when the game has
+// no clock.
+func (g *Game) TurnTimeLeft(
+	player *Player,
+	now time.Time,
+) (left time.Duration, limited bool) {
+	elapsed := now.Sub(g.TurnStartDate)
+	if g.Rules.TimeControl > 0 {
+		left, limited = g.Rules.TimeControl-elapsed, true
+	}
+	if g.Rules.TimeBank > 0 {
+		bankLeft := player.TimeLeft - elapsed
+		if !limited || bankLeft < left {
+			left = bankLeft
+		}
+		limited = true
+	}
+	return max(left, 0), limited
 }
 
 var GameMigrationSQL = map[string]string{
@@ -25,3 +61,68 @@ CREATE TABLE IF NOT EXISTS games(
 );
 `,
 }
+
+// Columns added to games after the table was created, each list by the change
+// that introduced it. Games created before a change get the defaults, which
+// are the rules every game was played with until then.
+
+// Date the game was finished
+var GameFinishColumnsSQL = map[string][]string{
+	"sqlite3": {
+		"finish_date INTEGER NOT NULL DEFAULT 0",
+	},
+}
+
+// Rule variants chosen on creation
+var GameRulesColumnsSQL = map[string][]string{
+	"sqlite3": {
+		"board_size INTEGER NOT NULL DEFAULT 15",
+		"rack_size INTEGER NOT NULL DEFAULT 9",
+		"min_word_len INTEGER NOT NULL DEFAULT 3",
+		"time_control INTEGER NOT NULL DEFAULT 0",
+		"alphabet TEXT NOT NULL DEFAULT 'ABCDEFGHIJKLMNOPQRSTUVWXYZ'",
+	},
+}
+
+// Language pack of the game
+var GameLanguageColumnsSQL = map[string][]string{
+	"sqlite3": {
+		"language TEXT NOT NULL DEFAULT 'en'",
+	},
+}
+
+// Challenge mode and turn order
+var GameChallengeColumnsSQL = map[string][]string{
+	"sqlite3": {
+		"challenge_mode INTEGER NOT NULL DEFAULT 0",
+		"turn_player_uuid BLOB",
+	},
+}
+
+// Time banks and the start of the running turn
+var GameClockColumnsSQL = map[string][]string{
+	"sqlite3": {
+		"time_bank INTEGER NOT NULL DEFAULT 0",
+		"time_increment INTEGER NOT NULL DEFAULT 0",
+		"turn_start_date INTEGER NOT NULL DEFAULT 0",
+	},
+}
+
+// Time control, bank and increment were stored in seconds, which dropped
+// fractions of a second. They are stored in milliseconds like the clocks.
+var GameClockMillisecondsSQL = map[string]string{
+	"sqlite3": `-- Game clock milliseconds
+UPDATE games SET
+    time_control = time_control * 1000,
+    time_bank = time_bank * 1000,
+    time_increment = time_increment * 1000;
+`,
+}
+
+// Correspondence games and forfeits
+var GameCorrespondenceColumnsSQL = map[string][]string{
+	"sqlite3": {
+		"correspondence INTEGER NOT NULL DEFAULT 0",
+		"forfeit_player_uuid BLOB",
+	},
+}
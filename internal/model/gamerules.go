@@ -0,0 +1,130 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	MinBoardSize = 5
+	MaxBoardSize = 21
+	MaxRackSize  = 20
+	// Shortest word that the minimum word length of a game can be set to
+	ShortestWordLen = 2
+)
+
+// Rules chosen when the game is created, stored with the game
+type GameRules struct {
+	// Number of fields along every edge of the cube
+	BoardSize int
+	// Number of available characters every player holds
+	RackSize    int
+	MinWordLen  int
+	PointsToWin int64
+	// Time for a single turn, zero without limit
+	TimeControl time.Duration
+	// Time every player has for the whole game, zero without limit
+	TimeBank time.Duration
+	// Time added to the bank of the player after every turn
+	TimeIncrement time.Duration
+	// Words are accepted provisionally and checked only when an opponent
+	// challenges them before the next play
+	ChallengeMode bool
+	// Turns are played over days from the game page without websocket,
+	// players are notified when it is their turn
+	Correspondence bool
+	// Code of the language pack used for the dictionary, letter values and
+	// tiles
+	Language string
+	// Characters that can be placed on the board, taken from the language pack
+	Alphabet string
+}
+
+func (r *GameRules) Validate() error {
+	var errs []error
+	if r.BoardSize < MinBoardSize || r.BoardSize > MaxBoardSize {
+		errs = append(errs, fmt.Errorf(
+			"board size should be between %v and %v, not %v",
+			MinBoardSize, MaxBoardSize, r.BoardSize,
+		))
+	}
+	if r.RackSize < 1 || r.RackSize > MaxRackSize {
+		errs = append(errs, fmt.Errorf(
+			"rack size should be between 1 and %v, not %v",
+			MaxRackSize, r.RackSize,
+		))
+	}
+	if r.MinWordLen < ShortestWordLen || r.MinWordLen > r.BoardSize {
+		errs = append(errs, fmt.Errorf(
+			"minimum word length should be between %v and board size, not %v",
+			ShortestWordLen, r.MinWordLen,
+		))
+	}
+	if r.PointsToWin < 1 {
+		errs = append(errs, fmt.Errorf(
+			"points to win should be at least 1, not %v", r.PointsToWin,
+		))
+	}
+	if r.TimeControl < 0 {
+		errs = append(errs, errors.New("time control cannot be negative"))
+	}
+	if r.TimeBank < 0 {
+		errs = append(errs, errors.New("time bank cannot be negative"))
+	}
+	if r.TimeIncrement < 0 {
+		errs = append(errs, errors.New("time increment cannot be negative"))
+	}
+	if r.TimeIncrement > 0 && r.TimeBank == 0 {
+		errs = append(errs, errors.New("time increment needs a time bank"))
+	}
+	if r.Language == "" {
+		errs = append(errs, errors.New("language cannot be empty"))
+	}
+	if r.Alphabet == "" {
+		errs = append(errs, errors.New("alphabet cannot be empty"))
+	}
+	for i, char := range r.Alphabet {
+		if !unicode.IsUpper(char) {
+			errs = append(errs, fmt.Errorf(
+				"alphabet should contain only upper case letters, not %q", char,
+			))
+			break
+		}
+		if strings.ContainsRune(r.Alphabet[:i], char) {
+			errs = append(errs, fmt.Errorf("alphabet repeats %q", char))
+			break
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Checks if turns of the game are limited in time
+func (r *GameRules) HasClock() bool {
+	return r.TimeControl > 0 || r.TimeBank > 0
+}
+
+// Checks if the points win the game
+func (r *GameRules) IsWinning(points int64) bool {
+	return points >= r.PointsToWin
+}
+
+// Character placed in the middle of the board when the game starts
+func (r *GameRules) FirstChar() string {
+	for _, char := range r.Alphabet {
+		return string(char)
+	}
+	return ""
+}
+
+// Checks if every position lies within the cube
+func (r *GameRules) IsOnBoard(positions ...int) bool {
+	for _, pos := range positions {
+		if pos < 0 || pos >= r.BoardSize {
+			return false
+		}
+	}
+	return true
+}
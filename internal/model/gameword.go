@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// House rule word of a single game. Allowed words are accepted even when they
+// are missing from the language words list, blocked ones are always rejected.
+type GameWord struct {
+	ID         int64
+	CreateDate time.Time
+	UpdateDate time.Time
+	GameUUID   uuid.UUID
+	// Normalized lower case word
+	Word    string
+	Allowed bool
+}
+
+var GameWordMigrationSQL = map[string]string{
+	"sqlite3": `-- GameWord
+CREATE TABLE IF NOT EXISTS game_words(
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+	create_date INTEGER NOT NULL,
+	update_date INTEGER,
+    game_uuid BLOB NOT NULL,
+    word TEXT NOT NULL,
+    allowed INTEGER NOT NULL,
+    UNIQUE (game_uuid, word),
+    FOREIGN KEY (game_uuid) REFERENCES games(uuid) ON DELETE CASCADE
+);
+`,
+}
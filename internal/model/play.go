@@ -0,0 +1,49 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Word played by the player. Fields of the play share the AppendNum of the
+// player.
+type Play struct {
+	ID         int64
+	CreateDate time.Time
+	UpdateDate time.Time
+	GameUUID   uuid.UUID
+	PlayerUUID uuid.UUID
+	AppendNum  int
+	Word       string
+	Points     int64
+	// Word was not checked yet and can be challenged
+	Provisional bool
+	// Author asked opponents to take the play back
+	UndoRequested bool
+}
+
+var PlayMigrationSQL = map[string]string{
+	"sqlite3": `-- Play
+CREATE TABLE IF NOT EXISTS plays(
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+	create_date INTEGER NOT NULL,
+	update_date INTEGER,
+    game_uuid BLOB NOT NULL,
+    player_uuid BLOB NOT NULL,
+    append_num INTEGER NOT NULL,
+    word TEXT NOT NULL,
+    points INTEGER NOT NULL,
+    provisional INTEGER NOT NULL,
+    FOREIGN KEY (game_uuid) REFERENCES games(uuid) ON DELETE CASCADE,
+    FOREIGN KEY (player_uuid) REFERENCES players(uuid) ON DELETE CASCADE
+);
+`,
+}
+
+// Undo requests, added to plays after the table was created
+var PlayUndoColumnsSQL = map[string][]string{
+	"sqlite3": {
+		"undo_requested INTEGER NOT NULL DEFAULT 0",
+	},
+}
@@ -13,6 +13,16 @@ type Player struct {
 	GameUUID   uuid.UUID
 	Points     int64
 	Appends    int
+	// Display name chosen by the player when joining the game
+	Name string
+	// Avatar colour in #rrggbb notation
+	Color string
+	// Account of the player, invalid for anonymous players
+	UserUUID uuid.NullUUID
+	// Player lost the next turn after a failed challenge
+	SkipTurn bool
+	// Time left in the bank of the player, used when the game has a time bank
+	TimeLeft time.Duration
 }
 
 var PlayerMigrationSQL = map[string]string{
@@ -28,3 +38,35 @@ CREATE TABLE IF NOT EXISTS players(
 );
 `,
 }
+
+// Columns added to players after the table was created, each list by the
+// change that introduced it
+
+// Display name and colour
+var PlayerNameColumnsSQL = map[string][]string{
+	"sqlite3": {
+		"name TEXT NOT NULL DEFAULT ''",
+		"color TEXT NOT NULL DEFAULT ''",
+	},
+}
+
+// Account of the player
+var PlayerUserColumnsSQL = map[string][]string{
+	"sqlite3": {
+		"user_uuid BLOB REFERENCES users(uuid) ON DELETE SET NULL",
+	},
+}
+
+// Turn lost to a rejected challenge
+var PlayerSkipTurnColumnsSQL = map[string][]string{
+	"sqlite3": {
+		"skip_turn INTEGER NOT NULL DEFAULT 0",
+	},
+}
+
+// Time bank of the player
+var PlayerTimeLeftColumnsSQL = map[string][]string{
+	"sqlite3": {
+		"time_left INTEGER NOT NULL DEFAULT 0",
+	},
+}
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Session struct {
+	// SHA-256 of the token kept in the user's cookie, so a leaked database
+	// does not leak usable sessions
+	TokenHash  []byte
+	CreateDate time.Time
+	UpdateDate time.Time
+	UserUUID   uuid.UUID
+	ExpireDate time.Time
+}
+
+var SessionMigrationSQL = map[string]string{
+	"sqlite3": `-- Session
+CREATE TABLE IF NOT EXISTS sessions(
+    token_hash BLOB PRIMARY KEY,
+	create_date INTEGER NOT NULL,
+	update_date INTEGER,
+    user_uuid BLOB NOT NULL,
+    expire_date INTEGER NOT NULL,
+    FOREIGN KEY (user_uuid) REFERENCES users(uuid) ON DELETE CASCADE
+);
+`,
+}
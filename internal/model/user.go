@@ -0,0 +1,27 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type User struct {
+	UUID         uuid.UUID
+	CreateDate   time.Time
+	UpdateDate   time.Time
+	Login        string
+	PasswordHash []byte
+}
+
+var UserMigrationSQL = map[string]string{
+	"sqlite3": `-- User
+CREATE TABLE IF NOT EXISTS users(
+    uuid BLOB PRIMARY KEY,
+	create_date INTEGER NOT NULL,
+	update_date INTEGER,
+    login TEXT NOT NULL UNIQUE,
+    password_hash BLOB NOT NULL
+);
+`,
+}
@@ -0,0 +1,77 @@
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"scrable3/internal/model"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Event string
+
+const (
+	// It is the player's turn
+	EventTurn Event = "turn"
+	// The player lost the game by not playing for too long
+	EventForfeit Event = "forfeit"
+	// One of the players reached the points to win
+	EventWin Event = "win"
+)
+
+type Notification struct {
+	Event      Event     `json:"event"`
+	Date       time.Time `json:"date"`
+	GameUUID   uuid.UUID `json:"game_uuid"`
+	PlayerUUID uuid.UUID `json:"player_uuid"`
+	PlayerName string    `json:"player_name"`
+	Message    string    `json:"message"`
+}
+
+// Tells players about their correspondence games while they are away, e.g.
+// by mail or chat. Implementations are used from many goroutines.
+type Notifier interface {
+	Notify(n Notification) error
+}
+
+type logNotifier struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// Writes notifications as JSON lines, one per notification. Used with a local
+// file or standard output when there is no real delivery.
+func NewLogNotifier(w io.Writer) Notifier {
+	return &logNotifier{w: w}
+}
+
+func (n *logNotifier) Notify(notification Notification) error {
+	line, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	_, err = fmt.Fprintf(n.w, "%s\n", line)
+	return err
+}
+
+// Notification of the event in the game addressed to the player
+func New(
+	event Event,
+	game *model.Game,
+	player *model.Player,
+	message string,
+) Notification {
+	return Notification{
+		Event:      event,
+		Date:       time.Now(),
+		GameUUID:   game.UUID,
+		PlayerUUID: player.UUID,
+		PlayerName: player.Name,
+		Message:    message,
+	}
+}
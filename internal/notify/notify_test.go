@@ -0,0 +1,50 @@
+package notify
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"scrable3/internal/model"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestLogNotifier(t *testing.T) {
+	var buf bytes.Buffer
+	notifier := NewLogNotifier(&buf)
+	game := &model.Game{UUID: uuid.New()}
+	player := &model.Player{UUID: uuid.New(), Name: "Ala"}
+
+	sent := []Notification{
+		New(EventTurn, game, player, "your turn"),
+		New(EventForfeit, game, player, "game forfeited"),
+	}
+	for _, n := range sent {
+		if err := notifier.Notify(n); err != nil {
+			t.Fatalf("notify failed; %v", err)
+		}
+	}
+
+	scanner := bufio.NewScanner(&buf)
+	var got []Notification
+	for scanner.Scan() {
+		var n Notification
+		if err := json.Unmarshal(scanner.Bytes(), &n); err != nil {
+			t.Fatalf("line is not JSON; %v", err)
+		}
+		got = append(got, n)
+	}
+	if len(got) != len(sent) {
+		t.Fatalf("expected %v lines, got %v", len(sent), len(got))
+	}
+	for i := range sent {
+		if got[i].Event != sent[i].Event ||
+			got[i].GameUUID != game.UUID ||
+			got[i].PlayerUUID != player.UUID ||
+			got[i].PlayerName != "Ala" ||
+			got[i].Message != sent[i].Message {
+			t.Errorf("expected %+v, got %+v", sent[i], got[i])
+		}
+	}
+}
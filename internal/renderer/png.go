@@ -0,0 +1,162 @@
+package renderer
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Scale of the 5x7 font in the PNG, every font pixel is drawn as a square
+const (
+	letterScale = 3
+	titleScale  = 2
+)
+
+// Upper case letters of a 5x7 pixel font, one byte per row with the leftmost
+// pixel in the highest of five bits
+var glyphs = map[rune][7]uint8{
+	'A': {0b01110, 0b10001, 0b10001, 0b11111, 0b10001, 0b10001, 0b10001},
+	'B': {0b11110, 0b10001, 0b10001, 0b11110, 0b10001, 0b10001, 0b11110},
+	'C': {0b01110, 0b10001, 0b10000, 0b10000, 0b10000, 0b10001, 0b01110},
+	'D': {0b11110, 0b10001, 0b10001, 0b10001, 0b10001, 0b10001, 0b11110},
+	'E': {0b11111, 0b10000, 0b10000, 0b11110, 0b10000, 0b10000, 0b11111},
+	'F': {0b11111, 0b10000, 0b10000, 0b11110, 0b10000, 0b10000, 0b10000},
+	'G': {0b01110, 0b10001, 0b10000, 0b10111, 0b10001, 0b10001, 0b01111},
+	'H': {0b10001, 0b10001, 0b10001, 0b11111, 0b10001, 0b10001, 0b10001},
+	'I': {0b01110, 0b00100, 0b00100, 0b00100, 0b00100, 0b00100, 0b01110},
+	'J': {0b00111, 0b00010, 0b00010, 0b00010, 0b00010, 0b10010, 0b01100},
+	'K': {0b10001, 0b10010, 0b10100, 0b11000, 0b10100, 0b10010, 0b10001},
+	'L': {0b10000, 0b10000, 0b10000, 0b10000, 0b10000, 0b10000, 0b11111},
+	'M': {0b10001, 0b11011, 0b10101, 0b10101, 0b10001, 0b10001, 0b10001},
+	'N': {0b10001, 0b10001, 0b11001, 0b10101, 0b10011, 0b10001, 0b10001},
+	'O': {0b01110, 0b10001, 0b10001, 0b10001, 0b10001, 0b10001, 0b01110},
+	'P': {0b11110, 0b10001, 0b10001, 0b11110, 0b10000, 0b10000, 0b10000},
+	'Q': {0b01110, 0b10001, 0b10001, 0b10001, 0b10101, 0b10010, 0b01101},
+	'R': {0b11110, 0b10001, 0b10001, 0b11110, 0b10100, 0b10010, 0b10001},
+	'S': {0b01111, 0b10000, 0b10000, 0b01110, 0b00001, 0b00001, 0b11110},
+	'T': {0b11111, 0b00100, 0b00100, 0b00100, 0b00100, 0b00100, 0b00100},
+	'U': {0b10001, 0b10001, 0b10001, 0b10001, 0b10001, 0b10001, 0b01110},
+	'V': {0b10001, 0b10001, 0b10001, 0b10001, 0b10001, 0b01010, 0b00100},
+	'W': {0b10001, 0b10001, 0b10001, 0b10101, 0b10101, 0b10101, 0b01010},
+	'X': {0b10001, 0b10001, 0b01010, 0b00100, 0b01010, 0b10001, 0b10001},
+	'Y': {0b10001, 0b10001, 0b01010, 0b00100, 0b00100, 0b00100, 0b00100},
+	'Z': {0b11111, 0b00001, 0b00010, 0b00100, 0b01000, 0b10000, 0b11111},
+}
+
+// Drawn for letters the font does not have
+var unknownGlyph = [7]uint8{0b11111, 0b10001, 0b10001, 0b10001, 0b10001, 0b10001, 0b11111}
+
+// Letters with a stroke have no decomposition, they are drawn as the base
+// letter with a mark like letters with diacritics
+var strokeLetters = map[rune]rune{'Ł': 'L', 'Ø': 'O', 'Đ': 'D', 'Ħ': 'H'}
+
+// |PRIVATE| //
+
+// Parses #rrggbb, gray is returned for anything else
+func parseColor(hex string, alpha uint8) color.NRGBA {
+	value, err := strconv.ParseUint(strings.TrimPrefix(hex, "#"), 16, 32)
+	if len(hex) != 7 || err != nil {
+		return color.NRGBA{0x80, 0x80, 0x80, alpha}
+	}
+	return color.NRGBA{uint8(value >> 16), uint8(value >> 8), uint8(value), alpha}
+}
+
+func fillRect(img draw.Image, rect image.Rectangle, c color.Color) {
+	draw.Draw(img, rect, image.NewUniform(c), image.Point{}, draw.Over)
+}
+
+// Draws a frame of the given width inside the rectangle
+func strokeRect(img draw.Image, rect image.Rectangle, width int, c color.Color) {
+	fillRect(img, image.Rect(rect.Min.X, rect.Min.Y, rect.Max.X, rect.Min.Y+width), c)
+	fillRect(img, image.Rect(rect.Min.X, rect.Max.Y-width, rect.Max.X, rect.Max.Y), c)
+	fillRect(img, image.Rect(rect.Min.X, rect.Min.Y+width, rect.Min.X+width, rect.Max.Y-width), c)
+	fillRect(img, image.Rect(rect.Max.X-width, rect.Min.Y+width, rect.Max.X, rect.Max.Y-width), c)
+}
+
+// Glyph of the letter and whether it carries a diacritic mark
+func glyphOf(letter rune) ([7]uint8, bool) {
+	letter = unicode.ToUpper(letter)
+	if glyph, ok := glyphs[letter]; ok {
+		return glyph, false
+	}
+	if base, ok := strokeLetters[letter]; ok {
+		return glyphs[base], true
+	}
+	// Letters with diacritics decompose into the base letter and marks
+	decomposed := []rune(norm.NFD.String(string(letter)))
+	if glyph, ok := glyphs[decomposed[0]]; ok {
+		return glyph, len(decomposed) > 1
+	}
+	return unknownGlyph, false
+}
+
+// Draws the text with its top left corner at 'origin', returns its width
+func drawText(img draw.Image, origin image.Point, text string, scale int, c color.Color) int {
+	x := origin.X
+	for _, letter := range text {
+		glyph, marked := glyphOf(letter)
+		for row, bits := range glyph {
+			for column := range 5 {
+				if bits&(1<<(4-column)) == 0 {
+					continue
+				}
+				min := image.Pt(x+column*scale, origin.Y+row*scale)
+				fillRect(img, image.Rectangle{min, min.Add(image.Pt(scale, scale))}, c)
+			}
+		}
+		if marked {
+			// Short bar above the letter stands for any diacritic
+			min := image.Pt(x+3*scale, origin.Y-2*scale)
+			fillRect(img, image.Rectangle{min, min.Add(image.Pt(2*scale, scale))}, c)
+		}
+		x += 6 * scale
+	}
+	return x - origin.X - scale
+}
+
+// |PUBLIC| //
+
+// Rasterizes the snapshot. Letters use a built in pixel font, so the picture
+// is close to the SVG without any font files.
+func (s *Snapshot) WritePNG(w io.Writer) error {
+	img := image.NewNRGBA(image.Rect(0, 0, s.width(), s.height()))
+	fillRect(img, img.Bounds(), parseColor(backgroundColor, 0xff))
+	faceColor := parseColor(faceColors[s.side], 0xff)
+	drawText(img, image.Pt(margin, margin+4), strings.ToUpper(SideName(s.side)), titleScale, faceColor)
+
+	x, y := s.cellOrigin(0, 0)
+	fillRect(img, image.Rect(x, y, x+s.size*cellSize, y+s.size*cellSize), faceColor)
+	gridColor := color.NRGBA{0, 0, 0, 0x26}
+	for i := 1; i < s.size; i++ {
+		fillRect(img, image.Rect(x+i*cellSize, y, x+i*cellSize+1, y+s.size*cellSize), gridColor)
+		fillRect(img, image.Rect(x, y+i*cellSize, x+s.size*cellSize, y+i*cellSize+1), gridColor)
+	}
+
+	for _, tile := range s.tiles {
+		x, y := s.cellOrigin(tile.column, tile.row)
+		alpha := uint8(s.opacity(tile.depth) * 0xff)
+		tileRect := image.Rect(
+			x+tilePadding, y+tilePadding,
+			x+cellSize-tilePadding, y+cellSize-tilePadding,
+		)
+		fillRect(img, tileRect, parseColor(tileColor, alpha))
+		strokeRect(img, tileRect, 3, parseColor(tile.color, alpha))
+		if tile.latest {
+			strokeRect(img, image.Rect(x+1, y+1, x+cellSize-1, y+cellSize-1), 2,
+				parseColor(latestColor, alpha))
+		}
+		textWidth := 5 * letterScale
+		textHeight := 7 * letterScale
+		drawText(img,
+			image.Pt(x+(cellSize-textWidth)/2, y+(cellSize-textHeight)/2),
+			tile.value, letterScale, parseColor(letterColor, alpha))
+	}
+	return png.Encode(w, img)
+}
@@ -0,0 +1,178 @@
+package renderer
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"scrable3/internal/board"
+	"scrable3/internal/model"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Layout of the snapshot in pixels, shared by SVG and PNG
+const (
+	cellSize    = 40
+	tilePadding = 3
+	margin      = 20
+	titleHeight = 30
+	// Tiles at the far side of the cube are drawn this transparent
+	farOpacity = 0.35
+)
+
+const (
+	backgroundColor = "#000000"
+	tileColor       = "#fffdf5"
+	letterColor     = "#1a1a1a"
+	latestColor     = "#ef3d59"
+	// Border of tiles placed by players that are gone
+	defaultPlayerColor = "#808080"
+)
+
+// Colours of the faces, the same as on the game page
+var faceColors = map[int]string{
+	0:                "#ACE7FF",
+	90:               "#FBE4FF",
+	180:              "#BFFCC6",
+	270:              "#FFCBC1",
+	board.SideTop:    "#FFF5BA",
+	board.SideBottom: "#D5D5D5",
+}
+
+// Tile seen on the face, with the depth it lies at
+type snapshotTile struct {
+	column int
+	row    int
+	depth  int
+	value  string
+	color  string
+	latest bool
+}
+
+// Picture of the board seen from one side. Tiles nearest to the face are
+// drawn, the deeper they lie the more transparent they are.
+type Snapshot struct {
+	size  int
+	side  int
+	tiles []snapshotTile
+}
+
+// Creates the snapshot of the fields seen from the side. 'colors' maps players
+// to the colours their tiles are framed with.
+func NewSnapshot(
+	size int,
+	fields []model.Field,
+	side int,
+	colors map[uuid.UUID]string,
+) (*Snapshot, error) {
+	if !slices.Contains(Sides, side) {
+		return nil, fmt.Errorf("side %v is not one of %v", side, Sides)
+	}
+	b, err := board.FromFields(size, fields)
+	if err != nil {
+		return nil, err
+	}
+	owners := make(map[board.Pos]uuid.UUID, len(fields))
+	for _, field := range fields {
+		owners[board.Pos{field.PosX, field.PosY, field.PosZ}] = field.PlayerUUID
+	}
+	latest := latestPlay(fields)
+
+	s := &Snapshot{size: size, side: side}
+	for _, pos := range b.Surface(side) {
+		column, row, depth := board.Project(size, side, pos)
+		color, ok := colors[owners[pos]]
+		if !ok {
+			color = defaultPlayerColor
+		}
+		s.tiles = append(s.tiles, snapshotTile{
+			column: column,
+			row:    row,
+			depth:  depth,
+			value:  b.At(pos),
+			color:  color,
+			latest: latest[pos],
+		})
+	}
+	// Map order is random, a stable order keeps the output the same
+	slices.SortFunc(s.tiles, func(a, b snapshotTile) int {
+		if a.row != b.row {
+			return a.row - b.row
+		}
+		return a.column - b.column
+	})
+	return s, nil
+}
+
+// |PRIVATE| //
+
+func (s *Snapshot) width() int {
+	return 2*margin + s.size*cellSize
+}
+
+func (s *Snapshot) height() int {
+	return 2*margin + titleHeight + s.size*cellSize
+}
+
+// Top left corner of the cell in pixels
+func (s *Snapshot) cellOrigin(column int, row int) (x int, y int) {
+	return margin + column*cellSize, margin + titleHeight + row*cellSize
+}
+
+func (s *Snapshot) opacity(depth int) float64 {
+	if s.size < 2 {
+		return 1
+	}
+	return 1 - (1-farOpacity)*float64(depth)/float64(s.size-1)
+}
+
+// |PUBLIC| //
+
+func (s *Snapshot) WriteSVG(w io.Writer) error {
+	var sb strings.Builder
+	width, height := s.width(), s.height()
+	fmt.Fprintf(&sb,
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%v" height="%v" viewBox="0 0 %v %v">`+"\n",
+		width, height, width, height)
+	fmt.Fprintf(&sb, `<rect width="%v" height="%v" fill="%v"/>`+"\n",
+		width, height, backgroundColor)
+	fmt.Fprintf(&sb,
+		`<text x="%v" y="%v" fill="%v" font-family="sans-serif" font-size="20">%v</text>`+"\n",
+		margin, margin+titleHeight-10, faceColors[s.side], SideName(s.side))
+
+	x, y := s.cellOrigin(0, 0)
+	fmt.Fprintf(&sb, `<rect x="%v" y="%v" width="%v" height="%v" fill="%v"/>`+"\n",
+		x, y, s.size*cellSize, s.size*cellSize, faceColors[s.side])
+	for i := 1; i < s.size; i++ {
+		fmt.Fprintf(&sb,
+			`<path d="M%v %vv%vM%v %vh%v" stroke="%v" stroke-opacity="0.15"/>`+"\n",
+			x+i*cellSize, y, s.size*cellSize,
+			x, y+i*cellSize, s.size*cellSize,
+			backgroundColor)
+	}
+
+	tileSize := cellSize - 2*tilePadding
+	for _, tile := range s.tiles {
+		x, y := s.cellOrigin(tile.column, tile.row)
+		fmt.Fprintf(&sb, `<g opacity="%.2f">`, s.opacity(tile.depth))
+		fmt.Fprintf(&sb,
+			`<rect x="%v" y="%v" width="%v" height="%v" rx="4" fill="%v" stroke="%v" stroke-width="3"/>`,
+			x+tilePadding, y+tilePadding, tileSize, tileSize,
+			tileColor, html.EscapeString(tile.color))
+		if tile.latest {
+			fmt.Fprintf(&sb,
+				`<rect x="%v" y="%v" width="%v" height="%v" rx="6" fill="none" stroke="%v" stroke-width="2"/>`,
+				x+1, y+1, cellSize-2, cellSize-2, latestColor)
+		}
+		fmt.Fprintf(&sb,
+			`<text x="%v" y="%v" fill="%v" font-family="sans-serif" font-size="22" font-weight="bold" text-anchor="middle" dominant-baseline="central">%v</text>`,
+			x+cellSize/2, y+cellSize/2, letterColor, html.EscapeString(tile.value))
+		sb.WriteString("</g>\n")
+	}
+	sb.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
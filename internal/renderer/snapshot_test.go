@@ -0,0 +1,95 @@
+package renderer
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestSnapshotSVG(t *testing.T) {
+	fields := setupFields()
+	colors := map[uuid.UUID]string{fields[0].PlayerUUID: "#3f88c5"}
+	snapshot, err := NewSnapshot(3, fields, 90, colors)
+	if err != nil {
+		t.Fatalf("creating snapshot failed; %v", err)
+	}
+	var buf bytes.Buffer
+	if err := snapshot.WriteSVG(&buf); err != nil {
+		t.Fatalf("writing SVG failed; %v", err)
+	}
+	svg := buf.String()
+
+	// From the east L hides the A played behind it, the initial A stays visible
+	if count := strings.Count(svg, "<g "); count != 2 {
+		t.Errorf("expected 2 tiles, got %v in\n%v", count, svg)
+	}
+	for _, part := range []string{">east</text>", `stroke="#3f88c5"`, `stroke="` + latestColor + `"`} {
+		if !strings.Contains(svg, part) {
+			t.Errorf("missing %q in\n%v", part, svg)
+		}
+	}
+
+	if _, err := NewSnapshot(3, fields, 45, colors); err == nil {
+		t.Error("expected error for unknown side, got nil")
+	}
+}
+
+func TestSnapshotPNG(t *testing.T) {
+	snapshot, err := NewSnapshot(3, setupFields(), 0, nil)
+	if err != nil {
+		t.Fatalf("creating snapshot failed; %v", err)
+	}
+	var buf bytes.Buffer
+	if err := snapshot.WritePNG(&buf); err != nil {
+		t.Fatalf("writing PNG failed; %v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("decoding PNG failed; %v", err)
+	}
+	if img.Bounds().Dx() != snapshot.width() || img.Bounds().Dy() != snapshot.height() {
+		t.Errorf("expected %vx%v, got %v", snapshot.width(), snapshot.height(), img.Bounds())
+	}
+
+	// Corner of the tile inside the frame is the tile colour, the empty cell
+	// next to it is the colour of the face
+	x, y := snapshot.cellOrigin(0, 1)
+	expected := parseColor(tileColor, 0xff)
+	if r, g, b, _ := img.At(x+7, y+7).RGBA(); r>>8 != uint32(expected.R) ||
+		g>>8 != uint32(expected.G) || b>>8 != uint32(expected.B) {
+		t.Errorf("expected tile colour %v, got %v", expected, img.At(x+7, y+7))
+	}
+	x, y = snapshot.cellOrigin(2, 1)
+	expected = parseColor(faceColors[0], 0xff)
+	if r, g, b, _ := img.At(x+7, y+7).RGBA(); r>>8 != uint32(expected.R) ||
+		g>>8 != uint32(expected.G) || b>>8 != uint32(expected.B) {
+		t.Errorf("expected face colour %v, got %v", expected, img.At(x+7, y+7))
+	}
+}
+
+func TestGlyphOf(t *testing.T) {
+	testCases := []struct {
+		letter rune
+		base   rune
+		marked bool
+	}{
+		{'A', 'A', false},
+		{'a', 'A', false},
+		{'Ą', 'A', true},
+		{'Ż', 'Z', true},
+		{'Ł', 'L', true},
+	}
+	for _, tc := range testCases {
+		glyph, marked := glyphOf(tc.letter)
+		if glyph != glyphs[tc.base] || marked != tc.marked {
+			t.Errorf("%q; expected glyph of %q marked %v, got marked %v",
+				tc.letter, tc.base, tc.marked, marked)
+		}
+	}
+	if glyph, _ := glyphOf('Ж'); glyph != unknownGlyph {
+		t.Error("expected unknown glyph for letter out of the font")
+	}
+}
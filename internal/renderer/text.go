@@ -0,0 +1,177 @@
+package renderer
+
+import (
+	"fmt"
+	"scrable3/internal/board"
+	"scrable3/internal/model"
+	"strings"
+)
+
+// Sides the board is seen from, in the order they are written
+var Sides = []int{0, 90, 180, 270, board.SideTop, board.SideBottom}
+
+// Text view of the board for logs, test failures and tools. Every cell is
+// three characters wide, letters of the latest play are put in brackets.
+//
+//	north
+//	    0  1  2
+//	 0  .  .  .
+//	 1  L [A] .
+//	 2  .  .  .
+type Text struct {
+	board  *board.Board
+	latest map[board.Pos]bool
+}
+
+func NewText(size int, fields []model.Field) (*Text, error) {
+	b, err := board.FromFields(size, fields)
+	if err != nil {
+		return nil, err
+	}
+	return &Text{board: b, latest: latestPlay(fields)}, nil
+}
+
+// |PRIVATE| //
+
+// Positions of the fields placed together with the newest one. Fields placed
+// when the game was created and ones not stored yet belong to no play.
+func latestPlay(fields []model.Field) map[board.Pos]bool {
+	var newest *model.Field
+	for i, field := range fields {
+		if field.ID <= 0 || field.AppendNum == model.InitialAppendNum {
+			continue
+		}
+		if newest == nil || field.ID > newest.ID {
+			newest = &fields[i]
+		}
+	}
+	latest := make(map[board.Pos]bool)
+	if newest == nil {
+		return latest
+	}
+	for _, field := range fields {
+		if field.PlayerUUID == newest.PlayerUUID && field.AppendNum == newest.AppendNum {
+			latest[board.Pos{field.PosX, field.PosY, field.PosZ}] = true
+		}
+	}
+	return latest
+}
+
+func (t *Text) cell(pos board.Pos, seen bool) string {
+	if !seen || !t.board.Occupied(pos) {
+		return " . "
+	}
+	if t.latest[pos] {
+		return "[" + t.board.At(pos) + "]"
+	}
+	return " " + t.board.At(pos) + " "
+}
+
+func (t *Text) layerHasTiles(z int) bool {
+	for x := range t.board.Size() {
+		for y := range t.board.Size() {
+			if t.board.Occupied(board.Pos{x, y, z}) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Writes the grid with column numbers above and row numbers on the left.
+// 'at' returns the position shown on the column and row, false for none.
+func (t *Text) writeGrid(
+	sb *strings.Builder,
+	title string,
+	at func(column int, row int) (board.Pos, bool),
+) {
+	size := t.board.Size()
+	header := "   "
+	for column := range size {
+		header += fmt.Sprintf("%2d ", column)
+	}
+	sb.WriteString(title + "\n" + strings.TrimRight(header, " ") + "\n")
+	for row := range size {
+		line := fmt.Sprintf("%2d ", row)
+		for column := range size {
+			pos, seen := at(column, row)
+			line += t.cell(pos, seen)
+		}
+		sb.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+}
+
+// |PUBLIC| //
+
+func SideName(side int) string {
+	switch side {
+	case 0:
+		return "north"
+	case 90:
+		return "east"
+	case 180:
+		return "south"
+	case 270:
+		return "west"
+	case board.SideTop:
+		return "top"
+	case board.SideBottom:
+		return "bottom"
+	default:
+		return fmt.Sprintf("side %v", side)
+	}
+}
+
+// Tiles seen from the side, columns and rows as in board.Project
+func (t *Text) Face(side int) string {
+	surface := t.board.Surface(side)
+	var sb strings.Builder
+	t.writeGrid(&sb, SideName(side), func(column int, row int) (board.Pos, bool) {
+		pos, ok := surface[[2]int{column, row}]
+		return pos, ok
+	})
+	return sb.String()
+}
+
+// Slice of the cube at depth 'z' seen from the north, columns along Y and
+// rows along X
+func (t *Text) Layer(z int) string {
+	var sb strings.Builder
+	t.writeGrid(&sb, fmt.Sprintf("z=%v", z), func(column int, row int) (board.Pos, bool) {
+		return board.Pos{row, column, z}, true
+	})
+	return sb.String()
+}
+
+// Faces from every side followed by the layers holding tiles
+func (t *Text) String() string {
+	var parts []string
+	for _, side := range Sides {
+		parts = append(parts, t.Face(side))
+	}
+	for z := range t.board.Size() {
+		if t.layerHasTiles(z) {
+			parts = append(parts, t.Layer(z))
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// Text view of the fields, or the error when they do not fit on the board.
+// Meant for log and test messages, where the view is only a help.
+func Fields(size int, fields []model.Field) string {
+	text, err := NewText(size, fields)
+	if err != nil {
+		return fmt.Sprintf("fields cannot be rendered; %v", err)
+	}
+	return text.String()
+}
+
+// Like Fields, but only the face seen from the side
+func FieldsFace(size int, fields []model.Field, side int) string {
+	text, err := NewText(size, fields)
+	if err != nil {
+		return fmt.Sprintf("fields cannot be rendered; %v", err)
+	}
+	return text.Face(side)
+}
@@ -0,0 +1,75 @@
+package renderer
+
+import (
+	"scrable3/internal/board"
+	"scrable3/internal/model"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// Board of size 3 with the initial A in the middle and LA played in front of
+// it along Y
+func setupFields() []model.Field {
+	player := uuid.New()
+	return []model.Field{
+		{ID: 1, PlayerUUID: player, AppendNum: model.InitialAppendNum, Value: "A", PosX: 1, PosY: 1, PosZ: 1},
+		{ID: 2, PlayerUUID: player, AppendNum: 0, Value: "L", PosX: 1, PosY: 0, PosZ: 0},
+		{ID: 3, PlayerUUID: player, AppendNum: 0, Value: "A", PosX: 1, PosY: 1, PosZ: 0},
+	}
+}
+
+func TestFace(t *testing.T) {
+	text, err := NewText(3, setupFields())
+	if err != nil {
+		t.Fatalf("creating view failed; %v", err)
+	}
+	testCases := []struct {
+		side     int
+		expected string
+	}{
+		{0, `north
+    0  1  2
+ 0  .  .  .
+ 1 [L][A] .
+ 2  .  .  .
+`},
+		{90, `east
+    0  1  2
+ 0  .  .  .
+ 1  .  A [L]
+ 2  .  .  .
+`},
+		{board.SideTop, `top
+    0  1  2
+ 0  .  .  .
+ 1  .  A  .
+ 2 [L][A] .
+`},
+	}
+	for _, tc := range testCases {
+		t.Run(SideName(tc.side), func(t *testing.T) {
+			if face := text.Face(tc.side); face != tc.expected {
+				t.Errorf("expected\n%v\ngot\n%v", tc.expected, face)
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	view := Fields(3, setupFields())
+	for _, title := range []string{"north\n", "bottom\n", "z=0\n", "z=1\n"} {
+		if !strings.Contains(view, title) {
+			t.Errorf("missing %q in\n%v", title, view)
+		}
+	}
+	if strings.Contains(view, "z=2\n") {
+		t.Errorf("empty layer written in\n%v", view)
+	}
+
+	broken := append(setupFields(), model.Field{ID: 4, Value: "X", PosX: 1, PosY: 1, PosZ: 1})
+	if view := Fields(3, broken); !strings.HasPrefix(view, "fields cannot be rendered") {
+		t.Errorf("expected error for overlapping fields, got\n%v", view)
+	}
+}
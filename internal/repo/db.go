@@ -3,6 +3,7 @@ package repo
 import "database/sql"
 
 type DB interface {
+	Begin() (*sql.Tx, error)
 	Close() error
 	Query(query string, args ...interface{}) (*sql.Rows, error)
 	QueryRow(query string, args ...interface{}) *sql.Row
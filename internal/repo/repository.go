@@ -13,17 +13,44 @@ type Repository interface {
 	InsertGame(game *model.Game) error
 	UpdateGame(game *model.Game) error
 	SelectGameByUUID(gameUUID uuid.UUID) (*model.Game, error)
+	SelectGamesByUserID(userUUID uuid.UUID) (*[]model.Game, error)
+	SelectUnfinishedGames() (*[]model.Game, error)
+	SelectGames() (*[]model.Game, error)
 	DeleteGame(game *model.Game) error
 
 	InsertPlayer(player *model.Player) error
 	SelectPlayerByUUID(playerUUID uuid.UUID) (*model.Player, error)
+	SelectPlayersByGameID(gameUUID uuid.UUID) (*[]model.Player, error)
+	SelectPlayerByGameAndUserID(
+		gameUUID uuid.UUID,
+		userUUID uuid.UUID,
+	) (*model.Player, error)
 	UpdatePlayer(updatedPlayer *model.Player) error
 
+	InsertUser(user *model.User) error
+	SelectUserByUUID(userUUID uuid.UUID) (*model.User, error)
+	SelectUserByLogin(login string) (*model.User, error)
+
+	InsertSession(session *model.Session) error
+	SelectSessionByTokenHash(tokenHash []byte) (*model.Session, error)
+	DeleteSession(session *model.Session) error
+
 	InsertField(field *model.Field) error
 	SelectFieldsByGameID(gameUUID uuid.UUID) (*[]model.Field, error)
 	DeleteField(field *model.Field) error
 
 	InsertAvChar(avChar *model.AvChar) error
 	SelectAvCharsByPlayerID(playerUUID uuid.UUID) (*[]model.AvChar, error)
+	SelectAvCharsByGameID(gameUUID uuid.UUID) (*[]model.AvChar, error)
 	DeleteAvCharByID(avCharID int64) error
+
+	InsertGameWord(gameWord *model.GameWord) error
+	SelectGameWordsByGameID(gameUUID uuid.UUID) (*[]model.GameWord, error)
+	DeleteGameWord(gameWord *model.GameWord) error
+
+	InsertPlay(play *model.Play) error
+	SelectLastPlayByGameID(gameUUID uuid.UUID) (*model.Play, error)
+	SelectPlaysByGameID(gameUUID uuid.UUID) (*[]model.Play, error)
+	UpdatePlay(play *model.Play) error
+	DeletePlay(play *model.Play) error
 }
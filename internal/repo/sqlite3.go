@@ -4,7 +4,11 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"log/slog"
+	"scrable3/internal/logging"
+	"scrable3/internal/metrics"
 	"scrable3/internal/model"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,13 +16,19 @@ import (
 )
 
 type sqlite3Repository struct {
-	db DB
+	db      DB
+	logger  *slog.Logger
+	metrics *metrics.Metrics
 }
 
 // TODO dodaj daty do wszyskiego, create, update w sqlite trzymaj date w int
 
-func NewSqlite3Connection(db DB) (Repository, error) {
-	repo := &sqlite3Repository{db: db}
+func NewSqlite3Connection(
+	db DB,
+	logger *slog.Logger,
+	metrics *metrics.Metrics,
+) (Repository, error) {
+	repo := &sqlite3Repository{db: db, logger: logger, metrics: metrics}
 	err := repo.Migrate()
 	if err != nil {
 		return nil, err
@@ -47,21 +57,358 @@ func (repo *sqlite3Repository) checkSqlErr(err error) error {
 	return err
 }
 
+// Common interface of *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// Dates that are not set yet are stored as 0
+func (repo *sqlite3Repository) unixOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func (repo *sqlite3Repository) unixMilliOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+func (repo *sqlite3Repository) timeOrZero(unix int64) time.Time {
+	if unix == 0 {
+		return time.Time{}
+	}
+	return time.Unix(unix, 0)
+}
+
+// Columns in the order rows are scanned. Migrations add columns at the end of
+// tables, so rows are never selected with *.
+const (
+	gameColumns = `uuid, create_date, update_date, turn, points_to_win,
+		finish_date, board_size, rack_size, min_word_len, time_control,
+		time_bank, time_increment, challenge_mode, language, alphabet,
+		turn_player_uuid, turn_start_date, correspondence, forfeit_player_uuid`
+	playerColumns = `uuid, create_date, update_date, game_uuid, points, appends,
+		name, color, user_uuid, skip_turn, time_left`
+	userColumns    = "uuid, create_date, update_date, login, password_hash"
+	sessionColumns = "token_hash, create_date, update_date, user_uuid, expire_date"
+	fieldColumns   = `id, create_date, update_date, game_uuid, player_uuid,
+		append_num, val, pos_x, pos_y, pos_z`
+	avCharColumns   = "id, create_date, update_date, player_uuid, val"
+	gameWordColumns = "id, create_date, update_date, game_uuid, word, allowed"
+	playColumns     = `id, create_date, update_date, game_uuid, player_uuid,
+		append_num, word, points, provisional, undo_requested`
+)
+
+func (repo *sqlite3Repository) scanGame(row rowScanner) (*model.Game, error) {
+	var game model.Game
+	var createDate int64
+	var updateDate int64
+	var finishDate int64
+	var timeControl int64
+	var timeBank int64
+	var timeIncrement int64
+	var turnStartDate int64
+	err := row.Scan(
+		&game.UUID, &createDate, &updateDate, &game.Turn,
+		&game.Rules.PointsToWin, &finishDate, &game.Rules.BoardSize,
+		&game.Rules.RackSize, &game.Rules.MinWordLen, &timeControl,
+		&timeBank, &timeIncrement,
+		&game.Rules.ChallengeMode, &game.Rules.Language, &game.Rules.Alphabet,
+		&game.TurnPlayerUUID, &turnStartDate, &game.Rules.Correspondence,
+		&game.ForfeitPlayerUUID,
+	)
+	game.CreateDate = time.Unix(createDate, 0)
+	game.UpdateDate = time.Unix(updateDate, 0)
+	game.FinishDate = repo.timeOrZero(finishDate)
+	// Clocks need better precision than seconds, times are stored in
+	// milliseconds
+	game.Rules.TimeControl = time.Duration(timeControl) * time.Millisecond
+	game.Rules.TimeBank = time.Duration(timeBank) * time.Millisecond
+	game.Rules.TimeIncrement = time.Duration(timeIncrement) * time.Millisecond
+	if turnStartDate != 0 {
+		game.TurnStartDate = time.UnixMilli(turnStartDate)
+	}
+	return &game, repo.checkSqlErr(err)
+}
+
+func (repo *sqlite3Repository) scanPlayer(row rowScanner) (*model.Player, error) {
+	var player model.Player
+	var createDate int64
+	var updateDate int64
+	var timeLeft int64
+	err := row.Scan(
+		&player.UUID, &createDate, &updateDate,
+		&player.GameUUID, &player.Points, &player.Appends,
+		&player.Name, &player.Color, &player.UserUUID, &player.SkipTurn,
+		&timeLeft,
+	)
+	player.CreateDate = time.Unix(createDate, 0)
+	player.UpdateDate = time.Unix(updateDate, 0)
+	// Time left is stored in milliseconds
+	player.TimeLeft = time.Duration(timeLeft) * time.Millisecond
+	return &player, repo.checkSqlErr(err)
+}
+
+func (repo *sqlite3Repository) scanPlay(row rowScanner) (*model.Play, error) {
+	var play model.Play
+	var createDate int64
+	var updateDate int64
+	err := row.Scan(
+		&play.ID, &createDate, &updateDate, &play.GameUUID, &play.PlayerUUID,
+		&play.AppendNum, &play.Word, &play.Points, &play.Provisional,
+		&play.UndoRequested,
+	)
+	play.CreateDate = time.Unix(createDate, 0)
+	play.UpdateDate = time.Unix(updateDate, 0)
+	return &play, repo.checkSqlErr(err)
+}
+
+func (repo *sqlite3Repository) selectGames(
+	query string,
+	args ...interface{},
+) (*[]model.Game, error) {
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var games []model.Game
+
+	for rows.Next() {
+		game, err := repo.scanGame(rows)
+		if err != nil {
+			return &games, err
+		}
+		games = append(games, *game)
+	}
+	if err = rows.Err(); err != nil {
+		return &games, err
+	}
+	return &games, nil
+}
+
+func (repo *sqlite3Repository) selectAvChars(
+	query string,
+	args ...interface{},
+) (*[]model.AvChar, error) {
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var available_characters []model.AvChar
+
+	for rows.Next() {
+		var createDate int64
+		var updateDate int64
+		var lt model.AvChar
+		err := rows.Scan(
+			&lt.ID, &createDate, &updateDate, &lt.PlayerUUID, &lt.Value,
+		)
+		lt.CreateDate = time.Unix(createDate, 0)
+		lt.UpdateDate = time.Unix(updateDate, 0)
+		if err = repo.checkSqlErr(err); err != nil {
+			return &available_characters, err
+		}
+		available_characters = append(available_characters, lt)
+	}
+	if err = rows.Err(); err != nil {
+		return &available_characters, err
+	}
+	return &available_characters, nil
+}
+
+func (repo *sqlite3Repository) selectUser(
+	query string,
+	args ...interface{},
+) (*model.User, error) {
+	row := repo.db.QueryRow(query, args...)
+
+	var user model.User
+	var createDate int64
+	var updateDate int64
+	err := row.Scan(
+		&user.UUID, &createDate, &updateDate, &user.Login, &user.PasswordHash,
+	)
+	user.CreateDate = time.Unix(createDate, 0)
+	user.UpdateDate = time.Unix(updateDate, 0)
+	if err = repo.checkSqlErr(err); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Schema change applied in a single transaction
+type sqlite3Migration struct {
+	// Statements creating new tables
+	tables string
+	// Columns added to tables created by earlier changes
+	columns []sqlite3Columns
+	// Statements converting stored values, run after tables and columns
+	data string
+}
+
+// Definitions of columns added to a table
+type sqlite3Columns struct {
+	table       string
+	definitions []string
+}
+
+// Schema changes in the order they were made. The first one is the schema the
+// game started with, later ones create new tables and add columns to the
+// existing ones. Databases created before the schema was versioned can hold
+// any of the columns already, so columns are added only when missing.
+func sqlite3Migrations() []sqlite3Migration {
+	d := "sqlite3"
+	return []sqlite3Migration{
+		// 1
+		{tables: model.GameMigrationSQL[d] +
+			model.PlayerMigrationSQL[d] +
+			model.FieldMigrationSQL[d] +
+			model.AvCharMigrationSQL[d]},
+		// 2
+		{columns: []sqlite3Columns{
+			{"players", model.PlayerNameColumnsSQL[d]},
+		}},
+		// 3
+		{
+			tables: model.UserMigrationSQL[d] + model.SessionMigrationSQL[d],
+			columns: []sqlite3Columns{
+				{"games", model.GameFinishColumnsSQL[d]},
+				{"players", model.PlayerUserColumnsSQL[d]},
+			},
+		},
+		// 4
+		{columns: []sqlite3Columns{
+			{"games", model.GameRulesColumnsSQL[d]},
+		}},
+		// 5
+		{columns: []sqlite3Columns{
+			{"games", model.GameLanguageColumnsSQL[d]},
+		}},
+		// 6
+		{tables: model.GameWordMigrationSQL[d]},
+		// 7
+		{
+			tables: model.PlayMigrationSQL[d],
+			columns: []sqlite3Columns{
+				{"games", model.GameChallengeColumnsSQL[d]},
+				{"players", model.PlayerSkipTurnColumnsSQL[d]},
+			},
+		},
+		// 8
+		{columns: []sqlite3Columns{
+			{"plays", model.PlayUndoColumnsSQL[d]},
+		}},
+		// 9
+		{columns: []sqlite3Columns{
+			{"games", model.GameClockColumnsSQL[d]},
+			{"players", model.PlayerTimeLeftColumnsSQL[d]},
+		}},
+		// 10
+		{columns: []sqlite3Columns{
+			{"games", model.GameCorrespondenceColumnsSQL[d]},
+		}},
+		// 11
+		{data: model.GameClockMillisecondsSQL[d]},
+	}
+}
+
+// Adds the column unless the table already has it. The name of the column is
+// the first word of its definition.
+func (repo *sqlite3Repository) addColumn(
+	tx *sql.Tx,
+	table string,
+	definition string,
+) error {
+	name, _, _ := strings.Cut(definition, " ")
+	var count int
+	err := tx.QueryRow(
+		"SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?",
+		table, name,
+	).Scan(&count)
+	if err != nil || count > 0 {
+		return err
+	}
+	// Identifiers do not take parameters
+	_, err = tx.Exec(fmt.Sprintf(
+		"ALTER TABLE %s ADD COLUMN %s;", table, definition,
+	))
+	return err
+}
+
+// Applies the change and stores the version in the same transaction
+func (repo *sqlite3Repository) migrate(
+	version int,
+	migration sqlite3Migration,
+) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if migration.tables != "" {
+		if _, err := tx.Exec(migration.tables); err != nil {
+			return err
+		}
+	}
+	for _, columns := range migration.columns {
+		for _, definition := range columns.definitions {
+			if err := repo.addColumn(tx, columns.table, definition); err != nil {
+				return err
+			}
+		}
+	}
+	if migration.data != "" {
+		if _, err := tx.Exec(migration.data); err != nil {
+			return err
+		}
+	}
+	// Pragmas do not take parameters
+	if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d;", version)); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	repo.logger.Info("database migrated", "version", version)
+	return nil
+}
+
 // |PUBLIC| //
 
+// Brings the schema up to date. Changes are applied in order, the database
+// keeps the number of applied ones in PRAGMA user_version.
 func (repo *sqlite3Repository) Migrate() error {
+	defer repo.metrics.ObserveQuery("Migrate", time.Now())
 	// Enable foreign key support
 	_, err := repo.db.Exec("PRAGMA foreign_keys = ON;")
 	if err != nil {
 		return err
 	}
-	d := "sqlite3"
-	migrationQuery := model.GameMigrationSQL[d] +
-		model.PlayerMigrationSQL[d] +
-		model.FieldMigrationSQL[d] +
-		model.AvCharMigrationSQL[d]
-	_, err = repo.db.Exec(migrationQuery)
-	return err
+	var version int
+	if err := repo.db.QueryRow("PRAGMA user_version;").Scan(&version); err != nil {
+		return err
+	}
+	migrations := sqlite3Migrations()
+	if version > len(migrations) {
+		return fmt.Errorf(
+			"database schema version %v is newer than the latest known %v",
+			version, len(migrations),
+		)
+	}
+	for i := version; i < len(migrations); i++ {
+		if err := repo.migrate(i+1, migrations[i]); err != nil {
+			return fmt.Errorf("migrating database to version %v; %w", i+1, err)
+		}
+	}
+	return nil
 }
 
 func (repo *sqlite3Repository) CloseConn() error {
@@ -71,19 +418,48 @@ func (repo *sqlite3Repository) CloseConn() error {
 // * Game * //
 
 func (repo *sqlite3Repository) InsertGame(game *model.Game) error {
+	defer repo.metrics.ObserveQuery("InsertGame", time.Now())
 	_, err := repo.db.Exec(
 		`INSERT INTO games(
 			uuid, 
 			create_date,
 			update_date,
 			turn, 
-			points_to_win
-		) values(?,?,?,?,?)`,
+			points_to_win,
+			finish_date,
+			board_size,
+			rack_size,
+			min_word_len,
+			time_control,
+			time_bank,
+			time_increment,
+			challenge_mode,
+			language,
+			alphabet,
+			turn_player_uuid,
+			turn_start_date,
+			correspondence,
+			forfeit_player_uuid
+		) values(?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`,
 		game.UUID,
 		game.CreateDate.Unix(),
 		game.UpdateDate.Unix(),
 		game.Turn,
-		game.PointsToWin,
+		game.Rules.PointsToWin,
+		repo.unixOrZero(game.FinishDate),
+		game.Rules.BoardSize,
+		game.Rules.RackSize,
+		game.Rules.MinWordLen,
+		game.Rules.TimeControl.Milliseconds(),
+		game.Rules.TimeBank.Milliseconds(),
+		game.Rules.TimeIncrement.Milliseconds(),
+		game.Rules.ChallengeMode,
+		game.Rules.Language,
+		game.Rules.Alphabet,
+		game.TurnPlayerUUID,
+		repo.unixMilliOrZero(game.TurnStartDate),
+		game.Rules.Correspondence,
+		game.ForfeitPlayerUUID,
 	)
 	return repo.checkSqlErr(err)
 }
@@ -91,27 +467,56 @@ func (repo *sqlite3Repository) InsertGame(game *model.Game) error {
 func (repo *sqlite3Repository) SelectGameByUUID(
 	gameUUID uuid.UUID,
 ) (*model.Game, error) {
-	row := repo.db.QueryRow("SELECT * FROM games WHERE uuid = ?", gameUUID)
+	defer repo.metrics.ObserveQuery("SelectGameByUUID", time.Now())
+	row := repo.db.QueryRow(
+		"SELECT "+gameColumns+" FROM games WHERE uuid = ?", gameUUID)
+	return repo.scanGame(row)
+}
 
-	var game model.Game
-	var createDate int64
-	var updateDate int64
-	err := row.Scan(&game.UUID, &createDate, &updateDate, &game.Turn, &game.PointsToWin)
-	game.CreateDate = time.Unix(createDate, 0)
-	game.UpdateDate = time.Unix(updateDate, 0)
-	return &game, repo.checkSqlErr(err)
+func (repo *sqlite3Repository) SelectGamesByUserID(
+	userUUID uuid.UUID,
+) (*[]model.Game, error) {
+	defer repo.metrics.ObserveQuery("SelectGamesByUserID", time.Now())
+	return repo.selectGames(
+		"SELECT "+gameColumns+` FROM games
+		WHERE uuid IN (SELECT game_uuid FROM players WHERE user_uuid = ?)
+		ORDER BY update_date DESC`,
+		userUUID,
+	)
+}
+
+func (repo *sqlite3Repository) SelectUnfinishedGames() (*[]model.Game, error) {
+	defer repo.metrics.ObserveQuery("SelectUnfinishedGames", time.Now())
+	return repo.selectGames(
+		"SELECT " + gameColumns + " FROM games WHERE finish_date = 0 ORDER BY create_date",
+	)
+}
+
+// All games, recently updated first
+func (repo *sqlite3Repository) SelectGames() (*[]model.Game, error) {
+	defer repo.metrics.ObserveQuery("SelectGames", time.Now())
+	return repo.selectGames(
+		"SELECT " + gameColumns + " FROM games ORDER BY update_date DESC",
+	)
 }
 
 func (repo *sqlite3Repository) UpdateGame(game *model.Game) error {
+	defer repo.metrics.ObserveQuery("UpdateGame", time.Now())
 	res, err := repo.db.Exec(
 		`UPDATE games SET 
 			turn = ?, 
-			points_to_win = ?,
 			update_date = ?,
+			finish_date = ?,
+			turn_player_uuid = ?,
+			turn_start_date = ?,
+			forfeit_player_uuid = ?
 		WHERE uuid = ?`,
 		game.Turn,
-		game.PointsToWin,
 		game.UpdateDate.Unix(),
+		repo.unixOrZero(game.FinishDate),
+		game.TurnPlayerUUID,
+		repo.unixMilliOrZero(game.TurnStartDate),
+		game.ForfeitPlayerUUID,
 		game.UUID,
 	)
 	if err != nil {
@@ -131,6 +536,7 @@ func (repo *sqlite3Repository) UpdateGame(game *model.Game) error {
 }
 
 func (repo *sqlite3Repository) DeleteGame(game *model.Game) error {
+	defer repo.metrics.ObserveQuery("DeleteGame", time.Now())
 	res, err := repo.db.Exec("DELETE FROM games WHERE uuid = ?", game.UUID)
 	if err != nil {
 		return err
@@ -151,7 +557,7 @@ func (repo *sqlite3Repository) DeleteGame(game *model.Game) error {
 // * Player * //
 
 func (repo *sqlite3Repository) InsertPlayer(player *model.Player) error {
-	fmt.Println(player)
+	defer repo.metrics.ObserveQuery("InsertPlayer", time.Now())
 	_, err := repo.db.Exec(
 		`INSERT INTO players(
 			uuid, 
@@ -159,52 +565,119 @@ func (repo *sqlite3Repository) InsertPlayer(player *model.Player) error {
 			update_date,
 			game_uuid, 
 			points, 
-			appends
-		) values(?,?,?,?,?,?)`,
+			appends,
+			name,
+			color,
+			user_uuid,
+			skip_turn,
+			time_left
+		) values(?,?,?,?,?,?,?,?,?,?,?)`,
 		player.UUID,
 		player.CreateDate.Unix(),
 		player.UpdateDate.Unix(),
 		player.GameUUID,
 		player.Points,
 		player.Appends,
+		player.Name,
+		player.Color,
+		player.UserUUID,
+		player.SkipTurn,
+		player.TimeLeft.Milliseconds(),
 	)
+	if err == nil {
+		repo.logger.Debug(
+			"player inserted",
+			logging.KeyGame, player.GameUUID,
+			logging.KeyPlayer, player.UUID,
+		)
+	}
 	return repo.checkSqlErr(err)
 }
 
 func (repo *sqlite3Repository) SelectPlayerByUUID(
 	playerUUID uuid.UUID,
 ) (*model.Player, error) {
-	row := repo.db.QueryRow("SELECT * FROM players WHERE uuid = ?", playerUUID)
+	defer repo.metrics.ObserveQuery("SelectPlayerByUUID", time.Now())
+	row := repo.db.QueryRow(
+		"SELECT "+playerColumns+" FROM players WHERE uuid = ?", playerUUID)
+	player, err := repo.scanPlayer(row)
+	if err != nil {
+		return nil, err
+	}
+	return player, nil
+}
 
-	var createDate int64
-	var updateDate int64
-	var player model.Player
-	err := row.Scan(
-		&player.UUID, &createDate, &updateDate,
-		&player.GameUUID, &player.Points, &player.Appends,
+func (repo *sqlite3Repository) SelectPlayerByGameAndUserID(
+	gameUUID uuid.UUID,
+	userUUID uuid.UUID,
+) (*model.Player, error) {
+	defer repo.metrics.ObserveQuery("SelectPlayerByGameAndUserID", time.Now())
+	row := repo.db.QueryRow(
+		"SELECT "+playerColumns+" FROM players WHERE game_uuid = ? AND user_uuid = ?",
+		gameUUID,
+		userUUID,
 	)
-	player.CreateDate = time.Unix(createDate, 0)
-	player.UpdateDate = time.Unix(updateDate, 0)
-	if err = repo.checkSqlErr(err); err != nil {
+	player, err := repo.scanPlayer(row)
+	if err != nil {
 		return nil, err
 	}
-	return &player, nil
+	return player, nil
+}
+
+func (repo *sqlite3Repository) SelectPlayersByGameID(
+	gameUUID uuid.UUID,
+) (*[]model.Player, error) {
+	defer repo.metrics.ObserveQuery("SelectPlayersByGameID", time.Now())
+	rows, err := repo.db.Query(
+		"SELECT "+playerColumns+` FROM players WHERE game_uuid = ?
+		ORDER BY create_date, rowid`,
+		gameUUID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var players []model.Player
+
+	for rows.Next() {
+		player, err := repo.scanPlayer(rows)
+		if err != nil {
+			return &players, err
+		}
+		players = append(players, *player)
+	}
+	if err = rows.Err(); err != nil {
+		return &players, err
+	}
+	return &players, nil
 }
 
 func (repo *sqlite3Repository) UpdatePlayer(
 	updatedPlayer *model.Player,
 ) error {
+	defer repo.metrics.ObserveQuery("UpdatePlayer", time.Now())
 	res, err := repo.db.Exec(
 		`UPDATE players SET 
 			game_uuid = ?,
 			update_date = ?,
 			points = ?, 
-			appends = ? 
+			appends = ?,
+			name = ?,
+			color = ?,
+			user_uuid = ?,
+			skip_turn = ?,
+			time_left = ?
 		WHERE uuid = ?`,
 		updatedPlayer.GameUUID,
 		updatedPlayer.UpdateDate.Unix(),
 		updatedPlayer.Points,
 		updatedPlayer.Appends,
+		updatedPlayer.Name,
+		updatedPlayer.Color,
+		updatedPlayer.UserUUID,
+		updatedPlayer.SkipTurn,
+		updatedPlayer.TimeLeft.Milliseconds(),
 		updatedPlayer.UUID,
 	)
 	if err != nil {
@@ -223,9 +696,110 @@ func (repo *sqlite3Repository) UpdatePlayer(
 	return nil
 }
 
+// * User * //
+
+func (repo *sqlite3Repository) InsertUser(user *model.User) error {
+	defer repo.metrics.ObserveQuery("InsertUser", time.Now())
+	_, err := repo.db.Exec(
+		`INSERT INTO users(
+			uuid,
+			create_date,
+			update_date,
+			login,
+			password_hash
+		) values(?,?,?,?,?)`,
+		user.UUID,
+		user.CreateDate.Unix(),
+		user.UpdateDate.Unix(),
+		user.Login,
+		user.PasswordHash,
+	)
+	return repo.checkSqlErr(err)
+}
+
+func (repo *sqlite3Repository) SelectUserByUUID(
+	userUUID uuid.UUID,
+) (*model.User, error) {
+	defer repo.metrics.ObserveQuery("SelectUserByUUID", time.Now())
+	return repo.selectUser("SELECT "+userColumns+" FROM users WHERE uuid = ?", userUUID)
+}
+
+func (repo *sqlite3Repository) SelectUserByLogin(
+	login string,
+) (*model.User, error) {
+	defer repo.metrics.ObserveQuery("SelectUserByLogin", time.Now())
+	return repo.selectUser("SELECT "+userColumns+" FROM users WHERE login = ?", login)
+}
+
+// * Session * //
+
+func (repo *sqlite3Repository) InsertSession(session *model.Session) error {
+	defer repo.metrics.ObserveQuery("InsertSession", time.Now())
+	_, err := repo.db.Exec(
+		`INSERT INTO sessions(
+			token_hash,
+			create_date,
+			update_date,
+			user_uuid,
+			expire_date
+		) values(?,?,?,?,?)`,
+		session.TokenHash,
+		session.CreateDate.Unix(),
+		session.UpdateDate.Unix(),
+		session.UserUUID,
+		session.ExpireDate.Unix(),
+	)
+	return repo.checkSqlErr(err)
+}
+
+func (repo *sqlite3Repository) SelectSessionByTokenHash(
+	tokenHash []byte,
+) (*model.Session, error) {
+	defer repo.metrics.ObserveQuery("SelectSessionByTokenHash", time.Now())
+	row := repo.db.QueryRow(
+		"SELECT "+sessionColumns+" FROM sessions WHERE token_hash = ?", tokenHash)
+
+	var session model.Session
+	var createDate int64
+	var updateDate int64
+	var expireDate int64
+	err := row.Scan(
+		&session.TokenHash, &createDate, &updateDate,
+		&session.UserUUID, &expireDate,
+	)
+	session.CreateDate = time.Unix(createDate, 0)
+	session.UpdateDate = time.Unix(updateDate, 0)
+	session.ExpireDate = time.Unix(expireDate, 0)
+	if err = repo.checkSqlErr(err); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (repo *sqlite3Repository) DeleteSession(session *model.Session) error {
+	defer repo.metrics.ObserveQuery("DeleteSession", time.Now())
+	res, err := repo.db.Exec(
+		"DELETE FROM sessions WHERE token_hash = ?", session.TokenHash)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrDeleteFailed
+	}
+
+	return err
+}
+
 // * Field * //
 
 func (repo *sqlite3Repository) InsertField(field *model.Field) error {
+	defer repo.metrics.ObserveQuery("InsertField", time.Now())
 	res, err := repo.db.Exec(`
 		INSERT INTO fields(
 			game_uuid, create_date, update_date, player_uuid, append_num,
@@ -259,8 +833,9 @@ func (repo *sqlite3Repository) InsertField(field *model.Field) error {
 func (repo *sqlite3Repository) SelectFieldsByGameID(
 	gameUUID uuid.UUID,
 ) (*[]model.Field, error) {
+	defer repo.metrics.ObserveQuery("SelectFieldsByGameID", time.Now())
 	rows, err := repo.db.Query(
-		"SELECT * FROM fields WHERE game_uuid = ?",
+		"SELECT "+fieldColumns+" FROM fields WHERE game_uuid = ?",
 		gameUUID,
 	)
 	if err != nil {
@@ -293,6 +868,7 @@ func (repo *sqlite3Repository) SelectFieldsByGameID(
 }
 
 func (repo *sqlite3Repository) DeleteField(field *model.Field) error {
+	defer repo.metrics.ObserveQuery("DeleteField", time.Now())
 	res, err := repo.db.Exec("DELETE FROM fields WHERE id = ?", field.ID)
 	if err != nil {
 		return err
@@ -313,6 +889,7 @@ func (repo *sqlite3Repository) DeleteField(field *model.Field) error {
 // * AvChar * //
 
 func (repo *sqlite3Repository) InsertAvChar(avChar *model.AvChar) error {
+	defer repo.metrics.ObserveQuery("InsertAvChar", time.Now())
 	res, err := repo.db.Exec(`
 		INSERT INTO available_characters(
 			create_date,
@@ -343,40 +920,251 @@ func (repo *sqlite3Repository) InsertAvChar(avChar *model.AvChar) error {
 func (repo *sqlite3Repository) SelectAvCharsByPlayerID(
 	playerUUID uuid.UUID,
 ) (*[]model.AvChar, error) {
-	rows, err := repo.db.Query(
-		"SELECT * FROM available_characters WHERE player_uuid = ?",
+	defer repo.metrics.ObserveQuery("SelectAvCharsByPlayerID", time.Now())
+	return repo.selectAvChars(
+		"SELECT "+avCharColumns+" FROM available_characters WHERE player_uuid = ?",
 		playerUUID,
 	)
+}
+
+// Available characters of all players of the game
+func (repo *sqlite3Repository) SelectAvCharsByGameID(
+	gameUUID uuid.UUID,
+) (*[]model.AvChar, error) {
+	defer repo.metrics.ObserveQuery("SelectAvCharsByGameID", time.Now())
+	return repo.selectAvChars(
+		"SELECT "+avCharColumns+` FROM available_characters
+		WHERE player_uuid IN (SELECT uuid FROM players WHERE game_uuid = ?)`,
+		gameUUID,
+	)
+}
+
+func (repo *sqlite3Repository) DeleteAvCharByID(avCharID int64) error {
+	defer repo.metrics.ObserveQuery("DeleteAvCharByID", time.Now())
+	res, err := repo.db.Exec(
+		"DELETE FROM available_characters WHERE id = ?", avCharID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrDeleteFailed
+	}
+
+	return err
+}
+
+// * GameWord * //
+
+func (repo *sqlite3Repository) InsertGameWord(gameWord *model.GameWord) error {
+	defer repo.metrics.ObserveQuery("InsertGameWord", time.Now())
+	res, err := repo.db.Exec(`
+		INSERT INTO game_words(
+			create_date,
+			update_date,
+			game_uuid,
+			word,
+			allowed
+		) values(
+			?,?,?,?,?
+		)`,
+		gameWord.CreateDate.Unix(),
+		gameWord.UpdateDate.Unix(),
+		gameWord.GameUUID,
+		gameWord.Word,
+		gameWord.Allowed,
+	)
+	if err = repo.checkSqlErr(err); err != nil {
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	gameWord.ID = id
+
+	return nil
+}
+
+func (repo *sqlite3Repository) SelectGameWordsByGameID(
+	gameUUID uuid.UUID,
+) (*[]model.GameWord, error) {
+	defer repo.metrics.ObserveQuery("SelectGameWordsByGameID", time.Now())
+	rows, err := repo.db.Query(
+		"SELECT "+gameWordColumns+" FROM game_words WHERE game_uuid = ? ORDER BY word",
+		gameUUID,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var available_characters []model.AvChar
+	var gameWords []model.GameWord
 
 	for rows.Next() {
 		var createDate int64
 		var updateDate int64
-		var lt model.AvChar
+		var lt model.GameWord
 		err := rows.Scan(
-			&lt.ID, &createDate, &updateDate, &lt.PlayerUUID, &lt.Value,
+			&lt.ID, &createDate, &updateDate, &lt.GameUUID, &lt.Word, &lt.Allowed,
 		)
 		lt.CreateDate = time.Unix(createDate, 0)
 		lt.UpdateDate = time.Unix(updateDate, 0)
 		if err = repo.checkSqlErr(err); err != nil {
-			return &available_characters, err
+			return &gameWords, err
 		}
-		available_characters = append(available_characters, lt)
+		gameWords = append(gameWords, lt)
 	}
 	if err = rows.Err(); err != nil {
-		return &available_characters, err
+		return &gameWords, err
 	}
-	return &available_characters, nil
+	return &gameWords, nil
 }
 
-func (repo *sqlite3Repository) DeleteAvCharByID(avCharID int64) error {
+func (repo *sqlite3Repository) DeleteGameWord(gameWord *model.GameWord) error {
+	defer repo.metrics.ObserveQuery("DeleteGameWord", time.Now())
+	res, err := repo.db.Exec("DELETE FROM game_words WHERE id = ?", gameWord.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrDeleteFailed
+	}
+
+	return err
+}
+
+// * Play * //
+
+func (repo *sqlite3Repository) InsertPlay(play *model.Play) error {
+	defer repo.metrics.ObserveQuery("InsertPlay", time.Now())
+	res, err := repo.db.Exec(`
+		INSERT INTO plays(
+			create_date,
+			update_date,
+			game_uuid,
+			player_uuid,
+			append_num,
+			word,
+			points,
+			provisional,
+			undo_requested
+		) values(
+			?,?,?,?,?,?,?,?,?
+		)`,
+		play.CreateDate.Unix(),
+		play.UpdateDate.Unix(),
+		play.GameUUID,
+		play.PlayerUUID,
+		play.AppendNum,
+		play.Word,
+		play.Points,
+		play.Provisional,
+		play.UndoRequested,
+	)
+	if err = repo.checkSqlErr(err); err != nil {
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	play.ID = id
+
+	return nil
+}
+
+func (repo *sqlite3Repository) SelectLastPlayByGameID(
+	gameUUID uuid.UUID,
+) (*model.Play, error) {
+	defer repo.metrics.ObserveQuery("SelectLastPlayByGameID", time.Now())
+	row := repo.db.QueryRow(
+		"SELECT "+playColumns+" FROM plays WHERE game_uuid = ? ORDER BY id DESC LIMIT 1",
+		gameUUID,
+	)
+	play, err := repo.scanPlay(row)
+	if err != nil {
+		return nil, err
+	}
+	return play, nil
+}
+
+// Plays of the game in the order they were made
+func (repo *sqlite3Repository) SelectPlaysByGameID(
+	gameUUID uuid.UUID,
+) (*[]model.Play, error) {
+	defer repo.metrics.ObserveQuery("SelectPlaysByGameID", time.Now())
+	rows, err := repo.db.Query(
+		"SELECT "+playColumns+" FROM plays WHERE game_uuid = ? ORDER BY id",
+		gameUUID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var plays []model.Play
+
+	for rows.Next() {
+		play, err := repo.scanPlay(rows)
+		if err != nil {
+			return &plays, err
+		}
+		plays = append(plays, *play)
+	}
+	if err = rows.Err(); err != nil {
+		return &plays, err
+	}
+	return &plays, nil
+}
+
+func (repo *sqlite3Repository) UpdatePlay(play *model.Play) error {
+	defer repo.metrics.ObserveQuery("UpdatePlay", time.Now())
 	res, err := repo.db.Exec(
-		"DELETE FROM available_characters WHERE id = ?", avCharID)
+		`UPDATE plays SET 
+			update_date = ?,
+			points = ?,
+			provisional = ?,
+			undo_requested = ?
+		WHERE id = ?`,
+		play.UpdateDate.Unix(),
+		play.Points,
+		play.Provisional,
+		play.UndoRequested,
+		play.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrUpdateFailed
+	}
+
+	return nil
+}
+
+func (repo *sqlite3Repository) DeletePlay(play *model.Play) error {
+	defer repo.metrics.ObserveQuery("DeletePlay", time.Now())
+	res, err := repo.db.Exec("DELETE FROM plays WHERE id = ?", play.ID)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,268 @@
+package repo
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"scrable3/internal/metrics"
+	"scrable3/internal/model"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func raiseErr(t *testing.T, service string, method string, err error) {
+	t.Errorf("%v.%v error; %v", service, method, err)
+}
+
+// Schema of the first release, created without a version
+const baselineSchemaSQL = `
+CREATE TABLE IF NOT EXISTS games(
+    uuid BLOB PRIMARY KEY,
+	create_date INTEGER NOT NULL,
+	update_date INTEGER,
+    turn INTEGER NOT NULL,
+    points_to_win INTEGER NOT NULL
+);
+CREATE TABLE IF NOT EXISTS players(
+    uuid BLOB PRIMARY KEY,
+	create_date INTEGER NOT NULL,
+	update_date INTEGER,
+    game_uuid BLOB NOT NULL,
+    points INTEGER NOT NULL,
+    appends INTEGER NOT NULL,
+    FOREIGN KEY (game_uuid) REFERENCES games(uuid) ON DELETE CASCADE
+);
+CREATE TABLE IF NOT EXISTS fields(
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+	create_date INTEGER NOT NULL,
+	update_date INTEGER,
+    game_uuid BLOB NOT NULL,
+    player_uuid BLOB NOT NULL,
+    append_num INTEGER NOT NULL,
+    val CHAR(1) NOT NULL,
+    pos_x INTEGER NOT NULL,
+    pos_y INTEGER NOT NULL,
+    pos_z INTEGER NOT NULL,
+    FOREIGN KEY (game_uuid) REFERENCES games(uuid) ON DELETE CASCADE,
+    FOREIGN KEY (player_uuid) REFERENCES players(uuid) ON DELETE CASCADE
+);
+CREATE TABLE IF NOT EXISTS available_characters(
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+	create_date INTEGER NOT NULL,
+	update_date INTEGER,
+    player_uuid BLOB NOT NULL,
+    val CHAR(1) NOT NULL,
+    FOREIGN KEY (player_uuid) REFERENCES players(uuid) ON DELETE CASCADE
+);
+`
+
+func openTestDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "sqlite.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func newTestRepository(t *testing.T, db *sql.DB) Repository {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repository, err := NewSqlite3Connection(db, logger, metrics.New())
+	if err != nil {
+		raiseErr(t, "Repository", "Migrate", err)
+		t.FailNow()
+	}
+	return repository
+}
+
+func userVersion(t *testing.T, db *sql.DB) int {
+	var version int
+	if err := db.QueryRow("PRAGMA user_version;").Scan(&version); err != nil {
+		t.Fatal(err)
+	}
+	return version
+}
+
+func TestMigrateBaseline(t *testing.T) {
+	sn := "Repository"
+	db := openTestDB(t)
+	if _, err := db.Exec(baselineSchemaSQL); err != nil {
+		t.Fatal(err)
+	}
+	gameUUID := uuid.New()
+	playerUUID := uuid.New()
+	date := time.Now().Unix()
+	_, err := db.Exec(
+		`INSERT INTO games(uuid, create_date, update_date, turn, points_to_win)
+		values(?,?,?,?,?)`,
+		gameUUID, date, date, 2, 20,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(
+		`INSERT INTO players(
+			uuid, create_date, update_date, game_uuid, points, appends
+		) values(?,?,?,?,?,?)`,
+		playerUUID, date, date, gameUUID, 7, 1,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repository := newTestRepository(t, db)
+
+	if version := userVersion(t, db); version != len(sqlite3Migrations()) {
+		t.Errorf(
+			"%v.Migrate version %v, expected %v",
+			sn, version, len(sqlite3Migrations()),
+		)
+	}
+	game, err := repository.SelectGameByUUID(gameUUID)
+	if err != nil {
+		raiseErr(t, sn, "SelectGameByUUID", err)
+		t.FailNow()
+	}
+	// Games of the first release were played with these rules
+	if game.Turn != 2 || game.Rules.PointsToWin != 20 ||
+		game.Rules.BoardSize != 15 || game.Rules.RackSize != 9 ||
+		game.Rules.MinWordLen != 3 || game.Rules.Language != "en" ||
+		game.Rules.Alphabet != "ABCDEFGHIJKLMNOPQRSTUVWXYZ" ||
+		!game.FinishDate.IsZero() {
+		t.Errorf("%v.SelectGameByUUID unexpected migrated game %+v", sn, game)
+	}
+	players, err := repository.SelectPlayersByGameID(gameUUID)
+	if err != nil {
+		raiseErr(t, sn, "SelectPlayersByGameID", err)
+		t.FailNow()
+	}
+	if len(*players) != 1 || (*players)[0].UUID != playerUUID ||
+		(*players)[0].Points != 7 || (*players)[0].UserUUID.Valid {
+		t.Errorf(
+			"%v.SelectPlayersByGameID unexpected migrated players %+v",
+			sn, *players,
+		)
+	}
+
+	// Migrating again changes nothing
+	repository = newTestRepository(t, db)
+	if _, err := repository.SelectGameByUUID(gameUUID); err != nil {
+		raiseErr(t, sn, "SelectGameByUUID", err)
+	}
+}
+
+func TestMigrateNewerVersion(t *testing.T) {
+	sn := "Repository"
+	db := openTestDB(t)
+	_, err := db.Exec(
+		"PRAGMA user_version = 1000;",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	if _, err := NewSqlite3Connection(db, logger, metrics.New()); err == nil {
+		t.Errorf("%v.Migrate expected error for an unknown version", sn)
+	}
+}
+
+// Databases versioned before the schema was split into steps hold every
+// column already, with version 0, 1 or 2
+func TestMigrateExistingColumns(t *testing.T) {
+	sn := "Repository"
+	for _, version := range []int{0, 1, 2} {
+		db := openTestDB(t)
+		newTestRepository(t, db)
+		_, err := db.Exec(fmt.Sprintf("PRAGMA user_version = %d;", version))
+		if err != nil {
+			t.Fatal(err)
+		}
+		repository := newTestRepository(t, db)
+		if v := userVersion(t, db); v != len(sqlite3Migrations()) {
+			t.Errorf(
+				"%v.Migrate from %v version %v, expected %v",
+				sn, version, v, len(sqlite3Migrations()),
+			)
+		}
+		if _, err := repository.SelectGames(); err != nil {
+			raiseErr(t, sn, "SelectGames", err)
+		}
+	}
+}
+
+func TestMigrateClockMilliseconds(t *testing.T) {
+	sn := "Repository"
+	db := openTestDB(t)
+	newTestRepository(t, db)
+	// Game stored when times were kept in seconds
+	gameUUID := uuid.New()
+	date := time.Now().Unix()
+	_, err := db.Exec(
+		`INSERT INTO games(
+			uuid, create_date, update_date, turn, points_to_win, time_control,
+			time_bank, time_increment
+		) values(?,?,?,?,?,?,?,?)`,
+		gameUUID, date, date, 0, 20, 30, 600, 5,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("PRAGMA user_version = 10;"); err != nil {
+		t.Fatal(err)
+	}
+
+	repository := newTestRepository(t, db)
+	game, err := repository.SelectGameByUUID(gameUUID)
+	if err != nil {
+		raiseErr(t, sn, "SelectGameByUUID", err)
+		t.FailNow()
+	}
+	if game.Rules.TimeControl != 30*time.Second ||
+		game.Rules.TimeBank != 10*time.Minute ||
+		game.Rules.TimeIncrement != 5*time.Second {
+		t.Errorf("%v.Migrate unexpected clock rules %+v", sn, game.Rules)
+	}
+}
+
+func TestGameClockPrecision(t *testing.T) {
+	sn := "Repository"
+	repository := newTestRepository(t, openTestDB(t))
+	now := time.Now()
+	game := &model.Game{
+		UUID:       uuid.New(),
+		CreateDate: now,
+		UpdateDate: now,
+		Rules: model.GameRules{
+			BoardSize:     9,
+			RackSize:      7,
+			MinWordLen:    3,
+			PointsToWin:   20,
+			TimeControl:   1500 * time.Millisecond,
+			TimeBank:      90*time.Second + 250*time.Millisecond,
+			TimeIncrement: 500 * time.Millisecond,
+			Language:      "en",
+			Alphabet:      "ABC",
+		},
+	}
+	if err := repository.InsertGame(game); err != nil {
+		raiseErr(t, sn, "InsertGame", err)
+		t.FailNow()
+	}
+	selected, err := repository.SelectGameByUUID(game.UUID)
+	if err != nil {
+		raiseErr(t, sn, "SelectGameByUUID", err)
+		t.FailNow()
+	}
+	if selected.Rules.TimeControl != game.Rules.TimeControl ||
+		selected.Rules.TimeBank != game.Rules.TimeBank ||
+		selected.Rules.TimeIncrement != game.Rules.TimeIncrement {
+		t.Errorf(
+			"%v.SelectGameByUUID expected %+v, got %+v",
+			sn, game.Rules, selected.Rules,
+		)
+	}
+}
@@ -0,0 +1,217 @@
+package simulator
+
+import (
+	"fmt"
+	"scrable3/internal/dto"
+	"scrable3/internal/renderer"
+	"scrable3/internal/wsclient"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// How long the server may take to answer, and how long all players have to
+// get nothing before the answer is taken as complete
+const (
+	answerTimeout = 5 * time.Second
+	answerQuiet   = 100 * time.Millisecond
+)
+
+// Game with a connected client for every player, in the order they joined
+type Game struct {
+	UUID    uuid.UUID
+	Players []*Player
+	sim     *Simulator
+}
+
+type Player struct {
+	Name   string
+	UUID   uuid.UUID
+	Client *wsclient.Client
+}
+
+// Step of a scripted game. Plays give the word seen from the side like the
+// terminal client does, other actions are sent as they are.
+type Move struct {
+	// Index of the player in Game.Players
+	Player int
+	// Action type like challenge or undoPlay, empty for a play
+	Action string
+	// Letters put on the rack before the move, empty keeps the rack
+	Rack       string
+	Side       int
+	Horizontal bool
+	Column     int
+	Row        int
+	Word       string
+	// Part of the error the player should get, empty when the move succeeds
+	Error string
+}
+
+func (m *Move) String() string {
+	if m.Action != "" {
+		return fmt.Sprintf("player %v %v", m.Player, m.Action)
+	}
+	direction := "v"
+	if m.Horizontal {
+		direction = "h"
+	}
+	return fmt.Sprintf("player %v play %v %v %v,%v %v",
+		m.Player, renderer.SideName(m.Side), direction, m.Column, m.Row, m.Word)
+}
+
+// |PRIVATE| //
+
+// Merges updates of a player into one, the latest error and notice win
+func merge(into *wsclient.Update, update wsclient.Update) {
+	into.Fields = into.Fields || update.Fields
+	into.Rack = into.Rack || update.Rack
+	into.Players = into.Players || update.Players
+	if update.Error != "" {
+		into.Error = update.Error
+	}
+	if update.Notice != "" {
+		into.Notice = update.Notice
+	}
+}
+
+// Collects updates of all players until they stop coming. When 'sender' is
+// not -1 that player has to get an answer first.
+func (g *Game) collect(sender int) ([]wsclient.Update, error) {
+	updates := make([]wsclient.Update, len(g.Players))
+	answered := sender == -1
+	start := time.Now()
+	last := start
+	for {
+		for i, player := range g.Players {
+		drain:
+			for {
+				select {
+				case update, ok := <-player.Client.Updates():
+					if !ok {
+						return updates, fmt.Errorf(
+							"connection of %v closed; %w", player.Name, player.Client.Err())
+					}
+					merge(&updates[i], update)
+					answered = answered || i == sender
+					last = time.Now()
+				default:
+					break drain
+				}
+			}
+		}
+		now := time.Now()
+		if answered && now.Sub(last) >= answerQuiet {
+			return updates, nil
+		}
+		if !answered && now.Sub(start) >= answerTimeout {
+			return updates, fmt.Errorf("%v got no answer", g.Players[sender].Name)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// |PUBLIC| //
+
+// Waits until the players get nothing more, returns what each of them got
+func (g *Game) Settle() []wsclient.Update {
+	updates, _ := g.collect(-1)
+	return updates
+}
+
+// Sends the action of the player, returns what each player got in answer
+func (g *Game) Send(player int, actionType string) ([]wsclient.Update, error) {
+	if err := g.Players[player].Client.Send(actionType); err != nil {
+		return nil, err
+	}
+	return g.collect(player)
+}
+
+// Plays the word seen from the side, letters already on the face are skipped.
+// Returns what each player got in answer.
+func (g *Game) Play(
+	player int,
+	side int,
+	horizontal bool,
+	column int,
+	row int,
+	word string,
+) ([]wsclient.Update, error) {
+	client := g.Players[player].Client
+	var play *dto.PlayData
+	var err error
+	client.WithState(func(s *wsclient.State) {
+		play, err = s.BuildPlay(side, horizontal, column, row, word)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Play(play); err != nil {
+		return nil, err
+	}
+	return g.collect(player)
+}
+
+// Puts the letters on the rack of the player in place of the tiles it had and
+// lets the player see them. Racks are drawn at random, scripts set them to
+// play known words. Racks shorter than the rules are filled up at random.
+func (g *Game) SetRack(player int, letters string) error {
+	if err := g.sim.setRack(g.Players[player], letters); err != nil {
+		return err
+	}
+	_, err := g.Send(player, "getChars")
+	return err
+}
+
+// Plays the moves in order. Stops at the first move that does not end as
+// expected: a failed move gives its player the error and changes no fields,
+// a successful one gives no error and a play shows new fields to everyone.
+func (g *Game) Run(moves []Move) error {
+	for i, move := range moves {
+		if move.Rack != "" {
+			if err := g.SetRack(move.Player, move.Rack); err != nil {
+				return fmt.Errorf("move %v, %v; %w", i, &move, err)
+			}
+		}
+		var updates []wsclient.Update
+		var err error
+		if move.Action != "" {
+			updates, err = g.Send(move.Player, move.Action)
+		} else {
+			updates, err = g.Play(
+				move.Player, move.Side, move.Horizontal, move.Column, move.Row, move.Word)
+		}
+		if err != nil {
+			return fmt.Errorf("move %v, %v; %w", i, &move, err)
+		}
+
+		got := updates[move.Player].Error
+		switch {
+		case move.Error == "" && got != "":
+			return fmt.Errorf("move %v, %v; unexpected error %q", i, &move, got)
+		case move.Error != "" && !strings.Contains(got, move.Error):
+			return fmt.Errorf("move %v, %v; expected error %q, got %q", i, &move, move.Error, got)
+		}
+		if move.Action != "" {
+			continue
+		}
+		// Rejected plays are shown only to their player
+		for j, update := range updates {
+			if update.Fields == (move.Error != "") {
+				return fmt.Errorf("move %v, %v; %v saw fields change %v",
+					i, &move, g.Players[j].Name, update.Fields)
+			}
+		}
+	}
+	return nil
+}
+
+// Text view of the board as the player sees it
+func (g *Game) Board(player int) string {
+	var board string
+	g.Players[player].Client.WithState(func(s *wsclient.State) {
+		board = renderer.Fields(s.Size, s.Fields())
+	})
+	return board
+}
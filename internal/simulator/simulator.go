@@ -0,0 +1,264 @@
+package simulator
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"scrable3/internal/auth"
+	"scrable3/internal/cfg"
+	"scrable3/internal/ctrl"
+	"scrable3/internal/handler"
+	"scrable3/internal/lang"
+	"scrable3/internal/metrics"
+	"scrable3/internal/model"
+	"scrable3/internal/notify"
+	"scrable3/internal/repo"
+	"scrable3/internal/svc"
+	"scrable3/internal/wsclient"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Server running the game handlers of scrable3 on an in-memory database.
+// Templates and language packs are read relative to the working directory,
+// so it has to be the root of the repository.
+type Simulator struct {
+	server        *httptest.Server
+	repository    repo.Repository
+	playerService svc.PlayerService
+	avCharService svc.AvCharService
+	gameClocks    handler.GameClocks
+
+	mu      sync.Mutex
+	clients []*wsclient.Client
+}
+
+// Starts the server with the game configuration of 'config'
+func New(config *cfg.Config, logger *slog.Logger) (*Simulator, error) {
+	packs, err := lang.LoadPacks(config.Langs.Dir)
+	if err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		return nil, err
+	}
+	// Every connection opens a separate in-memory database
+	db.SetMaxOpenConns(1)
+	metrics := metrics.New()
+	repository, err := repo.NewSqlite3Connection(db, logger, metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	playerTokenSigner, err := auth.NewPlayerTokenSigner(
+		[]auth.Key{{ID: "simulator", Secret: secret}},
+		time.Hour,
+	)
+	if err != nil {
+		return nil, err
+	}
+	playerCookies := handler.NewPlayerCookies(
+		playerTokenSigner,
+		time.Duration(config.Game.ForfeitAfter),
+		false,
+	)
+	originPolicy, err := auth.NewOriginPolicy(nil)
+	if err != nil {
+		return nil, err
+	}
+	csrfProtection := handler.NewCSRFProtection(originPolicy, false)
+
+	gameService := svc.NewGameService(repository, config.Game, packs)
+	playerService := svc.NewPlayerService(repository)
+	fieldService := svc.NewFieldService(repository)
+	avCharService := svc.NewAvCharService(repository)
+	userService := svc.NewUserService(repository)
+	sessionService := svc.NewSessionService(repository)
+	gameWordService := svc.NewGameWordService(repository)
+	playService := svc.NewPlayService(repository)
+
+	wordsController, err := ctrl.NewWordsController(packs, gameWordService)
+	if err != nil {
+		return nil, err
+	}
+	gameController := ctrl.NewGameController(
+		wordsController,
+		gameService,
+		playerService,
+		fieldService,
+		avCharService,
+		playService,
+		packs,
+		notify.NewLogNotifier(io.Discard),
+		logger,
+		metrics,
+	)
+
+	hub := handler.NewHub(logger)
+	gameClocks := handler.NewGameClocks(gameService, gameController, hub, logger)
+	mux := http.NewServeMux()
+	mux.Handle("/", handler.NewHomeHandler(gameService, csrfProtection))
+	gameHandler := handler.NewGameHandler(
+		gameService,
+		playerService,
+		fieldService,
+		userService,
+		sessionService,
+		playerCookies,
+		csrfProtection,
+		false,
+		config.Server.PublicURL,
+	)
+	mux.Handle("/game", gameHandler)
+	mux.Handle("/game/{gameUUID}", gameHandler)
+	mux.Handle(
+		"/game/{gameUUID}/player",
+		handler.NewPlayerHandler(playerService, playerCookies, csrfProtection),
+	)
+	mux.Handle("/game/{gameUUID}/play", handler.NewPlayHandler(
+		gameService,
+		playerService,
+		gameController,
+		playerCookies,
+		csrfProtection,
+		hub,
+	))
+	mux.Handle("/game/{gameUUID}/debug.txt", handler.NewDebugHandler(gameService, fieldService))
+	mux.Handle("/ws/{gameUUID}", handler.NewWebsocketHandler(
+		gameService,
+		playerService,
+		gameController,
+		playerCookies,
+		originPolicy,
+		hub,
+		gameClocks,
+		metrics,
+	))
+
+	return &Simulator{
+		server:        httptest.NewServer(handler.NewRequestLogging(logger, mux)),
+		repository:    repository,
+		playerService: playerService,
+		avCharService: avCharService,
+		gameClocks:    gameClocks,
+	}, nil
+}
+
+// |PRIVATE| //
+
+func (s *Simulator) connect(gameUUID uuid.UUID, name string, token string) (*Player, error) {
+	client, err := wsclient.Dial(s.URL(), gameUUID, token)
+	if err != nil {
+		return nil, fmt.Errorf("%v connecting; %w", name, err)
+	}
+	s.mu.Lock()
+	s.clients = append(s.clients, client)
+	s.mu.Unlock()
+
+	players, err := s.playerService.GetWithGameUUID(gameUUID)
+	if err != nil {
+		return nil, err
+	}
+	for _, player := range *players {
+		if player.Name == name {
+			return &Player{Name: name, UUID: player.UUID, Client: client}, nil
+		}
+	}
+	return nil, fmt.Errorf("player %v is not in the game", name)
+}
+
+// Puts the letters on the rack of the player in place of the tiles it had
+func (s *Simulator) setRack(player *Player, letters string) error {
+	rack, err := s.avCharService.GetWithPlayerUUID(player.UUID)
+	if err != nil {
+		return err
+	}
+	ids := make([]int64, 0, len(*rack))
+	for _, avChar := range *rack {
+		ids = append(ids, avChar.ID)
+	}
+	if err := s.avCharService.DeleteMany(&ids); err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, letter := range letters {
+		avChar := &model.AvChar{
+			CreateDate: now,
+			UpdateDate: now,
+			PlayerUUID: player.UUID,
+			Value:      string(letter),
+		}
+		if err := s.repository.InsertAvChar(avChar); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// |PUBLIC| //
+
+// Address of the server, like http://127.0.0.1:41234
+func (s *Simulator) URL() string {
+	return s.server.URL
+}
+
+// Database of the server, for checking what the game left behind
+func (s *Simulator) Repository() repo.Repository {
+	return s.repository
+}
+
+// Creates the game with the rules of the home page form and connects the
+// players. The first one creates the game, the others join it.
+func (s *Simulator) StartGame(rules url.Values, names ...string) (*Game, error) {
+	if len(names) == 0 {
+		return nil, errors.New("game needs at least one player")
+	}
+	gameUUID, token, err := wsclient.Create(s.URL(), rules, names[0])
+	if err != nil {
+		return nil, err
+	}
+	game := &Game{UUID: gameUUID, sim: s}
+	for i, name := range names {
+		if i > 0 {
+			token, err = wsclient.Join(s.URL(), gameUUID, name)
+			if err != nil {
+				return nil, fmt.Errorf("%v joining; %w", name, err)
+			}
+		}
+		player, err := s.connect(gameUUID, name, token)
+		if err != nil {
+			return nil, err
+		}
+		game.Players = append(game.Players, player)
+	}
+	// Players joining later are announced to the ones already connected
+	game.Settle()
+	return game, nil
+}
+
+// Closes connections of players, then the server and the database
+func (s *Simulator) Close() error {
+	s.mu.Lock()
+	for _, client := range s.clients {
+		client.Close()
+	}
+	s.clients = nil
+	s.mu.Unlock()
+
+	s.gameClocks.StopAll()
+	s.server.Close()
+	return s.repository.CloseConn()
+}
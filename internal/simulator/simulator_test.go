@@ -0,0 +1,327 @@
+package simulator
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"os"
+	"path/filepath"
+	"scrable3/internal/board"
+	"scrable3/internal/cfg"
+	"scrable3/internal/logging"
+	"scrable3/internal/model"
+	"scrable3/internal/renderer"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Words of the pack the games are played in
+var testWords = []string{"alp", "lam", "lma", "pal"}
+
+// Templates are read from the views directory of the repository
+func TestMain(m *testing.M) {
+	if err := os.Chdir("../.."); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
+
+func newSimulator(t *testing.T) *Simulator {
+	dir := t.TempDir()
+	wordsFile := filepath.Join(dir, "words.txt")
+	words := ""
+	for _, word := range testWords {
+		words += word + "\n"
+	}
+	if err := os.WriteFile(wordsFile, []byte(words), 0600); err != nil {
+		t.Fatalf("writing words failed; %v", err)
+	}
+	pack := fmt.Sprintf(`{
+		"code": "sim",
+		"name": "Simulator",
+		"alphabet": "LAMP",
+		"words_file": %q,
+		"values": {"L": 1, "A": 1, "M": 2, "P": 3},
+		"distribution": {"L": 30, "A": 30, "M": 30, "P": 30}
+	}`, wordsFile)
+	if err := os.WriteFile(filepath.Join(dir, "sim.json"), []byte(pack), 0600); err != nil {
+		t.Fatalf("writing pack failed; %v", err)
+	}
+
+	config := cfg.Default()
+	config.Langs.Dir = dir
+	config.Game.Language = "sim"
+	sim, err := New(&config, logging.Discard())
+	if err != nil {
+		t.Fatalf("starting simulator failed; %v", err)
+	}
+	t.Cleanup(func() { sim.Close() })
+	return sim
+}
+
+// 5x5x5 cube with L in the middle
+var rules = url.Values{"board_size": {"5"}}
+
+func fieldPositions(t *testing.T, sim *Simulator, game *Game) map[board.Pos]string {
+	fields, err := sim.Repository().SelectFieldsByGameID(game.UUID)
+	if err != nil {
+		t.Fatalf("selecting fields failed; %v", err)
+	}
+	positions := make(map[board.Pos]string)
+	for _, field := range *fields {
+		positions[board.Pos{field.PosX, field.PosY, field.PosZ}] = field.Value
+	}
+	return positions
+}
+
+func players(t *testing.T, sim *Simulator, game *Game) map[string]model.Player {
+	selected, err := sim.Repository().SelectPlayersByGameID(game.UUID)
+	if err != nil {
+		t.Fatalf("selecting players failed; %v", err)
+	}
+	byName := make(map[string]model.Player)
+	for _, player := range *selected {
+		byName[player.Name] = player
+	}
+	return byName
+}
+
+// ALP played through the middle from every side lands where the side shows it
+func TestSides(t *testing.T) {
+	sim := newSimulator(t)
+	testCases := []struct {
+		side       int
+		horizontal bool
+		a          board.Pos
+		p          board.Pos
+	}{
+		{0, true, board.Pos{2, 1, 2}, board.Pos{2, 3, 2}},
+		{0, false, board.Pos{1, 2, 2}, board.Pos{3, 2, 2}},
+		{90, true, board.Pos{2, 2, 3}, board.Pos{2, 2, 1}},
+		{90, false, board.Pos{1, 2, 2}, board.Pos{3, 2, 2}},
+		{180, true, board.Pos{2, 3, 2}, board.Pos{2, 1, 2}},
+		{180, false, board.Pos{1, 2, 2}, board.Pos{3, 2, 2}},
+		{270, true, board.Pos{2, 2, 1}, board.Pos{2, 2, 3}},
+		{270, false, board.Pos{1, 2, 2}, board.Pos{3, 2, 2}},
+		{board.SideTop, true, board.Pos{2, 1, 2}, board.Pos{2, 3, 2}},
+		{board.SideTop, false, board.Pos{2, 2, 3}, board.Pos{2, 2, 1}},
+		{board.SideBottom, true, board.Pos{2, 1, 2}, board.Pos{2, 3, 2}},
+		{board.SideBottom, false, board.Pos{2, 2, 1}, board.Pos{2, 2, 3}},
+	}
+	for _, tc := range testCases {
+		name := renderer.SideName(tc.side) + " vertical"
+		if tc.horizontal {
+			name = renderer.SideName(tc.side) + " horizontal"
+		}
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			game, err := sim.StartGame(rules, "Ala")
+			if err != nil {
+				t.Fatalf("starting game failed; %v", err)
+			}
+			column, row := 2, 1
+			if tc.horizontal {
+				column, row = 1, 2
+			}
+			err = game.Run([]Move{{
+				Rack: "ALP", Side: tc.side, Horizontal: tc.horizontal,
+				Column: column, Row: row, Word: "ALP",
+			}})
+			if err != nil {
+				t.Fatalf("%v\n%v", err, game.Board(0))
+			}
+
+			expected := map[board.Pos]string{{2, 2, 2}: "L", tc.a: "A", tc.p: "P"}
+			if positions := fieldPositions(t, sim, game); !maps.Equal(positions, expected) {
+				t.Errorf("expected fields %v, got %v\n%v", expected, positions, game.Board(0))
+			}
+		})
+	}
+}
+
+func TestGame(t *testing.T) {
+	sim := newSimulator(t)
+	game, err := sim.StartGame(rules, "Ala", "Ola")
+	if err != nil {
+		t.Fatalf("starting game failed; %v", err)
+	}
+
+	err = game.Run([]Move{
+		{Player: 1, Rack: "ALP", Side: 0, Column: 2, Row: 1, Word: "ALP", Error: "it is Ala's turn"},
+		{Player: 0, Rack: "ALP", Side: 0, Horizontal: true, Column: 2, Row: 2, Word: "LPA",
+			Error: "not on words list"},
+		{Player: 0, Side: 0, Column: 2, Row: 1, Word: "ALP"},
+		// From the top A is seen first, one row above the middle
+		{Player: 1, Rack: "LMA", Side: board.SideTop, Horizontal: true, Column: 0, Row: 2, Word: "LMA"},
+		{Player: 0, Rack: "AM", Side: 0, Column: 0, Row: 1, Word: "LAM"},
+		{Player: 1, Rack: "PAL", Side: 0, Horizontal: true, Column: 0, Row: 4, Word: "PAL",
+			Error: "no existing characters"},
+	})
+	if err != nil {
+		t.Fatalf("%v\n%v", err, game.Board(0))
+	}
+
+	expected := map[board.Pos]string{
+		{2, 2, 2}: "L",
+		{1, 2, 2}: "A", {3, 2, 2}: "P",
+		{1, 0, 2}: "L", {1, 1, 2}: "M",
+		{2, 0, 2}: "A", {3, 0, 2}: "M",
+	}
+	if positions := fieldPositions(t, sim, game); !maps.Equal(positions, expected) {
+		t.Errorf("expected fields %v, got %v\n%v", expected, positions, game.Board(0))
+	}
+	// Both players see the board the database holds
+	for i := range game.Players {
+		if board := game.Board(i); board != game.Board(0) {
+			t.Errorf("%v sees\n%v\ninstead of\n%v", game.Players[i].Name, board, game.Board(0))
+		}
+	}
+
+	byName := players(t, sim, game)
+	if byName["Ala"].Points != 9 || byName["Ola"].Points != 4 {
+		t.Errorf("expected 9 and 4 points, got %v and %v", byName["Ala"].Points, byName["Ola"].Points)
+	}
+	stored, err := sim.Repository().SelectGameByUUID(game.UUID)
+	if err != nil {
+		t.Fatalf("selecting game failed; %v", err)
+	}
+	if stored.TurnPlayerUUID.UUID != byName["Ola"].UUID {
+		t.Errorf("expected Ola's turn, got %v", stored.TurnPlayerUUID)
+	}
+	plays, err := sim.Repository().SelectPlaysByGameID(game.UUID)
+	if err != nil {
+		t.Fatalf("selecting plays failed; %v", err)
+	}
+	words := make([]string, 0, len(*plays))
+	for _, play := range *plays {
+		words = append(words, play.Word)
+	}
+	if !slices.Equal(words, []string{"ALP", "LMA", "LAM"}) {
+		t.Errorf("expected plays ALP, LMA and LAM, got %v", words)
+	}
+}
+
+func TestUndo(t *testing.T) {
+	sim := newSimulator(t)
+	game, err := sim.StartGame(rules, "Ala", "Ola")
+	if err != nil {
+		t.Fatalf("starting game failed; %v", err)
+	}
+	err = game.Run([]Move{{Player: 0, Rack: "ALP", Side: 90, Column: 2, Row: 1, Word: "ALP"}})
+	if err != nil {
+		t.Fatalf("%v\n%v", err, game.Board(0))
+	}
+
+	updates, err := game.Send(0, "undoPlay")
+	if err != nil {
+		t.Fatalf("asking for undo failed; %v", err)
+	}
+	if updates[1].Notice != "Ala wants to take back ALP" {
+		t.Errorf("expected undo request for Ola, got %+v", updates[1])
+	}
+	updates, err = game.Send(1, "acceptUndo")
+	if err != nil {
+		t.Fatalf("accepting undo failed; %v", err)
+	}
+	for i, update := range updates {
+		if !update.Fields || update.Error != "" {
+			t.Errorf("expected %v to see fields removed, got %+v", game.Players[i].Name, update)
+		}
+	}
+
+	expected := map[board.Pos]string{{2, 2, 2}: "L"}
+	if positions := fieldPositions(t, sim, game); !maps.Equal(positions, expected) {
+		t.Errorf("expected fields %v, got %v", expected, positions)
+	}
+	if board := game.Board(1); board != game.Board(0) {
+		t.Errorf("Ola sees\n%v\ninstead of\n%v", board, game.Board(0))
+	}
+	if points := players(t, sim, game)["Ala"].Points; points != 0 {
+		t.Errorf("expected points taken back, got %v", points)
+	}
+}
+
+func TestWin(t *testing.T) {
+	sim := newSimulator(t)
+	winRules := url.Values{"board_size": {"5"}, "points_to_win": {"5"}}
+	game, err := sim.StartGame(winRules, "Ala", "Ola")
+	if err != nil {
+		t.Fatalf("starting game failed; %v", err)
+	}
+	err = game.Run([]Move{{Player: 0, Rack: "AM", Side: 0, Column: 2, Row: 2, Word: "LAM"}})
+	if err != nil {
+		t.Fatalf("%v\n%v", err, game.Board(0))
+	}
+	stored, err := sim.Repository().SelectGameByUUID(game.UUID)
+	if err != nil {
+		t.Fatalf("selecting game failed; %v", err)
+	}
+	if stored.IsFinished() {
+		t.Fatalf("game finished with %v points", players(t, sim, game)["Ala"].Points)
+	}
+
+	// PAL through the A of LAM gives Ola 5 points
+	if err := game.SetRack(1, "PL"); err != nil {
+		t.Fatalf("setting rack failed; %v", err)
+	}
+	updates, err := game.Play(1, 0, true, 1, 3, "PAL")
+	if err != nil {
+		t.Fatalf("playing failed; %v", err)
+	}
+	for i, update := range updates {
+		if update.Notice != "Ola won the game with 5 points" {
+			t.Errorf("expected %v to see Ola win, got %+v", game.Players[i].Name, update)
+		}
+	}
+	stored, err = sim.Repository().SelectGameByUUID(game.UUID)
+	if err != nil {
+		t.Fatalf("selecting game failed; %v", err)
+	}
+	if !stored.IsFinished() || stored.ForfeitPlayerUUID.Valid {
+		t.Errorf("expected game won, got finish date %v and forfeit %v",
+			stored.FinishDate, stored.ForfeitPlayerUUID)
+	}
+}
+
+// Forfeited games take no more actions from any player
+func TestForfeitedGame(t *testing.T) {
+	sim := newSimulator(t)
+	challengeRules := url.Values{"board_size": {"5"}, "challenge_mode": {"true"}}
+	game, err := sim.StartGame(challengeRules, "Ala", "Ola")
+	if err != nil {
+		t.Fatalf("starting game failed; %v", err)
+	}
+	err = game.Run([]Move{{Player: 0, Rack: "AP", Side: 0, Column: 2, Row: 1, Word: "ALP"}})
+	if err != nil {
+		t.Fatalf("%v\n%v", err, game.Board(0))
+	}
+
+	// Game as the forfeit of Ola leaves it
+	stored, err := sim.Repository().SelectGameByUUID(game.UUID)
+	if err != nil {
+		t.Fatalf("selecting game failed; %v", err)
+	}
+	stored.FinishDate = time.Now()
+	stored.ForfeitPlayerUUID = uuid.NullUUID{UUID: game.Players[1].UUID, Valid: true}
+	if err := sim.Repository().UpdateGame(stored); err != nil {
+		t.Fatalf("updating game failed; %v", err)
+	}
+
+	err = game.Run([]Move{
+		{Player: 1, Rack: "LM", Side: 0, Horizontal: true, Column: 1, Row: 1, Word: "LAM",
+			Error: "game is finished"},
+		{Player: 1, Action: "challenge", Error: "game is finished"},
+		{Player: 0, Action: "undoPlay", Error: "game is finished"},
+	})
+	if err != nil {
+		t.Fatalf("%v\n%v", err, game.Board(0))
+	}
+	expected := map[board.Pos]string{{2, 2, 2}: "L", {1, 2, 2}: "A", {3, 2, 2}: "P"}
+	if positions := fieldPositions(t, sim, game); !maps.Equal(positions, expected) {
+		t.Errorf("expected fields %v, got %v", expected, positions)
+	}
+}
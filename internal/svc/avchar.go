@@ -1,46 +1,117 @@
 package svc
 
 import (
+	"cmp"
+	"maps"
+	"math/rand/v2"
 	"scrable3/internal/model"
 	"scrable3/internal/repo"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type AvCharService interface {
-	CreateMany(player *model.Player, n int) (*[]model.AvChar, error)
+	CreateMany(
+		player *model.Player,
+		n int,
+		distribution map[string]int,
+	) (*[]model.AvChar, error)
 	GetWithPlayerUUID(playerUUID uuid.UUID) (*[]model.AvChar, error)
 	DeleteMany(charsIDs *[]int64) error
+	// Puts tiles taken back from the board on the rack of the player
+	ReturnMany(
+		player *model.Player,
+		values []string,
+		rackSize int,
+	) (*[]model.AvChar, error)
 }
 
 type avCharService struct {
 	repository repo.Repository
+	// Returns random number in [0, n)
+	intN func(n int) int
 }
 
 func NewAvCharService(r repo.Repository) AvCharService {
 	return &avCharService{
 		repository: r,
+		intN:       rand.IntN,
 	}
 }
 
-var n = -1
+// Counts tiles left in the bag of the game. Tiles on the board and on the racks
+// of all players are out of the bag.
+func (service *avCharService) tilesInBag(
+	gameUUID uuid.UUID,
+	distribution map[string]int,
+) (map[string]int, error) {
+	bag := maps.Clone(distribution)
 
-func (service *avCharService) drawValue() string {
-	// randomIndex := rand.Intn(len(cfg.ALLOWED_CHARACTERS))
-	// return string(cfg.ALLOWED_CHARACTERS[randomIndex])
-	s := "LAMPLAMPLAMPX"
-	n += 1
-	return string(s[n%len(s)])
+	fields, err := service.repository.SelectFieldsByGameID(gameUUID)
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range *fields {
+		bag[field.Value] -= 1
+	}
+
+	avChars, err := service.repository.SelectAvCharsByGameID(gameUUID)
+	if err != nil {
+		return nil, err
+	}
+	for _, avChar := range *avChars {
+		bag[avChar.Value] -= 1
+	}
+	return bag, nil
 }
 
+// Takes random tile out of the bag, returns false when the bag is empty
+func (service *avCharService) drawValue(bag map[string]int) (string, bool) {
+	letters := make([]string, 0, len(bag))
+	total := 0
+	for letter, count := range bag {
+		if count > 0 {
+			letters = append(letters, letter)
+			total += count
+		}
+	}
+	if total == 0 {
+		return "", false
+	}
+	// Map order is random, sorting keeps draws reproducible for given numbers
+	slices.Sort(letters)
+
+	i := service.intN(total)
+	for _, letter := range letters {
+		if i < bag[letter] {
+			bag[letter] -= 1
+			return letter, true
+		}
+		i -= bag[letter]
+	}
+	return "", false
+}
+
+// Draws up to n tiles from the bag of the player's game. Fewer characters are
+// created when the bag runs out.
 func (service *avCharService) CreateMany(
 	player *model.Player,
 	n int,
+	distribution map[string]int,
 ) (*[]model.AvChar, error) {
 	avChars := []model.AvChar{}
+	bag, err := service.tilesInBag(player.GameUUID, distribution)
+	if err != nil {
+		return &avChars, err
+	}
+
 	for range n {
-		randomValue := service.drawValue()
+		randomValue, ok := service.drawValue(bag)
+		if !ok {
+			break
+		}
 		avChar := model.AvChar{
 			CreateDate: time.Now(),
 			UpdateDate: time.Now(),
@@ -73,3 +144,45 @@ func (service *avCharService) DeleteMany(charsIDs *[]int64) error {
 	}
 	return nil
 }
+
+// Tiles drawn after the play are put back into the bag, so the rack does not
+// grow over its size
+func (service *avCharService) ReturnMany(
+	player *model.Player,
+	values []string,
+	rackSize int,
+) (*[]model.AvChar, error) {
+	rack, err := service.repository.SelectAvCharsByPlayerID(player.UUID)
+	if err != nil {
+		return rack, err
+	}
+
+	// Newest tiles were drawn last
+	slices.SortFunc(*rack, func(a, b model.AvChar) int {
+		return cmp.Compare(b.ID, a.ID)
+	})
+	excess := min(len(*rack)+len(values)-rackSize, len(*rack))
+	for i := 0; i < excess; i++ {
+		if err := service.repository.DeleteAvCharByID((*rack)[i].ID); err != nil {
+			return rack, err
+		}
+	}
+	if excess > 0 {
+		*rack = (*rack)[excess:]
+	}
+	slices.Reverse(*rack)
+
+	for _, value := range values {
+		avChar := model.AvChar{
+			CreateDate: time.Now(),
+			UpdateDate: time.Now(),
+			PlayerUUID: player.UUID,
+			Value:      value,
+		}
+		if err := service.repository.InsertAvChar(&avChar); err != nil {
+			return rack, err
+		}
+		*rack = append(*rack, avChar)
+	}
+	return rack, nil
+}
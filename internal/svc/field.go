@@ -25,6 +25,12 @@ type FieldService interface {
 	) (*[]model.Field, error)
 	GetWithGameUUID(gameUUID uuid.UUID) (*[]model.Field, error)
 	Delete(field *model.Field) error
+	// Removes fields placed by the player in a single play
+	DeleteWithAppendNum(
+		gameUUID uuid.UUID,
+		playerUUID uuid.UUID,
+		playerAppendNum int,
+	) (*[]model.Field, error)
 	// CreateInitialData(
 	// 	game *model.Game,
 	// 	player *model.Player,
@@ -97,3 +103,25 @@ func (service *fieldService) GetWithGameUUID(gameUUID uuid.UUID) (*[]model.Field
 func (service *fieldService) Delete(field *model.Field) error {
 	return service.repository.DeleteField(field)
 }
+
+func (service *fieldService) DeleteWithAppendNum(
+	gameUUID uuid.UUID,
+	playerUUID uuid.UUID,
+	playerAppendNum int,
+) (*[]model.Field, error) {
+	deleted := []model.Field{}
+	fields, err := service.repository.SelectFieldsByGameID(gameUUID)
+	if err != nil {
+		return &deleted, err
+	}
+	for _, field := range *fields {
+		if field.PlayerUUID != playerUUID || field.AppendNum != playerAppendNum {
+			continue
+		}
+		if err := service.Delete(&field); err != nil {
+			return &deleted, err
+		}
+		deleted = append(deleted, field)
+	}
+	return &deleted, nil
+}
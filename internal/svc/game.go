@@ -1,42 +1,108 @@
 package svc
 
 import (
+	"errors"
+	"scrable3/internal/cfg"
+	"scrable3/internal/lang"
 	"scrable3/internal/model"
 	"scrable3/internal/repo"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type GameService interface {
-	Create() (*model.Game, error)
+	DefaultRules() model.GameRules
+	Languages() []*lang.Pack
+	Create(rules model.GameRules) (*model.Game, error)
 	GetWithUUID(gameUUID uuid.UUID) (*model.Game, error)
+	GetWithUserUUID(userUUID uuid.UUID) (*[]model.Game, error)
+	GetUnfinished() (*[]model.Game, error)
+	// All games, recently updated first
+	GetAll() (*[]model.Game, error)
 	Update(game *model.Game) error
 	Delete(game *model.Game) error
 	Refresh(game *model.Game) error
+	// Returns the player that makes the next play
+	CurrentPlayer(game *model.Game) (*model.Player, error)
+	// Passes the turn to the next player, charging the time of the turn to the
+	// bank of the current player
+	NextTurn(game *model.Game) error
+	// Gives the turn back to the player whose play was taken back
+	UndoTurn(game *model.Game, player *model.Player) error
+	// Players that joined the game in the order they take turns
+	TurnOrder(gameUUID uuid.UUID) ([]model.Player, error)
+	// Finishes the game won by the player that reached the points to win
+	Finish(game *model.Game) error
+	// Finishes the game lost by the player that stopped playing
+	Forfeit(game *model.Game, player *model.Player) error
 }
 
 type gameService struct {
 	repository repo.Repository
+	config     cfg.GameConfig
+	packs      lang.Packs
 }
 
-func NewGameService(r repo.Repository) GameService {
+func NewGameService(
+	r repo.Repository,
+	config cfg.GameConfig,
+	packs lang.Packs,
+) GameService {
 	return &gameService{
 		repository: r,
+		config:     config,
+		packs:      packs,
 	}
 }
 
-func (service *gameService) Create() (*model.Game, error) {
+// Rules from the configuration, offered in the create game form
+func (service *gameService) DefaultRules() model.GameRules {
+	rules := model.GameRules{
+		BoardSize:      service.config.BoardSize,
+		RackSize:       service.config.RackSize,
+		MinWordLen:     service.config.MinWordLen,
+		PointsToWin:    service.config.PointsToWin,
+		TimeControl:    time.Duration(service.config.TimeControl),
+		TimeBank:       time.Duration(service.config.TimeBank),
+		TimeIncrement:  time.Duration(service.config.TimeIncrement),
+		ChallengeMode:  service.config.ChallengeMode,
+		Correspondence: service.config.Correspondence,
+		Language:       service.config.Language,
+	}
+	if pack, err := service.packs.Get(rules.Language); err == nil {
+		rules.Alphabet = pack.Alphabet
+	}
+	return rules
+}
+
+// Language packs that games can be created with
+func (service *gameService) Languages() []*lang.Pack {
+	return service.packs.Sorted()
+}
+
+// Creates game with the rules, alphabet is taken from the language pack
+func (service *gameService) Create(rules model.GameRules) (*model.Game, error) {
+	pack, err := service.packs.Get(rules.Language)
+	if err != nil {
+		return nil, err
+	}
+	rules.Alphabet = pack.Alphabet
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
 	newUUID, err := uuid.NewUUID()
 	if err != nil {
 		return nil, err
 	}
 	game := &model.Game{
-		UUID:        newUUID,
-		CreateDate:  time.Now(),
-		UpdateDate:  time.Now(),
-		Turn:        int(0),
-		PointsToWin: 20,
+		UUID:          newUUID,
+		CreateDate:    time.Now(),
+		UpdateDate:    time.Now(),
+		Turn:          int(0),
+		TurnStartDate: time.Now(),
+		Rules:         rules,
 	}
 	err = service.repository.InsertGame(game)
 	return game, err
@@ -49,6 +115,23 @@ func (service *gameService) GetWithUUID(
 	return game, err
 }
 
+func (service *gameService) GetWithUserUUID(
+	userUUID uuid.UUID,
+) (*[]model.Game, error) {
+	games, err := service.repository.SelectGamesByUserID(userUUID)
+	return games, err
+}
+
+func (service *gameService) GetUnfinished() (*[]model.Game, error) {
+	games, err := service.repository.SelectUnfinishedGames()
+	return games, err
+}
+
+func (service *gameService) GetAll() (*[]model.Game, error) {
+	games, err := service.repository.SelectGames()
+	return games, err
+}
+
 func (service *gameService) Update(game *model.Game) error {
 	game.UpdateDate = time.Now()
 	err := service.repository.UpdateGame(game)
@@ -68,3 +151,120 @@ func (service *gameService) Refresh(game *model.Game) error {
 	*game = *newGame
 	return nil
 }
+
+// Players take turns in the order they joined the game. Players that did not
+// pick a name yet are not in the game.
+func (service *gameService) TurnOrder(gameUUID uuid.UUID) ([]model.Player, error) {
+	players, err := service.repository.SelectPlayersByGameID(gameUUID)
+	if err != nil {
+		return nil, err
+	}
+	order := make([]model.Player, 0, len(*players))
+	for _, player := range *players {
+		if player.Name != "" {
+			order = append(order, player)
+		}
+	}
+	if len(order) == 0 {
+		return nil, errors.New("no players joined the game")
+	}
+	return order, nil
+}
+
+func (service *gameService) CurrentPlayer(game *model.Game) (*model.Player, error) {
+	order, err := service.TurnOrder(game.UUID)
+	if err != nil {
+		return nil, err
+	}
+	for _, player := range order {
+		if game.TurnPlayerUUID.Valid && player.UUID == game.TurnPlayerUUID.UUID {
+			return &player, nil
+		}
+	}
+	return &order[0], nil
+}
+
+// Takes the time of the current turn from the bank of the player and adds the
+// increment. Games without a bank do not keep time of players.
+func (service *gameService) chargeTime(
+	game *model.Game,
+	player *model.Player,
+	now time.Time,
+) error {
+	if game.Rules.TimeBank == 0 {
+		return nil
+	}
+	if !game.TurnStartDate.IsZero() {
+		player.TimeLeft = max(player.TimeLeft-now.Sub(game.TurnStartDate), 0)
+	}
+	player.TimeLeft += game.Rules.TimeIncrement
+	player.UpdateDate = now
+	return service.repository.UpdatePlayer(player)
+}
+
+// Players that lost their turn are skipped once
+func (service *gameService) NextTurn(game *model.Game) error {
+	current, err := service.CurrentPlayer(game)
+	if err != nil {
+		return err
+	}
+	order, err := service.TurnOrder(game.UUID)
+	if err != nil {
+		return err
+	}
+	currentIdx := slices.IndexFunc(order, func(p model.Player) bool {
+		return p.UUID == current.UUID
+	})
+	now := time.Now()
+	if err := service.chargeTime(game, current, now); err != nil {
+		return err
+	}
+	// The charged bank is stored already, players later in the order must not
+	// overwrite it with their copy
+	order[currentIdx] = *current
+
+	next := order[(currentIdx+1)%len(order)]
+	for i := 1; i <= len(order); i++ {
+		player := order[(currentIdx+i)%len(order)]
+		if !player.SkipTurn {
+			next = player
+			break
+		}
+		player.SkipTurn = false
+		player.UpdateDate = time.Now()
+		if err := service.repository.UpdatePlayer(&player); err != nil {
+			return err
+		}
+	}
+
+	game.Turn += 1
+	game.TurnPlayerUUID = uuid.NullUUID{UUID: next.UUID, Valid: true}
+	game.TurnStartDate = now
+	return service.Update(game)
+}
+
+// The clock of the player starts again from the moment the play is taken back
+func (service *gameService) UndoTurn(game *model.Game, player *model.Player) error {
+	if game.Turn > 0 {
+		game.Turn -= 1
+	}
+	game.TurnPlayerUUID = uuid.NullUUID{UUID: player.UUID, Valid: true}
+	game.TurnStartDate = time.Now()
+	return service.Update(game)
+}
+
+func (service *gameService) Finish(game *model.Game) error {
+	if game.IsFinished() {
+		return errors.New("game is already finished")
+	}
+	game.FinishDate = time.Now()
+	return service.Update(game)
+}
+
+func (service *gameService) Forfeit(game *model.Game, player *model.Player) error {
+	if game.IsFinished() {
+		return errors.New("game is already finished")
+	}
+	game.ForfeitPlayerUUID = uuid.NullUUID{UUID: player.UUID, Valid: true}
+	return service.Finish(game)
+}
@@ -0,0 +1,159 @@
+package svc
+
+import (
+	"errors"
+	"fmt"
+	"scrable3/internal/lang"
+	"scrable3/internal/model"
+	"scrable3/internal/repo"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// House rule words lists of games. Only the game creator can edit them and
+// only before the first word is played.
+type GameWordService interface {
+	GetWithGameUUID(gameUUID uuid.UUID) (*[]model.GameWord, error)
+	// Returns error when the player cannot edit words lists of the game
+	CheckEditable(game *model.Game, player *model.Player) error
+	// Puts the word on the allow-list or the block-list of the game, moving it
+	// from the other list when it is already there
+	Set(
+		game *model.Game,
+		player *model.Player,
+		word string,
+		allowed bool,
+	) (*model.GameWord, error)
+	Remove(game *model.Game, player *model.Player, word string) error
+}
+
+var (
+	ErrNotGameCreator = errors.New("only the game creator can edit words lists")
+	ErrGameStarted    = errors.New("words lists cannot be edited after the game started")
+)
+
+type gameWordService struct {
+	repository repo.Repository
+}
+
+func NewGameWordService(r repo.Repository) GameWordService {
+	return &gameWordService{
+		repository: r,
+	}
+}
+
+// Normalizes the word and checks if it can be built from the game alphabet
+func (service *gameWordService) normalizeWord(
+	rules *model.GameRules,
+	word string,
+) (string, error) {
+	normalized := lang.NormalizeWord(word)
+	if normalized == "" {
+		return "", errors.New("word cannot be empty")
+	}
+	for _, letter := range normalized {
+		if !strings.ContainsRune(rules.Alphabet, unicode.ToUpper(letter)) {
+			return "", fmt.Errorf("word %v has letter %q outside of alphabet", word, letter)
+		}
+	}
+	return normalized, nil
+}
+
+func (service *gameWordService) findWord(
+	gameUUID uuid.UUID,
+	word string,
+) (*model.GameWord, error) {
+	gameWords, err := service.repository.SelectGameWordsByGameID(gameUUID)
+	if err != nil {
+		return nil, err
+	}
+	for _, gameWord := range *gameWords {
+		if gameWord.Word == word {
+			return &gameWord, nil
+		}
+	}
+	return nil, repo.ErrNotExists
+}
+
+func (service *gameWordService) GetWithGameUUID(
+	gameUUID uuid.UUID,
+) (*[]model.GameWord, error) {
+	gameWords, err := service.repository.SelectGameWordsByGameID(gameUUID)
+	return gameWords, err
+}
+
+// Checks if the player created the game and no word has been played yet. The
+// creator is the first player of the game.
+func (service *gameWordService) CheckEditable(
+	game *model.Game,
+	player *model.Player,
+) error {
+	players, err := service.repository.SelectPlayersByGameID(game.UUID)
+	if err != nil {
+		return err
+	}
+	if len(*players) == 0 || (*players)[0].UUID != player.UUID {
+		return ErrNotGameCreator
+	}
+	for _, p := range *players {
+		if p.Appends > 0 {
+			return ErrGameStarted
+		}
+	}
+	return nil
+}
+
+func (service *gameWordService) Set(
+	game *model.Game,
+	player *model.Player,
+	word string,
+	allowed bool,
+) (*model.GameWord, error) {
+	if err := service.CheckEditable(game, player); err != nil {
+		return nil, err
+	}
+	normalized, err := service.normalizeWord(&game.Rules, word)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := service.findWord(game.UUID, normalized)
+	switch {
+	case err == nil && existing.Allowed == allowed:
+		return existing, nil
+	case err == nil:
+		if err := service.repository.DeleteGameWord(existing); err != nil {
+			return nil, err
+		}
+	case !errors.Is(err, repo.ErrNotExists):
+		return nil, err
+	}
+
+	gameWord := &model.GameWord{
+		CreateDate: time.Now(),
+		UpdateDate: time.Now(),
+		GameUUID:   game.UUID,
+		Word:       normalized,
+		Allowed:    allowed,
+	}
+	err = service.repository.InsertGameWord(gameWord)
+	return gameWord, err
+}
+
+func (service *gameWordService) Remove(
+	game *model.Game,
+	player *model.Player,
+	word string,
+) error {
+	if err := service.CheckEditable(game, player); err != nil {
+		return err
+	}
+	existing, err := service.findWord(game.UUID, lang.NormalizeWord(word))
+	if err != nil {
+		return err
+	}
+	return service.repository.DeleteGameWord(existing)
+}
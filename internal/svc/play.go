@@ -0,0 +1,84 @@
+package svc
+
+import (
+	"scrable3/internal/model"
+	"scrable3/internal/repo"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PlayService interface {
+	Create(
+		player *model.Player,
+		playerAppendNum int,
+		word string,
+		points int64,
+		provisional bool,
+	) (*model.Play, error)
+	GetLast(gameUUID uuid.UUID) (*model.Play, error)
+	// Marks provisional play as checked, so it cannot be challenged anymore
+	Accept(play *model.Play) error
+	// Stores whether the author waits for opponents to approve taking the
+	// play back
+	SetUndoRequested(play *model.Play, requested bool) error
+	Delete(play *model.Play) error
+}
+
+type playService struct {
+	repository repo.Repository
+}
+
+func NewPlayService(r repo.Repository) PlayService {
+	return &playService{
+		repository: r,
+	}
+}
+
+func (service *playService) Create(
+	player *model.Player,
+	playerAppendNum int,
+	word string,
+	points int64,
+	provisional bool,
+) (*model.Play, error) {
+	play := &model.Play{
+		CreateDate:  time.Now(),
+		UpdateDate:  time.Now(),
+		GameUUID:    player.GameUUID,
+		PlayerUUID:  player.UUID,
+		AppendNum:   playerAppendNum,
+		Word:        word,
+		Points:      points,
+		Provisional: provisional,
+	}
+	err := service.repository.InsertPlay(play)
+	return play, err
+}
+
+func (service *playService) GetLast(gameUUID uuid.UUID) (*model.Play, error) {
+	play, err := service.repository.SelectLastPlayByGameID(gameUUID)
+	return play, err
+}
+
+func (service *playService) Accept(play *model.Play) error {
+	if !play.Provisional {
+		return nil
+	}
+	play.Provisional = false
+	play.UpdateDate = time.Now()
+	return service.repository.UpdatePlay(play)
+}
+
+func (service *playService) SetUndoRequested(
+	play *model.Play,
+	requested bool,
+) error {
+	play.UndoRequested = requested
+	play.UpdateDate = time.Now()
+	return service.repository.UpdatePlay(play)
+}
+
+func (service *playService) Delete(play *model.Play) error {
+	return service.repository.DeletePlay(play)
+}
@@ -1,9 +1,14 @@
 package svc
 
 import (
+	"errors"
+	"fmt"
+	"regexp"
 	"scrable3/internal/model"
 	"scrable3/internal/repo"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
@@ -11,10 +16,23 @@ import (
 type PlayerService interface {
 	Create(game *model.Game) (*model.Player, error)
 	GetWithUUID(playerUUID uuid.UUID) (*model.Player, error)
+	GetWithGameUUID(gameUUID uuid.UUID) (*[]model.Player, error)
+	GetWithGameAndUser(gameUUID uuid.UUID, user *model.User) (*model.Player, error)
+	LinkUser(player *model.Player, user *model.User) error
 	Update(player *model.Player) error
+	Rename(player *model.Player, name string, color string) error
 	Refresh(player *model.Player) error
 }
 
+const maxPlayerNameLen = 24
+
+var playerColorRegexp = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// Colours assigned to players that did not pick one
+var playerColorPalette = []string{
+	"#ef3d59", "#e17a47", "#efc958", "#4ab19d", "#3f88c5", "#9b5de5",
+}
+
 type playerService struct {
 	repository repo.Repository
 }
@@ -37,6 +55,7 @@ func (service *playerService) Create(game *model.Game) (*model.Player, error) {
 		GameUUID:   game.UUID,
 		Points:     0,
 		Appends:    0,
+		TimeLeft:   game.Rules.TimeBank,
 	}
 	err = service.repository.InsertPlayer(player)
 	return player, err
@@ -49,12 +68,72 @@ func (service *playerService) GetWithUUID(
 	return player, err
 }
 
+func (service *playerService) GetWithGameUUID(
+	gameUUID uuid.UUID,
+) (*[]model.Player, error) {
+	players, err := service.repository.SelectPlayersByGameID(gameUUID)
+	return players, err
+}
+
+func (service *playerService) GetWithGameAndUser(
+	gameUUID uuid.UUID,
+	user *model.User,
+) (*model.Player, error) {
+	player, err := service.repository.SelectPlayerByGameAndUserID(
+		gameUUID,
+		user.UUID,
+	)
+	return player, err
+}
+
+// Links the player to the user account. Players that are not named yet take
+// the user's login as their name.
+func (service *playerService) LinkUser(
+	player *model.Player,
+	user *model.User,
+) error {
+	player.UserUUID = uuid.NullUUID{UUID: user.UUID, Valid: true}
+	if player.Name == "" {
+		return service.Rename(player, user.Login, "")
+	}
+	return service.Update(player)
+}
+
 func (service *playerService) Update(player *model.Player) error {
 	player.UpdateDate = time.Now()
 	err := service.repository.UpdatePlayer(player)
 	return err
 }
 
+// Validates and stores the display name and avatar colour of the player. An
+// empty colour is replaced with one from the default palette.
+func (service *playerService) Rename(
+	player *model.Player,
+	name string,
+	color string,
+) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New("player name cannot be empty")
+	}
+	if utf8.RuneCountInString(name) > maxPlayerNameLen {
+		return fmt.Errorf(
+			"player name cannot be longer than %v characters",
+			maxPlayerNameLen,
+		)
+	}
+	if color == "" {
+		color = playerColorPalette[int(player.UUID[0])%len(playerColorPalette)]
+	}
+	if !playerColorRegexp.MatchString(color) {
+		return fmt.Errorf("player color ('%v') should be in #rrggbb format", color)
+	}
+
+	player.Name = name
+	player.Color = strings.ToLower(color)
+	return service.Update(player)
+}
+
 func (service *playerService) Refresh(player *model.Player) error {
 	newPlayer, err := service.repository.SelectPlayerByUUID(player.UUID)
 	if err != nil {
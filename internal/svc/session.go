@@ -0,0 +1,78 @@
+package svc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"scrable3/internal/model"
+	"scrable3/internal/repo"
+	"time"
+)
+
+type SessionService interface {
+	// Returns the created session together with the token that should be
+	// handed to the user. Only the hash of the token is stored.
+	Create(user *model.User) (*model.Session, string, error)
+	GetWithToken(token string) (*model.Session, error)
+	Delete(session *model.Session) error
+}
+
+const SessionDuration = 30 * 24 * time.Hour
+
+var ErrSessionExpired = errors.New("session expired")
+
+type sessionService struct {
+	repository repo.Repository
+}
+
+func NewSessionService(r repo.Repository) SessionService {
+	return &sessionService{
+		repository: r,
+	}
+}
+
+func (service *sessionService) hashToken(token string) []byte {
+	hash := sha256.Sum256([]byte(token))
+	return hash[:]
+}
+
+func (service *sessionService) Create(
+	user *model.User,
+) (*model.Session, string, error) {
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return nil, "", err
+	}
+	token := hex.EncodeToString(tokenBytes)
+
+	session := &model.Session{
+		TokenHash:  service.hashToken(token),
+		CreateDate: time.Now(),
+		UpdateDate: time.Now(),
+		UserUUID:   user.UUID,
+		ExpireDate: time.Now().Add(SessionDuration),
+	}
+	err := service.repository.InsertSession(session)
+	return session, token, err
+}
+
+func (service *sessionService) GetWithToken(
+	token string,
+) (*model.Session, error) {
+	session, err := service.repository.SelectSessionByTokenHash(
+		service.hashToken(token),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if time.Now().After(session.ExpireDate) {
+		service.repository.DeleteSession(session)
+		return nil, ErrSessionExpired
+	}
+	return session, nil
+}
+
+func (service *sessionService) Delete(session *model.Session) error {
+	return service.repository.DeleteSession(session)
+}
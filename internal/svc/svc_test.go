@@ -2,10 +2,15 @@ package svc
 
 import (
 	"errors"
+	"fmt"
+	"scrable3/internal/cfg"
 	"scrable3/internal/dto"
+	"scrable3/internal/lang"
 	"scrable3/internal/mock"
 	"scrable3/internal/model"
+	"scrable3/internal/repo"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"go.uber.org/mock/gomock"
@@ -15,6 +20,16 @@ func raiseErr(t *testing.T, service string, method string, err error) {
 	t.Errorf("%v.%v error; %v", service, method, err)
 }
 
+var testPacks = lang.Packs{
+	"en": &lang.Pack{
+		Code:         "en",
+		Name:         "English",
+		Alphabet:     "ABC",
+		Values:       map[string]int64{"A": 1, "B": 3, "C": 3},
+		Distribution: map[string]int{"A": 3, "B": 1, "C": 1},
+	},
+}
+
 func TestGameService(t *testing.T) {
 	sn := "GameService"
 	mc := gomock.NewController(t)
@@ -22,18 +37,45 @@ func TestGameService(t *testing.T) {
 
 	mockRepo := mock.NewMockRepository(mc)
 
-	gameService := NewGameService(mockRepo)
+	gameService := NewGameService(mockRepo, cfg.Default().Game, testPacks)
 
 	// *
 	mn := "Create()"
 	mockRepo.EXPECT().InsertGame(gomock.Any()).Return(nil)
 
-	createdGame, err := gameService.Create()
+	rules := gameService.DefaultRules()
+	rules.BoardSize = 9
+	rules.TimeControl = time.Minute
+	createdGame, err := gameService.Create(rules)
 	if err != nil {
 		raiseErr(t, sn, mn, err)
 	}
+	rules.Alphabet = "ABC"
+	if createdGame.Rules != rules {
+		err = errors.New("Rules not stored with the game")
+		raiseErr(t, sn, mn, err)
+	}
 	createdGameUUID := createdGame.UUID
 
+	// *
+	mn = "Create() with invalid rules"
+	invalidRules := []func(r *model.GameRules){
+		func(r *model.GameRules) { r.BoardSize = model.MaxBoardSize + 1 },
+		func(r *model.GameRules) { r.RackSize = 0 },
+		func(r *model.GameRules) { r.MinWordLen = r.BoardSize + 1 },
+		func(r *model.GameRules) { r.PointsToWin = 0 },
+		func(r *model.GameRules) { r.TimeControl = -time.Second },
+		func(r *model.GameRules) { r.Language = "xx" },
+	}
+	for i, modify := range invalidRules {
+		invalid := gameService.DefaultRules()
+		modify(&invalid)
+		if _, err := gameService.Create(invalid); err == nil {
+			err = fmt.Errorf("rules %v accepted", i)
+			raiseErr(t, sn, mn, err)
+		}
+	}
+
 	// *
 	mn = "Update()"
 	mockRepo.EXPECT().UpdateGame(gomock.Any()).Return(nil)
@@ -133,6 +175,54 @@ func TestPlayerService(t *testing.T) {
 		raiseErr(t, sn, mn, err)
 	}
 
+	// *
+	mn = "GetWithGameUUID()"
+	mockRepo.EXPECT().
+		SelectPlayersByGameID(game.UUID).
+		Return(&[]model.Player{*createdPlayer}, nil)
+
+	fetchedPlayers, err := playerService.GetWithGameUUID(game.UUID)
+	if err != nil {
+		raiseErr(t, sn, mn, err)
+	}
+	if len(*fetchedPlayers) != 1 ||
+		(*fetchedPlayers)[0].UUID != createdPlayerUUID {
+		err = errors.New("Unexpected data manipulation")
+		raiseErr(t, sn, mn, err)
+	}
+
+	// *
+	mn = "Rename()"
+	mockRepo.EXPECT().UpdatePlayer(gomock.Any()).Return(nil).Times(2)
+
+	err = playerService.Rename(createdPlayer, "  Alice ", "#ABCDEF")
+	if err != nil {
+		raiseErr(t, sn, mn, err)
+	}
+	if createdPlayer.Name != "Alice" || createdPlayer.Color != "#abcdef" {
+		err = errors.New("Unexpected data manipulation")
+		raiseErr(t, sn, mn, err)
+	}
+	err = playerService.Rename(createdPlayer, "Bob", "")
+	if err != nil {
+		raiseErr(t, sn, mn, err)
+	}
+	if createdPlayer.Color == "" {
+		err = errors.New("Default color not assigned")
+		raiseErr(t, sn, mn, err)
+	}
+	for _, invalid := range [][2]string{
+		{"", "#abcdef"},
+		{"   ", ""},
+		{"Bob", "red"},
+		{"NameThatIsWayTooLongForTheScoreboard", ""},
+	} {
+		if err = playerService.Rename(createdPlayer, invalid[0], invalid[1]); err == nil {
+			err = fmt.Errorf("%v accepted as valid name and color", invalid)
+			raiseErr(t, sn, mn, err)
+		}
+	}
+
 	// *
 	mn = "Refresh()"
 	mockRepo.EXPECT().UpdatePlayer(gomock.Any()).Return(nil)
@@ -214,6 +304,28 @@ func TestFieldService(t *testing.T) {
 	if err != nil {
 		raiseErr(t, sn, mn, err)
 	}
+
+	// *
+	mn = "DeleteWithAppendNum()"
+	other := uuid.New()
+	mockRepo.EXPECT().
+		SelectFieldsByGameID(game.UUID).
+		Return(&[]model.Field{
+			{ID: 1, PlayerUUID: player.UUID, AppendNum: 0},
+			{ID: 2, PlayerUUID: player.UUID, AppendNum: 1},
+			{ID: 3, PlayerUUID: other, AppendNum: 1},
+			{ID: 4, PlayerUUID: player.UUID, AppendNum: 1},
+		}, nil)
+	mockRepo.EXPECT().DeleteField(gomock.Any()).Return(nil).Times(2)
+
+	deletedFields, err := fieldService.DeleteWithAppendNum(game.UUID, player.UUID, 1)
+	if err != nil {
+		raiseErr(t, sn, mn, err)
+	}
+	if len(*deletedFields) != 2 || (*deletedFields)[0].ID != 2 || (*deletedFields)[1].ID != 4 {
+		err = fmt.Errorf("Wrong fields deleted; %v", *deletedFields)
+		raiseErr(t, sn, mn, err)
+	}
 }
 
 func TestAvCharService(t *testing.T) {
@@ -227,13 +339,17 @@ func TestAvCharService(t *testing.T) {
 
 	avCharService := NewAvCharService(mockRepo)
 
+	distribution := testPacks["en"].Distribution
+
 	//*
 	mn := "CreateMany()"
+	mockRepo.EXPECT().SelectFieldsByGameID(game.UUID).Return(&[]model.Field{}, nil)
+	mockRepo.EXPECT().SelectAvCharsByGameID(game.UUID).Return(&[]model.AvChar{}, nil)
 	mockRepo.EXPECT().InsertAvChar(gomock.Any()).Return(nil)
 	mockRepo.EXPECT().InsertAvChar(gomock.Any()).Return(nil)
 	mockRepo.EXPECT().InsertAvChar(gomock.Any()).Return(nil)
 
-	availableChars, err := avCharService.CreateMany(player, 3)
+	availableChars, err := avCharService.CreateMany(player, 3, distribution)
 	if err != nil {
 		raiseErr(t, sn, mn, err)
 	}
@@ -241,4 +357,530 @@ func TestAvCharService(t *testing.T) {
 		err := errors.New("Wrong number of AvChars")
 		raiseErr(t, sn, mn, err)
 	}
+
+	// *
+	mn = "CreateMany() with tiles out of the bag"
+	mockRepo.EXPECT().
+		SelectFieldsByGameID(game.UUID).
+		Return(&[]model.Field{{Value: "A"}, {Value: "A"}, {Value: "C"}}, nil)
+	mockRepo.EXPECT().
+		SelectAvCharsByGameID(game.UUID).
+		Return(&[]model.AvChar{{Value: "B"}}, nil)
+	mockRepo.EXPECT().InsertAvChar(gomock.Any()).Return(nil)
+
+	availableChars, err = avCharService.CreateMany(player, 3, distribution)
+	if err != nil {
+		raiseErr(t, sn, mn, err)
+	}
+	if len(*availableChars) != 1 || (*availableChars)[0].Value != "A" {
+		err := fmt.Errorf("Expected last A tile, got %v", *availableChars)
+		raiseErr(t, sn, mn, err)
+	}
+	if distribution["A"] != 3 {
+		err := errors.New("Drawing tiles changed the distribution")
+		raiseErr(t, sn, mn, err)
+	}
+
+	// *
+	mn = "ReturnMany()"
+	mockRepo.EXPECT().
+		SelectAvCharsByPlayerID(player.UUID).
+		Return(&[]model.AvChar{{ID: 5}, {ID: 9}, {ID: 7}}, nil)
+	// Two tiles drawn after the play go back into the bag
+	mockRepo.EXPECT().DeleteAvCharByID(int64(9)).Return(nil)
+	mockRepo.EXPECT().DeleteAvCharByID(int64(7)).Return(nil)
+	mockRepo.EXPECT().InsertAvChar(gomock.Any()).Return(nil).Times(2)
+
+	rack, err := avCharService.ReturnMany(player, []string{"A", "B"}, 3)
+	if err != nil {
+		raiseErr(t, sn, mn, err)
+	}
+	if len(*rack) != 3 || (*rack)[0].ID != 5 {
+		err := fmt.Errorf("Unexpected rack %v", *rack)
+		raiseErr(t, sn, mn, err)
+	}
+}
+
+func TestGameServiceTurns(t *testing.T) {
+	sn := "GameService"
+	mc := gomock.NewController(t)
+	defer mc.Finish()
+
+	mockRepo := mock.NewMockRepository(mc)
+	gameService := NewGameService(mockRepo, cfg.Default().Game, testPacks)
+
+	game := &model.Game{UUID: uuid.New()}
+	players := []model.Player{
+		{UUID: uuid.New(), Name: "Ala"},
+		{UUID: uuid.New()}, // did not join yet
+		{UUID: uuid.New(), Name: "Ola", SkipTurn: true},
+		{UUID: uuid.New(), Name: "Ela"},
+	}
+	mockRepo.EXPECT().SelectPlayersByGameID(game.UUID).Return(&players, nil).AnyTimes()
+
+	// *
+	mn := "CurrentPlayer()"
+	current, err := gameService.CurrentPlayer(game)
+	if err != nil {
+		raiseErr(t, sn, mn, err)
+	}
+	if current.Name != "Ala" {
+		err = fmt.Errorf("Expected first joined player, got %v", current.Name)
+		raiseErr(t, sn, mn, err)
+	}
+
+	// *
+	mn = "NextTurn() skipping player"
+	mockRepo.EXPECT().
+		UpdatePlayer(gomock.Any()).
+		DoAndReturn(func(player *model.Player) error {
+			if player.Name != "Ola" || player.SkipTurn {
+				t.Errorf("Unexpected player update %+v", player)
+			}
+			return nil
+		})
+	mockRepo.EXPECT().UpdateGame(game).Return(nil)
+
+	if err = gameService.NextTurn(game); err != nil {
+		raiseErr(t, sn, mn, err)
+	}
+	current, err = gameService.CurrentPlayer(game)
+	if err != nil {
+		raiseErr(t, sn, mn, err)
+	}
+	if current.Name != "Ela" || game.Turn != 1 {
+		err = fmt.Errorf("Expected Ela in turn 1, got %v in %v", current.Name, game.Turn)
+		raiseErr(t, sn, mn, err)
+	}
+
+	// *
+	mn = "NextTurn() wrapping around"
+	mockRepo.EXPECT().UpdateGame(game).Return(nil)
+
+	if err = gameService.NextTurn(game); err != nil {
+		raiseErr(t, sn, mn, err)
+	}
+	if game.TurnPlayerUUID.UUID != players[0].UUID {
+		err = errors.New("Turn not passed to the first player")
+		raiseErr(t, sn, mn, err)
+	}
+
+	// *
+	mn = "UndoTurn()"
+	mockRepo.EXPECT().UpdateGame(game).Return(nil)
+
+	if err = gameService.UndoTurn(game, &players[3]); err != nil {
+		raiseErr(t, sn, mn, err)
+	}
+	current, err = gameService.CurrentPlayer(game)
+	if err != nil {
+		raiseErr(t, sn, mn, err)
+	}
+	if current.Name != "Ela" || game.Turn != 1 {
+		err = fmt.Errorf("Expected Ela in turn 1, got %v in %v", current.Name, game.Turn)
+		raiseErr(t, sn, mn, err)
+	}
+}
+
+func TestGameServiceClock(t *testing.T) {
+	sn := "GameService"
+	mc := gomock.NewController(t)
+	defer mc.Finish()
+
+	mockRepo := mock.NewMockRepository(mc)
+	gameService := NewGameService(mockRepo, cfg.Default().Game, testPacks)
+
+	game := &model.Game{UUID: uuid.New()}
+	game.Rules.TimeBank = 5 * time.Minute
+	game.Rules.TimeIncrement = 10 * time.Second
+	game.TurnStartDate = time.Now().Add(-time.Minute)
+	players := []model.Player{
+		{UUID: uuid.New(), Name: "Ala", TimeLeft: 5 * time.Minute},
+		{UUID: uuid.New(), Name: "Ola", TimeLeft: 5 * time.Minute},
+	}
+	mockRepo.EXPECT().SelectPlayersByGameID(game.UUID).Return(&players, nil).AnyTimes()
+
+	// *
+	mn := "NextTurn() charging bank"
+	var charged *model.Player
+	mockRepo.EXPECT().
+		UpdatePlayer(gomock.Any()).
+		DoAndReturn(func(player *model.Player) error {
+			charged = player
+			return nil
+		})
+	mockRepo.EXPECT().UpdateGame(game).Return(nil)
+
+	before := time.Now()
+	if err := gameService.NextTurn(game); err != nil {
+		raiseErr(t, sn, mn, err)
+	}
+	if charged == nil || charged.Name != "Ala" {
+		raiseErr(t, sn, mn, errors.New("Bank of the current player not charged"))
+	} else if left := charged.TimeLeft; left < 4*time.Minute+9*time.Second ||
+		left > 4*time.Minute+10*time.Second {
+		err := fmt.Errorf("Expected about 4m10s left, got %v", left)
+		raiseErr(t, sn, mn, err)
+	}
+	if game.TurnStartDate.Before(before) {
+		raiseErr(t, sn, mn, errors.New("Clock of the next player not started"))
+	}
+
+	// *
+	mn = "NextTurn() with empty bank"
+	players[1].TimeLeft = 0
+	game.TurnStartDate = time.Now().Add(-time.Minute)
+	mockRepo.EXPECT().
+		UpdatePlayer(gomock.Any()).
+		DoAndReturn(func(player *model.Player) error {
+			charged = player
+			return nil
+		})
+	mockRepo.EXPECT().UpdateGame(game).Return(nil)
+
+	if err := gameService.NextTurn(game); err != nil {
+		raiseErr(t, sn, mn, err)
+	}
+	if charged.Name != "Ola" || charged.TimeLeft != game.Rules.TimeIncrement {
+		err := fmt.Errorf("Expected only increment left, got %v", charged.TimeLeft)
+		raiseErr(t, sn, mn, err)
+	}
+}
+
+func TestGameServiceForfeit(t *testing.T) {
+	sn := "GameService"
+	mc := gomock.NewController(t)
+	defer mc.Finish()
+
+	mockRepo := mock.NewMockRepository(mc)
+	gameService := NewGameService(mockRepo, cfg.Default().Game, testPacks)
+	game := &model.Game{UUID: uuid.New()}
+	player := &model.Player{UUID: uuid.New()}
+
+	// *
+	mn := "Forfeit()"
+	mockRepo.EXPECT().UpdateGame(game).Return(nil)
+	if err := gameService.Forfeit(game, player); err != nil {
+		raiseErr(t, sn, mn, err)
+	}
+	if !game.IsFinished() || game.ForfeitPlayerUUID.UUID != player.UUID {
+		raiseErr(t, sn, mn, errors.New("Game not finished by the player"))
+	}
+
+	// *
+	mn = "Forfeit() finished game"
+	if err := gameService.Forfeit(game, player); err == nil {
+		raiseErr(t, sn, mn, errors.New("Expected error, got nil"))
+	}
+}
+
+func TestGameServiceFinish(t *testing.T) {
+	sn := "GameService"
+	mc := gomock.NewController(t)
+	defer mc.Finish()
+
+	mockRepo := mock.NewMockRepository(mc)
+	gameService := NewGameService(mockRepo, cfg.Default().Game, testPacks)
+	game := &model.Game{UUID: uuid.New()}
+
+	// *
+	mn := "Finish()"
+	mockRepo.EXPECT().UpdateGame(game).Return(nil)
+	if err := gameService.Finish(game); err != nil {
+		raiseErr(t, sn, mn, err)
+	}
+	if !game.IsFinished() || game.ForfeitPlayerUUID.Valid {
+		raiseErr(t, sn, mn, errors.New("Game not finished without forfeit"))
+	}
+
+	// *
+	mn = "Finish() finished game"
+	if err := gameService.Finish(game); err == nil {
+		raiseErr(t, sn, mn, errors.New("Expected error, got nil"))
+	}
+}
+
+func TestPlayService(t *testing.T) {
+	sn := "PlayService"
+	mc := gomock.NewController(t)
+	defer mc.Finish()
+
+	mockRepo := mock.NewMockRepository(mc)
+	player := &model.Player{UUID: uuid.New(), GameUUID: uuid.New(), Appends: 2}
+
+	playService := NewPlayService(mockRepo)
+
+	// *
+	mn := "Create()"
+	mockRepo.EXPECT().InsertPlay(gomock.Any()).Return(nil)
+
+	play, err := playService.Create(player, player.Appends, "cab", 7, true)
+	if err != nil {
+		raiseErr(t, sn, mn, err)
+	}
+	if play.GameUUID != player.GameUUID || play.AppendNum != 2 || !play.Provisional {
+		err = fmt.Errorf("Unexpected play %+v", play)
+		raiseErr(t, sn, mn, err)
+	}
+
+	// *
+	mn = "Accept()"
+	mockRepo.EXPECT().UpdatePlay(play).Return(nil)
+
+	if err = playService.Accept(play); err != nil {
+		raiseErr(t, sn, mn, err)
+	}
+	if play.Provisional {
+		err = errors.New("Play still provisional")
+		raiseErr(t, sn, mn, err)
+	}
+	// Accepted play is not stored again
+	if err = playService.Accept(play); err != nil {
+		raiseErr(t, sn, mn, err)
+	}
+
+	// *
+	mn = "SetUndoRequested()"
+	mockRepo.EXPECT().UpdatePlay(play).Return(nil)
+
+	if err = playService.SetUndoRequested(play, true); err != nil {
+		raiseErr(t, sn, mn, err)
+	}
+	if !play.UndoRequested {
+		err = errors.New("Undo not requested")
+		raiseErr(t, sn, mn, err)
+	}
+
+	// *
+	mn = "GetLast()"
+	mockRepo.EXPECT().SelectLastPlayByGameID(player.GameUUID).Return(play, nil)
+
+	if last, err := playService.GetLast(player.GameUUID); err != nil || last != play {
+		raiseErr(t, sn, mn, err)
+	}
+
+	// *
+	mn = "Delete()"
+	mockRepo.EXPECT().DeletePlay(play).Return(nil)
+
+	if err = playService.Delete(play); err != nil {
+		raiseErr(t, sn, mn, err)
+	}
+}
+
+func TestUserService(t *testing.T) {
+	sn := "UserService"
+	mc := gomock.NewController(t)
+	defer mc.Finish()
+
+	mockRepo := mock.NewMockRepository(mc)
+
+	userService := NewUserService(mockRepo)
+
+	// *
+	mn := "Register()"
+	mockRepo.EXPECT().InsertUser(gomock.Any()).Return(nil)
+
+	createdUser, err := userService.Register("alice", "correct horse")
+	if err != nil {
+		raiseErr(t, sn, mn, err)
+	}
+	if string(createdUser.PasswordHash) == "correct horse" {
+		err = errors.New("Password stored as plain text")
+		raiseErr(t, sn, mn, err)
+	}
+	for _, invalid := range [][2]string{
+		{"al", "correct horse"},
+		{"alice bob", "correct horse"},
+		{"alice", "short"},
+	} {
+		if _, err = userService.Register(invalid[0], invalid[1]); err == nil {
+			err = fmt.Errorf("%v accepted as valid login and password", invalid)
+			raiseErr(t, sn, mn, err)
+		}
+	}
+	mockRepo.EXPECT().InsertUser(gomock.Any()).Return(repo.ErrDuplicate)
+	if _, err = userService.Register("alice", "correct horse"); err != ErrLoginTaken {
+		err = fmt.Errorf("Expected ErrLoginTaken, got %v", err)
+		raiseErr(t, sn, mn, err)
+	}
+
+	// *
+	mn = "Authenticate()"
+	mockRepo.EXPECT().
+		SelectUserByLogin("alice").
+		Return(createdUser, nil).
+		Times(2)
+
+	authenticatedUser, err := userService.Authenticate("alice", "correct horse")
+	if err != nil {
+		raiseErr(t, sn, mn, err)
+	}
+	if authenticatedUser.UUID != createdUser.UUID {
+		err = errors.New("Unexpected data manipulation")
+		raiseErr(t, sn, mn, err)
+	}
+	if _, err = userService.Authenticate("alice", "wrong horse"); err != ErrInvalidCredentials {
+		err = fmt.Errorf("Expected ErrInvalidCredentials, got %v", err)
+		raiseErr(t, sn, mn, err)
+	}
+	mockRepo.EXPECT().SelectUserByLogin("bob").Return(nil, repo.ErrNotExists)
+	if _, err = userService.Authenticate("bob", "correct horse"); err != ErrInvalidCredentials {
+		err = fmt.Errorf("Expected ErrInvalidCredentials, got %v", err)
+		raiseErr(t, sn, mn, err)
+	}
+}
+
+func TestSessionService(t *testing.T) {
+	sn := "SessionService"
+	mc := gomock.NewController(t)
+	defer mc.Finish()
+
+	mockRepo := mock.NewMockRepository(mc)
+	user := &model.User{UUID: uuid.UUID{}}
+
+	sessionService := NewSessionService(mockRepo)
+
+	// *
+	mn := "Create()"
+	mockRepo.EXPECT().InsertSession(gomock.Any()).Return(nil)
+
+	createdSession, token, err := sessionService.Create(user)
+	if err != nil {
+		raiseErr(t, sn, mn, err)
+	}
+	if token == "" || string(createdSession.TokenHash) == token {
+		err = errors.New("Token stored as plain text")
+		raiseErr(t, sn, mn, err)
+	}
+
+	// *
+	mn = "GetWithToken()"
+	mockRepo.EXPECT().
+		SelectSessionByTokenHash(createdSession.TokenHash).
+		Return(createdSession, nil)
+
+	fetchedSession, err := sessionService.GetWithToken(token)
+	if err != nil {
+		raiseErr(t, sn, mn, err)
+	}
+	if fetchedSession.UserUUID != user.UUID {
+		err = errors.New("Unexpected data manipulation")
+		raiseErr(t, sn, mn, err)
+	}
+
+	expiredSession := *createdSession
+	expiredSession.ExpireDate = time.Now().Add(-time.Minute)
+	mockRepo.EXPECT().
+		SelectSessionByTokenHash(createdSession.TokenHash).
+		Return(&expiredSession, nil)
+	mockRepo.EXPECT().DeleteSession(&expiredSession).Return(nil)
+
+	if _, err = sessionService.GetWithToken(token); err != ErrSessionExpired {
+		err = fmt.Errorf("Expected ErrSessionExpired, got %v", err)
+		raiseErr(t, sn, mn, err)
+	}
+
+	// *
+	mn = "Delete()"
+	mockRepo.EXPECT().DeleteSession(createdSession).Return(nil)
+
+	err = sessionService.Delete(createdSession)
+	if err != nil {
+		raiseErr(t, sn, mn, err)
+	}
+}
+
+func TestGameWordService(t *testing.T) {
+	sn := "GameWordService"
+	mc := gomock.NewController(t)
+	defer mc.Finish()
+
+	mockRepo := mock.NewMockRepository(mc)
+	game := &model.Game{UUID: uuid.New(), Rules: model.GameRules{Alphabet: "ABC"}}
+	creator := model.Player{UUID: uuid.New(), GameUUID: game.UUID}
+	guest := model.Player{UUID: uuid.New(), GameUUID: game.UUID}
+	players := []model.Player{creator, guest}
+
+	gameWordService := NewGameWordService(mockRepo)
+
+	// *
+	mn := "Set()"
+	mockRepo.EXPECT().SelectPlayersByGameID(game.UUID).Return(&players, nil)
+	mockRepo.EXPECT().
+		SelectGameWordsByGameID(game.UUID).
+		Return(&[]model.GameWord{}, nil)
+	mockRepo.EXPECT().InsertGameWord(gomock.Any()).Return(nil)
+
+	gameWord, err := gameWordService.Set(game, &creator, " Cab ", true)
+	if err != nil {
+		raiseErr(t, sn, mn, err)
+	}
+	if gameWord.Word != "cab" || !gameWord.Allowed {
+		err = fmt.Errorf("Unexpected game word %+v", gameWord)
+		raiseErr(t, sn, mn, err)
+	}
+
+	// *
+	mn = "Set() moving word to another list"
+	stored := *gameWord
+	mockRepo.EXPECT().SelectPlayersByGameID(game.UUID).Return(&players, nil)
+	mockRepo.EXPECT().
+		SelectGameWordsByGameID(game.UUID).
+		Return(&[]model.GameWord{stored}, nil)
+	mockRepo.EXPECT().DeleteGameWord(&stored).Return(nil)
+	mockRepo.EXPECT().InsertGameWord(gomock.Any()).Return(nil)
+
+	gameWord, err = gameWordService.Set(game, &creator, "CAB", false)
+	if err != nil {
+		raiseErr(t, sn, mn, err)
+	}
+	if gameWord.Allowed {
+		err = errors.New("Word not blocked")
+		raiseErr(t, sn, mn, err)
+	}
+
+	// *
+	mn = "Set() with letters outside of alphabet"
+	mockRepo.EXPECT().SelectPlayersByGameID(game.UUID).Return(&players, nil)
+
+	if _, err = gameWordService.Set(game, &creator, "abd", true); err == nil {
+		err = errors.New("Word with letter outside of alphabet accepted")
+		raiseErr(t, sn, mn, err)
+	}
+
+	// *
+	mn = "Set() by another player"
+	mockRepo.EXPECT().SelectPlayersByGameID(game.UUID).Return(&players, nil)
+
+	_, err = gameWordService.Set(game, &guest, "cab", true)
+	if !errors.Is(err, ErrNotGameCreator) {
+		err = fmt.Errorf("Expected ErrNotGameCreator, got %v", err)
+		raiseErr(t, sn, mn, err)
+	}
+
+	// *
+	mn = "Remove() after the game started"
+	startedPlayers := []model.Player{creator, guest}
+	startedPlayers[1].Appends = 1
+	mockRepo.EXPECT().SelectPlayersByGameID(game.UUID).Return(&startedPlayers, nil)
+
+	err = gameWordService.Remove(game, &creator, "cab")
+	if !errors.Is(err, ErrGameStarted) {
+		err = fmt.Errorf("Expected ErrGameStarted, got %v", err)
+		raiseErr(t, sn, mn, err)
+	}
+
+	// *
+	mn = "Remove()"
+	mockRepo.EXPECT().SelectPlayersByGameID(game.UUID).Return(&players, nil)
+	mockRepo.EXPECT().
+		SelectGameWordsByGameID(game.UUID).
+		Return(&[]model.GameWord{*gameWord}, nil)
+	mockRepo.EXPECT().DeleteGameWord(gomock.Any()).Return(nil)
+
+	err = gameWordService.Remove(game, &creator, "cab")
+	if err != nil {
+		raiseErr(t, sn, mn, err)
+	}
 }
@@ -0,0 +1,103 @@
+package svc
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"scrable3/internal/model"
+	"scrable3/internal/repo"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type UserService interface {
+	Register(login string, password string) (*model.User, error)
+	Authenticate(login string, password string) (*model.User, error)
+	GetWithUUID(userUUID uuid.UUID) (*model.User, error)
+}
+
+const minPasswordLen = 8
+
+var (
+	ErrInvalidCredentials = errors.New("invalid login or password")
+	ErrLoginTaken         = errors.New("login is already taken")
+)
+
+var loginRegexp = regexp.MustCompile(`^[a-zA-Z0-9_.-]{3,32}$`)
+
+type userService struct {
+	repository repo.Repository
+}
+
+func NewUserService(r repo.Repository) UserService {
+	return &userService{
+		repository: r,
+	}
+}
+
+func (service *userService) Register(
+	login string,
+	password string,
+) (*model.User, error) {
+	if !loginRegexp.MatchString(login) {
+		return nil, errors.New(
+			"login should have 3 to 32 letters, digits or any of '_.-'",
+		)
+	}
+	if len(password) < minPasswordLen {
+		return nil, fmt.Errorf(
+			"password should have at least %v characters", minPasswordLen,
+		)
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword(
+		[]byte(password), bcrypt.DefaultCost,
+	)
+	if err != nil {
+		return nil, err
+	}
+	newUUID, err := uuid.NewUUID()
+	if err != nil {
+		return nil, err
+	}
+	user := &model.User{
+		UUID:         newUUID,
+		CreateDate:   time.Now(),
+		UpdateDate:   time.Now(),
+		Login:        login,
+		PasswordHash: passwordHash,
+	}
+	err = service.repository.InsertUser(user)
+	if errors.Is(err, repo.ErrDuplicate) {
+		return nil, ErrLoginTaken
+	}
+	return user, err
+}
+
+func (service *userService) Authenticate(
+	login string,
+	password string,
+) (*model.User, error) {
+	user, err := service.repository.SelectUserByLogin(login)
+	if errors.Is(err, repo.ErrNotExists) {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = bcrypt.CompareHashAndPassword(user.PasswordHash, []byte(password))
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+	return user, nil
+}
+
+func (service *userService) GetWithUUID(
+	userUUID uuid.UUID,
+) (*model.User, error) {
+	user, err := service.repository.SelectUserByUUID(userUUID)
+	return user, err
+}
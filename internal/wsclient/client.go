@@ -0,0 +1,292 @@
+package wsclient
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"path"
+	"scrable3/internal/dto"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+// Player connected to a game over websocket, the same way the game page is.
+// Messages of the server are applied to the state as they come, every one of
+// them is reported on Updates.
+type Client struct {
+	conn    *websocket.Conn
+	writeMu sync.Mutex
+	mu      sync.Mutex
+	state   *State
+	updates chan Update
+	// Closed by Close, so reading does not wait for updates nobody takes
+	done      chan struct{}
+	closeOnce sync.Once
+	// Why reading stopped, set before updates are closed
+	err error
+}
+
+// Same JSON as the forms of the game page send
+type actionMessage struct {
+	Type string `json:"actionType"`
+}
+
+type playMessage struct {
+	Type string `json:"actionType"`
+	dto.PlayData
+}
+
+// |PRIVATE| //
+
+func cookieName(gameUUID uuid.UUID) string {
+	return "player-uuid-" + gameUUID.String()
+}
+
+// Client keeping cookies between requests like a browser
+func newHTTPClient() (*http.Client, error) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Jar: jar}, nil
+}
+
+func (c *Client) read() {
+	defer close(c.updates)
+	for {
+		_, message, err := c.conn.ReadMessage()
+		if err != nil {
+			c.mu.Lock()
+			c.err = err
+			c.mu.Unlock()
+			return
+		}
+		c.mu.Lock()
+		update, err := c.state.Apply(message)
+		c.mu.Unlock()
+		if err != nil {
+			update.Error = fmt.Sprintf("message not understood; %v", err)
+		}
+		select {
+		case c.updates <- update:
+		case <-c.done:
+			return
+		}
+	}
+}
+
+func (c *Client) write(v any) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.conn.WriteJSON(v)
+}
+
+// Token of the CSRF cookie set by the last page visited, pages send it back
+// in the header of forms
+func csrfToken(client *http.Client, serverURL string) (string, error) {
+	u, err := url.Parse(serverURL + "/")
+	if err != nil {
+		return "", err
+	}
+	for _, cookie := range client.Jar.Cookies(u) {
+		if cookie.Name == "csrf-token" {
+			return cookie.Value, nil
+		}
+	}
+	return "", errors.New("server did not set the csrf cookie")
+}
+
+// Sends the form with the CSRF token in the header, like htmx does
+func postForm(
+	client *http.Client,
+	serverURL string,
+	path string,
+	values url.Values,
+) (*http.Response, error) {
+	token, err := csrfToken(client, serverURL)
+	if err != nil {
+		return nil, err
+	}
+	request, err := http.NewRequest(
+		http.MethodPost, serverURL+path, strings.NewReader(values.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("X-CSRF-Token", token)
+	return client.Do(request)
+}
+
+// Visits the game and sends the name like the join form does, returns the
+// token of the player cookie
+func join(
+	client *http.Client,
+	serverURL string,
+	gameUUID uuid.UUID,
+	name string,
+) (string, error) {
+	gameURL := serverURL + "/game/" + gameUUID.String()
+	// Visiting the game creates the player, the creator already has one
+	response, err := client.Get(gameURL)
+	if err != nil {
+		return "", err
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("game page; %v", response.Status)
+	}
+
+	// The join form sends the CSRF token set by the game page
+	response, err = postForm(
+		client,
+		serverURL,
+		"/game/"+gameUUID.String()+"/player",
+		url.Values{"name": {name}},
+	)
+	if err != nil {
+		return "", err
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("join; %v", response.Status)
+	}
+	// Rejected names are sent back in place of the redirect
+	if response.Header.Get("HX-Redirect") == "" {
+		return "", fmt.Errorf("name %q was not accepted", name)
+	}
+
+	u, err := url.Parse(gameURL)
+	if err != nil {
+		return "", err
+	}
+	for _, cookie := range client.Jar.Cookies(u) {
+		if cookie.Name == cookieName(gameUUID) {
+			return cookie.Value, nil
+		}
+	}
+	return "", errors.New("server did not set the player cookie")
+}
+
+// |PUBLIC| //
+
+// Joins the game as a new player with the name. Returns the token of the
+// player cookie, which Dial takes.
+func Join(serverURL string, gameUUID uuid.UUID, name string) (string, error) {
+	client, err := newHTTPClient()
+	if err != nil {
+		return "", err
+	}
+	return join(client, strings.TrimSuffix(serverURL, "/"), gameUUID, name)
+}
+
+// Creates a game like the form of the home page, 'rules' holds the fields of
+// the form. The creator joins the game with the name.
+func Create(serverURL string, rules url.Values, name string) (uuid.UUID, string, error) {
+	client, err := newHTTPClient()
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+	serverURL = strings.TrimSuffix(serverURL, "/")
+
+	// The home page sets the CSRF cookie, the same token goes in the header
+	response, err := client.Get(serverURL + "/")
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+	response.Body.Close()
+
+	response, err = postForm(client, serverURL, "/game", rules)
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+	body, _ := io.ReadAll(response.Body)
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return uuid.Nil, "", fmt.Errorf(
+			"create game; %v %v", response.Status, strings.TrimSpace(string(body)))
+	}
+	gameUUID, err := uuid.Parse(path.Base(response.Header.Get("HX-Redirect")))
+	if err != nil {
+		return uuid.Nil, "", fmt.Errorf("create game redirect; %w", err)
+	}
+
+	playerToken, err := join(client, serverURL, gameUUID, name)
+	return gameUUID, playerToken, err
+}
+
+// Connects to the game as the player the token belongs to
+func Dial(serverURL string, gameUUID uuid.UUID, token string) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(serverURL, "/") + "/ws/" + gameUUID.String())
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "http":
+		u.Scheme = "ws"
+	case "https":
+		u.Scheme = "wss"
+	}
+	header := http.Header{}
+	header.Set("Cookie", (&http.Cookie{Name: cookieName(gameUUID), Value: token}).String())
+	conn, response, err := websocket.DefaultDialer.Dial(u.String(), header)
+	if err != nil {
+		if response != nil {
+			return nil, fmt.Errorf("%w; %v", err, response.Status)
+		}
+		return nil, err
+	}
+
+	c := &Client{
+		conn:    conn,
+		state:   NewState(),
+		updates: make(chan Update, 64),
+		done:    make(chan struct{}),
+	}
+	go c.read()
+	return c, nil
+}
+
+// Updates made by the messages of the server, closed when the connection is
+func (c *Client) Updates() <-chan Update {
+	return c.updates
+}
+
+// Calls 'f' with the state, messages are not applied until it returns
+func (c *Client) WithState(f func(s *State)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	f(c.state)
+}
+
+// Why the connection ended, nil while it is open
+func (c *Client) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// Sends an action without data, like challenge or undoPlay
+func (c *Client) Send(actionType string) error {
+	return c.write(actionMessage{Type: actionType})
+}
+
+func (c *Client) Play(play *dto.PlayData) error {
+	return c.write(playMessage{Type: "makePlay", PlayData: *play})
+}
+
+func (c *Client) Close() error {
+	c.closeOnce.Do(func() { close(c.done) })
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	c.conn.WriteMessage(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+	)
+	return c.conn.Close()
+}
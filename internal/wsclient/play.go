@@ -0,0 +1,97 @@
+package wsclient
+
+import (
+	"errors"
+	"fmt"
+	"scrable3/internal/board"
+	"scrable3/internal/dto"
+	"scrable3/internal/renderer"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// |PRIVATE| //
+
+// Tilt and rotation the game page would have when showing the side
+func sideAndTilt(side int) (int, int) {
+	switch side {
+	case board.SideTop:
+		return 0, 90
+	case board.SideBottom:
+		return 0, -90
+	default:
+		return side, 0
+	}
+}
+
+// |PUBLIC| //
+
+// Reads the side from its degrees or its name, like 90 or east
+func ParseSide(value string) (int, error) {
+	for _, side := range renderer.Sides {
+		if strings.EqualFold(value, renderer.SideName(side)) || value == strconv.Itoa(side) {
+			return side, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown side %q", value)
+}
+
+// Builds the play of the word seen from the side, starting at the column and
+// the row and going right when 'horizontal', down otherwise. Letters already
+// seen on the face are skipped, the others are taken from the rack.
+func (s *State) BuildPlay(
+	side int,
+	horizontal bool,
+	column int,
+	row int,
+	word string,
+) (*dto.PlayData, error) {
+	if !slices.Contains(renderer.Sides, side) {
+		return nil, fmt.Errorf("unknown side %v", side)
+	}
+	b, err := s.Board()
+	if err != nil {
+		return nil, err
+	}
+	surface := b.Surface(side)
+
+	used := make(map[string]bool)
+	var chars []dto.Char
+	for _, letter := range strings.ToUpper(word) {
+		value := string(letter)
+		if column < 0 || row < 0 || column >= s.Size || row >= s.Size {
+			return nil, fmt.Errorf("%v does not fit on the board", word)
+		}
+		if pos, ok := surface[[2]int{column, row}]; ok {
+			if b.At(pos) != value {
+				return nil, fmt.Errorf(
+					"%v is on %v,%v instead of %v", b.At(pos), column, row, value)
+			}
+		} else {
+			i := slices.IndexFunc(s.Rack, func(tile Tile) bool {
+				return tile.Value == value && !used[tile.ID]
+			})
+			if i == -1 {
+				return nil, fmt.Errorf("no %v on the rack", value)
+			}
+			used[s.Rack[i].ID] = true
+			chars = append(chars, dto.Char{
+				HtmlIdentifier: s.Rack[i].ID,
+				Value:          value,
+				Position:       [2]int{column, row},
+			})
+		}
+		if horizontal {
+			column++
+		} else {
+			row++
+		}
+	}
+	if len(chars) == 0 {
+		return nil, errors.New("the word is already on the board")
+	}
+
+	sideInt, tilt := sideAndTilt(side)
+	return &dto.PlayData{SideInt: sideInt, Tilt: tilt, Chars: chars}, nil
+}
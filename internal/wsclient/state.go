@@ -0,0 +1,213 @@
+package wsclient
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"scrable3/internal/board"
+	"scrable3/internal/model"
+	"scrable3/internal/renderer"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// The server answers with the htmx fragments the game page swaps in, there is
+// no JSON variant of them. Fragments are recognized by the ids of their
+// elements, the same ones the page relies on.
+var (
+	// Field id is its value followed by X, Y and Z in hex, translateZ tells
+	// the size of the cube
+	fieldRegexp = regexp.MustCompile(
+		`<div id="([^"]+)" class="inner-cube" title="[^"]*" style="[^"]*translateZ\((-?\d+)px\)`)
+	removedFieldRegexp = regexp.MustCompile(`<div id="([^"]+)" hx-swap-oob="delete">`)
+	rackTileRegexp     = regexp.MustCompile(
+		`<div id="(char-[^"]+)" class="draggable-square character">([^<]*)</div>`)
+	playerRegexp = regexp.MustCompile(
+		`<div class="player( turn)?"[^>]*>\s*<span class="player-name">([^<]*)</span>\s*` +
+			`<span class="player-points">(-?\d+)</span>(?:\s*<span class="player-clock">([^<]*)</span>)?`)
+	// Errors, notices and undo requests share the dialog
+	dialogRegexp = regexp.MustCompile(
+		`<(?:form|div) id="error-popup"( class="notice")?[^>]*>\s*<span>([^<]*)</span>`)
+)
+
+// Size of a field on the game page in pixels, see dto.NewHtmlFieldData
+const fieldSize = 60
+
+// Tile on the rack of the player
+type Tile struct {
+	// Id of the tile the server expects back in a play, like char-A12
+	ID    string
+	Value string
+}
+
+type Player struct {
+	Name    string
+	Points  int
+	HasTurn bool
+	// Empty for games without a clock
+	Clock string
+}
+
+// What a message from the server changed
+type Update struct {
+	Fields  bool
+	Rack    bool
+	Players bool
+	Error   string
+	Notice  string
+}
+
+// Game as seen by the client, built from the messages of the server
+type State struct {
+	// 0 until the first field arrives
+	Size    int
+	Rack    []Tile
+	Players []Player
+	fields  map[string]model.Field
+	// Fields get increasing ids in the order they arrive
+	lastID int64
+	// Counts messages with fields, fields of the latest one are the latest play
+	messages int
+}
+
+func NewState() *State {
+	return &State{fields: make(map[string]model.Field)}
+}
+
+// |PRIVATE| //
+
+// Reads the position from the id of the field and the size of the cube from
+// its translateZ
+func parseField(id string, translateZ string) (model.Field, int, error) {
+	var field model.Field
+	if len(id) <= 6 {
+		return field, 0, fmt.Errorf("field id %q is too short", id)
+	}
+	pos, err := strconv.ParseUint(id[len(id)-6:], 16, 32)
+	if err != nil {
+		return field, 0, fmt.Errorf("field id %q; %w", id, err)
+	}
+	z, err := strconv.Atoi(translateZ)
+	if err != nil {
+		return field, 0, fmt.Errorf("field id %q; %w", id, err)
+	}
+	field.Value = id[:len(id)-6]
+	field.PosX = int(pos >> 16 & 0xff)
+	field.PosY = int(pos >> 8 & 0xff)
+	field.PosZ = int(pos & 0xff)
+	// translateZ is (size-1)*fieldSize/2 - z*fieldSize
+	size := (z+field.PosZ*fieldSize)*2/fieldSize + 1
+	return field, size, nil
+}
+
+func (s *State) applyFields(message string, update *Update) error {
+	matches := fieldRegexp.FindAllStringSubmatch(message, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+	// Fields sent when the client connects belong to no play
+	appendNum := model.InitialAppendNum
+	if len(s.fields) > 0 {
+		s.messages++
+		appendNum = s.messages
+	}
+	for _, match := range matches {
+		id := html.UnescapeString(match[1])
+		field, size, err := parseField(id, match[2])
+		if err != nil {
+			return err
+		}
+		s.Size = size
+		s.lastID++
+		field.ID = s.lastID
+		field.AppendNum = appendNum
+		s.fields[id] = field
+	}
+	update.Fields = true
+	return nil
+}
+
+// |PUBLIC| //
+
+// Applies the message of the server to the state
+func (s *State) Apply(message []byte) (Update, error) {
+	var update Update
+	text := string(message)
+
+	if err := s.applyFields(text, &update); err != nil {
+		return update, err
+	}
+	for _, match := range removedFieldRegexp.FindAllStringSubmatch(text, -1) {
+		delete(s.fields, html.UnescapeString(match[1]))
+		update.Fields = true
+	}
+
+	if strings.Contains(text, `id="availble-characters"`) {
+		s.Rack = s.Rack[:0]
+		for _, match := range rackTileRegexp.FindAllStringSubmatch(text, -1) {
+			s.Rack = append(s.Rack, Tile{
+				ID:    html.UnescapeString(match[1]),
+				Value: html.UnescapeString(match[2]),
+			})
+		}
+		update.Rack = true
+	}
+
+	if strings.Contains(text, `id="players"`) {
+		s.Players = s.Players[:0]
+		for _, match := range playerRegexp.FindAllStringSubmatch(text, -1) {
+			points, err := strconv.Atoi(match[3])
+			if err != nil {
+				return update, err
+			}
+			s.Players = append(s.Players, Player{
+				Name:    html.UnescapeString(match[2]),
+				Points:  points,
+				HasTurn: match[1] != "",
+				Clock:   match[4],
+			})
+		}
+		update.Players = true
+	}
+
+	if match := dialogRegexp.FindStringSubmatch(text); match != nil {
+		if match[1] != "" {
+			update.Notice = html.UnescapeString(match[2])
+		} else {
+			update.Error = html.UnescapeString(match[2])
+		}
+	}
+	return update, nil
+}
+
+// Fields on the board, in the order they arrived
+func (s *State) Fields() []model.Field {
+	fields := make([]model.Field, 0, len(s.fields))
+	for _, field := range s.fields {
+		fields = append(fields, field)
+	}
+	slices.SortFunc(fields, func(a, b model.Field) int {
+		return int(a.ID - b.ID)
+	})
+	return fields
+}
+
+func (s *State) Board() (*board.Board, error) {
+	return board.FromFields(s.Size, s.Fields())
+}
+
+// Text view of the board, letters of the latest play are in brackets
+func (s *State) Text() (*renderer.Text, error) {
+	return renderer.NewText(s.Size, s.Fields())
+}
+
+// Player whose turn it is, nil when nobody has it
+func (s *State) Turn() *Player {
+	for i := range s.Players {
+		if s.Players[i].HasTurn {
+			return &s.Players[i]
+		}
+	}
+	return nil
+}
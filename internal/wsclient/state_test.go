@@ -0,0 +1,136 @@
+package wsclient
+
+import (
+	"fmt"
+	"scrable3/internal/board"
+	"strings"
+	"testing"
+)
+
+// Field fragment as views/game/field.html writes it on a board of size 5
+func fieldFragment(value string, x int, y int, z int) string {
+	return fmt.Sprintf(`<div id="outer-cube" hx-swap-oob="beforeend">
+    <div id="%v%02X%02X%02X" class="inner-cube" title="Ala" style="top: %vpx; left: %vpx; transform: translateZ(%vpx); --player-color: #3f88c5;">
+        <div class="inner-face character inner-north">%v</div>
+    </div>
+</div>`, value, x, y, z, x*60, y*60, 120-z*60, value)
+}
+
+const rackFragment = `<div id="availble-characters" hx-swap-oob="innerHTML">
+    <div id="char-M11" class="draggable-square character">M</div>
+    <div id="char-P14" class="draggable-square character">P</div>
+    <div id="char-A15" class="draggable-square character">A</div>
+</div>`
+
+const playersFragment = `<div id="players" hx-swap-oob="innerHTML">
+    <div class="player" style="--player-color: #3f88c5;">
+        <span class="player-name">Ala</span>
+        <span class="player-points">6</span>
+        <span class="player-clock">4:59</span>
+    </div>
+    <div class="player turn" style="--player-color: #ef3d59;">
+        <span class="player-name">Ola &amp; Ela</span>
+        <span class="player-points">0</span>
+        <span class="player-clock">5:00</span>
+    </div>
+</div>`
+
+func TestApply(t *testing.T) {
+	s := NewState()
+	update, err := s.Apply([]byte(rackFragment + fieldFragment("L", 2, 2, 2)))
+	if err != nil {
+		t.Fatalf("applying initial data failed; %v", err)
+	}
+	if !update.Fields || !update.Rack || update.Players {
+		t.Errorf("expected fields and rack update, got %+v", update)
+	}
+	if s.Size != 5 {
+		t.Errorf("expected size 5, got %v", s.Size)
+	}
+	if len(s.Rack) != 3 || s.Rack[1] != (Tile{ID: "char-P14", Value: "P"}) {
+		t.Errorf("unexpected rack %v", s.Rack)
+	}
+
+	_, err = s.Apply([]byte(fieldFragment("A", 3, 2, 2) + fieldFragment("M", 4, 2, 2)))
+	if err != nil {
+		t.Fatalf("applying play failed; %v", err)
+	}
+	text, err := s.Text()
+	if err != nil {
+		t.Fatalf("rendering failed; %v", err)
+	}
+	face := text.Face(0)
+	for _, line := range []string{" 2  .  .  L", " 3  .  . [A]", " 4  .  . [M]"} {
+		if !strings.Contains(face, line) {
+			t.Errorf("expected line %q in\n%v", line, face)
+		}
+	}
+
+	update, err = s.Apply([]byte(`<div id="M040202" hx-swap-oob="delete"></div>` + "\n" + playersFragment))
+	if err != nil {
+		t.Fatalf("applying undo failed; %v", err)
+	}
+	if !update.Fields || !update.Players || len(s.Fields()) != 2 {
+		t.Errorf("expected removed field, got %+v and %v", update, s.Fields())
+	}
+	if turn := s.Turn(); turn == nil || *turn != (Player{"Ola & Ela", 0, true, "5:00"}) {
+		t.Errorf("unexpected player with turn %+v in %+v", turn, s.Players)
+	}
+
+	update, err = s.Apply([]byte(`<div id="error-dialog" hx-swap-oob="morphdom">
+    <form id="error-popup" hx-vals='{"actionType": "dismissError"}' ws-send>
+        <span>not your turn</span>`))
+	if err != nil || update.Error != "not your turn" || update.Notice != "" {
+		t.Errorf("expected error, got %+v; %v", update, err)
+	}
+	update, err = s.Apply([]byte(`<div id="error-dialog" hx-swap-oob="morphdom">
+    <div id="error-popup" class="notice">
+        <span>Ala wants to take back LAM</span>`))
+	if err != nil || update.Notice != "Ala wants to take back LAM" || update.Error != "" {
+		t.Errorf("expected notice, got %+v; %v", update, err)
+	}
+}
+
+func TestBuildPlay(t *testing.T) {
+	s := NewState()
+	if _, err := s.Apply([]byte(rackFragment + fieldFragment("L", 2, 2, 2))); err != nil {
+		t.Fatalf("applying initial data failed; %v", err)
+	}
+
+	// From the east L is seen on column 2, row 2
+	play, err := s.BuildPlay(90, false, 2, 1, "alm")
+	if err != nil {
+		t.Fatalf("building play failed; %v", err)
+	}
+	if play.SideInt != 90 || play.Tilt != 0 || len(play.Chars) != 2 {
+		t.Fatalf("unexpected play %+v", play)
+	}
+	if play.Chars[0].HtmlIdentifier != "char-A15" || play.Chars[0].Position != [2]int{2, 1} ||
+		play.Chars[1].HtmlIdentifier != "char-M11" || play.Chars[1].Position != [2]int{2, 3} {
+		t.Errorf("unexpected chars %+v", play.Chars)
+	}
+
+	play, err = s.BuildPlay(board.SideTop, true, 2, 2, "LA")
+	if err != nil {
+		t.Fatalf("building play failed; %v", err)
+	}
+	if play.SideInt != 0 || play.Tilt != 90 {
+		t.Errorf("expected tilt to the top, got %+v", play)
+	}
+
+	testCases := []struct {
+		column int
+		row    int
+		word   string
+	}{
+		{2, 2, "M"},   // L is there already
+		{3, 2, "MAP"}, // off the board
+		{2, 1, "ALL"}, // no L on the rack
+		{2, 2, "L"},   // no tile from the rack
+	}
+	for _, tc := range testCases {
+		if _, err := s.BuildPlay(0, true, tc.column, tc.row, tc.word); err == nil {
+			t.Errorf("%v at %v,%v; expected error, got nil", tc.word, tc.column, tc.row)
+		}
+	}
+}
@@ -1,73 +1,386 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
 	"database/sql"
+	"errors"
+	"flag"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
+	"scrable3/internal/auth"
+	"scrable3/internal/cfg"
 	"scrable3/internal/ctrl"
 	"scrable3/internal/handler"
+	"scrable3/internal/janitor"
+	"scrable3/internal/lang"
+	"scrable3/internal/logging"
+	"scrable3/internal/metrics"
+	"scrable3/internal/notify"
 	"scrable3/internal/repo"
 	"scrable3/internal/svc"
+	"syscall"
+	"time"
 )
 
-const port string = ":8080"
-const databaseFile = "sqlite.db"
+// Parses configured player token signing keys, where the first key signs new
+// tokens. Without them a random key is used, so tokens do not survive
+// a restart.
+func loadPlayerTokenKeys(config cfg.AuthConfig, logger *slog.Logger) ([]auth.Key, error) {
+	keys, err := auth.ParseKeys(config.PlayerTokenKeys)
+	if err != nil || len(keys) > 0 {
+		return keys, err
+	}
+
+	logger.Warn("player token keys are not configured, using random key")
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	return []auth.Key{{ID: "random", Secret: secret}}, nil
+}
+
+// Loads language packs and disables the ones without a words list, so the
+// server starts without dictionaries that were not downloaded
+func loadLanguagePacks(config *cfg.Config, logger *slog.Logger) (lang.Packs, error) {
+	packs, err := lang.LoadPacks(config.Langs.Dir)
+	if err != nil {
+		return nil, err
+	}
+	for code, pack := range packs {
+		if _, err := os.Stat(pack.WordsFile); err != nil {
+			logger.Warn("language disabled", "language", code, logging.KeyErr, err)
+			delete(packs, code)
+		}
+	}
+	if _, err := packs.Get(config.Game.Language); err != nil {
+		return nil, fmt.Errorf("default language: %w", err)
+	}
+	return packs, nil
+}
+
+// Appends notifications to the configured file, or writes them to standard
+// output when there is none
+func openNotifier(config cfg.NotifyConfig) (notify.Notifier, func() error, error) {
+	if config.File == "" {
+		return notify.NewLogNotifier(os.Stdout), func() error { return nil }, nil
+	}
+	fl, err := os.OpenFile(config.File, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, nil, err
+	}
+	return notify.NewLogNotifier(fl), fl.Close, nil
+}
+
+// Stops taking new requests and background work, then tells connected players
+// the server restarts and waits for plays in progress. The repository is
+// closed by main after that, also when 'ctx' runs out first.
+func shutdown(
+	ctx context.Context,
+	servers []*http.Server,
+	gameController ctrl.GameController,
+	hub handler.Hub,
+	gameClocks handler.GameClocks,
+	correspondenceSweeper handler.CorrespondenceSweeper,
+	janitor janitor.Janitor,
+	logger *slog.Logger,
+) {
+	// Plays posted over HTTP finish before Shutdown returns
+	for _, server := range servers {
+		if err := server.Shutdown(ctx); err != nil {
+			logger.Error("http shutdown", "addr", server.Addr, logging.KeyErr, err)
+		}
+	}
+	gameClocks.StopAll()
+	correspondenceSweeper.Stop()
+	janitor.Stop()
+
+	// Connections are closed without the notice when it cannot be rendered,
+	// players still see the close frame
+	notice, err := gameController.BuildNotice("Server is restarting, the game will be back in a moment")
+	if err != nil {
+		logger.Error("restart notice", logging.KeyErr, err)
+		notice = nil
+	}
+	if err := hub.Shutdown(ctx, notice); err != nil {
+		logger.Error("websocket shutdown", logging.KeyErr, err)
+		return
+	}
+	logger.Info("connections closed")
+}
 
 func main() {
-	wordsController, err := ctrl.NewWordsController("words/words_alpha.txt")
+	config, err := cfg.Load(os.Args[1:], os.LookupEnv)
+	if errors.Is(err, flag.ErrHelp) {
+		fmt.Println("Usage of scrable3:")
+		cfg.Usage(os.Stdout)
+		return
+	}
 	if err != nil {
 		fmt.Println(err)
-		return
+		os.Exit(2)
 	}
 
-	os.Remove(databaseFile)
-	db, err := sql.Open("sqlite3", databaseFile)
+	logger, err := logging.New(os.Stderr, config.Log.Level, config.Log.Format)
 	if err != nil {
 		fmt.Println(err)
+		os.Exit(2)
+	}
+	slog.SetDefault(logger)
+
+	packs, err := loadLanguagePacks(config, logger)
+	if err != nil {
+		logger.Error("startup", logging.KeyErr, err)
 		return
 	}
 
-	repo, err := repo.NewSqlite3Connection(db)
+	if config.Database.Reset {
+		os.Remove(config.Database.File)
+	}
+	db, err := sql.Open("sqlite3", config.Database.File)
 	if err != nil {
-		fmt.Println(err)
+		logger.Error("startup", logging.KeyErr, err)
+		return
+	}
+
+	metrics := metrics.New()
+	repo, err := repo.NewSqlite3Connection(db, logger, metrics)
+	if err != nil {
+		logger.Error("startup", logging.KeyErr, err)
+		return
+	}
+	defer func() {
+		if err := repo.CloseConn(); err != nil {
+			logger.Error("database close", logging.KeyErr, err)
+			return
+		}
+		logger.Info("database closed")
+	}()
+
+	playerTokenKeys, err := loadPlayerTokenKeys(config.Auth, logger)
+	if err != nil {
+		logger.Error("startup", logging.KeyErr, err)
+		return
+	}
+	playerTokenSigner, err := auth.NewPlayerTokenSigner(
+		playerTokenKeys,
+		time.Duration(config.Auth.PlayerTokenTTL),
+	)
+	if err != nil {
+		logger.Error("startup", logging.KeyErr, err)
+		return
+	}
+	secureCookies := config.Auth.SecureCookies
+	playerCookies := handler.NewPlayerCookies(
+		playerTokenSigner,
+		time.Duration(config.Game.ForfeitAfter),
+		secureCookies,
+	)
+
+	originPolicy, err := auth.NewOriginPolicy(config.Auth.AllowedOrigins)
+	if err != nil {
+		logger.Error("startup", logging.KeyErr, err)
 		return
 	}
-	defer repo.CloseConn()
+	csrfProtection := handler.NewCSRFProtection(originPolicy, secureCookies)
 
 	mux := http.NewServeMux()
-	static := http.StripPrefix("/static/", http.FileServer(http.Dir("static")))
+	static := http.StripPrefix("/static/", http.FileServer(http.Dir(config.Server.StaticDir)))
 	mux.Handle("/static/", static)
-	styles := http.StripPrefix("/styles/", http.FileServer(http.Dir("styles")))
+	styles := http.StripPrefix("/styles/", http.FileServer(http.Dir(config.Server.StylesDir)))
 	mux.Handle("/styles/", styles)
-	scripts := http.StripPrefix("/scripts/", http.FileServer(http.Dir("scripts")))
+	scripts := http.StripPrefix("/scripts/", http.FileServer(http.Dir(config.Server.ScriptsDir)))
 	mux.Handle("/scripts/", scripts)
 
-	gameService := svc.NewGameService(repo)
+	gameService := svc.NewGameService(repo, config.Game, packs)
 	playerService := svc.NewPlayerService(repo)
 	fieldService := svc.NewFieldService(repo)
 	avCharService := svc.NewAvCharService(repo)
+	userService := svc.NewUserService(repo)
+	sessionService := svc.NewSessionService(repo)
+	gameWordService := svc.NewGameWordService(repo)
+	playService := svc.NewPlayService(repo)
+
+	notifier, closeNotifier, err := openNotifier(config.Notify)
+	if err != nil {
+		logger.Error("startup", logging.KeyErr, err)
+		return
+	}
+	defer closeNotifier()
+
+	wordsController, err := ctrl.NewWordsController(packs, gameWordService)
+	if err != nil {
+		logger.Error("startup", logging.KeyErr, err)
+		return
+	}
 
 	gameController := ctrl.NewGameController(
 		wordsController,
+		gameService,
 		playerService,
 		fieldService,
 		avCharService,
+		playService,
+		packs,
+		notifier,
+		logger,
+		metrics,
 	)
 
-	homeHandler := handler.NewHomeHandler()
-	gameHandler := handler.NewGameHandler(gameService, playerService, fieldService)
+	homeHandler := handler.NewHomeHandler(gameService, csrfProtection)
+	gameHandler := handler.NewGameHandler(
+		gameService,
+		playerService,
+		fieldService,
+		userService,
+		sessionService,
+		playerCookies,
+		csrfProtection,
+		secureCookies,
+		config.Server.PublicURL,
+	)
+	userHandler := handler.NewUserHandler(
+		userService,
+		sessionService,
+		secureCookies,
+	)
+	gamesHandler := handler.NewGamesHandler(
+		gameService,
+		playerService,
+		userService,
+		sessionService,
+		csrfProtection,
+	)
+	playerHandler := handler.NewPlayerHandler(
+		playerService,
+		playerCookies,
+		csrfProtection,
+	)
+	gameWordHandler := handler.NewGameWordHandler(
+		gameService,
+		playerService,
+		gameWordService,
+		playerCookies,
+		csrfProtection,
+	)
+	hub := handler.NewHub(logger)
+	gameClocks := handler.NewGameClocks(gameService, gameController, hub, logger)
 	websocketHandler := handler.NewWebsocketHandler(
 		gameService,
 		playerService,
 		gameController,
+		playerCookies,
+		originPolicy,
+		hub,
+		gameClocks,
+		metrics,
+	)
+	playHandler := handler.NewPlayHandler(
+		gameService,
+		playerService,
+		gameController,
+		playerCookies,
+		csrfProtection,
+		hub,
+	)
+	correspondenceSweeper := handler.NewCorrespondenceSweeper(
+		gameService,
+		gameController,
+		hub,
+		time.Duration(config.Game.ForfeitAfter),
+		logger,
+	)
+	debugHandler := handler.NewDebugHandler(gameService, fieldService)
+	snapshotHandler := handler.NewSnapshotHandler(gameService, playerService, fieldService)
+	healthHandler := handler.NewHealthHandler(db, gameService, wordsController)
+	adminHandler := handler.NewAdminHandler(
+		gameService,
+		playerService,
+		userService,
+		sessionService,
+		hub,
+		config.Auth.AdminLogins,
 	)
+	correspondenceSweeper.Start()
+	defer correspondenceSweeper.Stop()
+	janitor := janitor.New(
+		gameService,
+		hub,
+		gameClocks,
+		config.Cleanup,
+		logger,
+	)
+	janitor.Start()
+	defer janitor.Stop()
 
 	mux.Handle("/", homeHandler)
+	mux.Handle("/register", userHandler)
+	mux.Handle("/login", userHandler)
+	mux.Handle("/logout", userHandler)
+	mux.Handle("/games", gamesHandler)
 	mux.Handle("/game", gameHandler)
 	mux.Handle("/game/{gameUUID}", gameHandler)
+	mux.Handle("/game/{gameUUID}/player", playerHandler)
+	mux.Handle("/game/{gameUUID}/words", gameWordHandler)
+	mux.Handle("/game/{gameUUID}/play", playHandler)
+	mux.Handle("/game/{gameUUID}/debug.txt", debugHandler)
+	mux.Handle("/game/{gameUUID}/snapshot.svg", snapshotHandler)
+	mux.Handle("/game/{gameUUID}/snapshot.png", snapshotHandler)
 	mux.Handle("/ws/{gameUUID}", websocketHandler)
+	mux.Handle("/healthz", healthHandler)
+	mux.Handle("/readyz", healthHandler)
+	mux.Handle("/admin/status", adminHandler)
+
+	server := &http.Server{
+		Addr:    config.Server.Addr,
+		Handler: handler.NewRequestLogging(logger, mux),
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	fmt.Printf("Start server, port %v\n", port)
-	http.ListenAndServe(port, mux)
+	servers := []*http.Server{server}
+	if config.Server.MetricsAddr != "" {
+		metricsMux := http.NewServeMux()
+		metricsMux.Handle("/metrics", metrics)
+		servers = append(servers, &http.Server{
+			Addr:    config.Server.MetricsAddr,
+			Handler: metricsMux,
+		})
+	}
+
+	// Serving stops when either server fails, the other one and the games
+	// are shut down the same way as on a signal
+	served := make(chan error, len(servers))
+	for _, server := range servers {
+		go func() {
+			logger.Info("start server", "addr", server.Addr)
+			served <- server.ListenAndServe()
+		}()
+	}
+	select {
+	case err := <-served:
+		logger.Error("server stopped", logging.KeyErr, err)
+	case <-ctx.Done():
+	}
+	stop()
+
+	logger.Info("shutting down", "timeout", config.Server.ShutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(
+		context.Background(),
+		time.Duration(config.Server.ShutdownTimeout),
+	)
+	defer cancel()
+	shutdown(
+		shutdownCtx,
+		servers,
+		gameController,
+		hub,
+		gameClocks,
+		correspondenceSweeper,
+		janitor,
+		logger,
+	)
 }
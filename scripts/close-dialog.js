@@ -0,0 +1,7 @@
+// Pages without websocket close dialogs on their own, the server is not asked
+document.addEventListener("submit", (event) => {
+    if (event.target.id === "error-popup") {
+        event.preventDefault();
+        document.getElementById("error-dialog").replaceChildren();
+    }
+});
@@ -1 +1,5 @@
-const gridContainers = document.querySelectorAll('.grid-container');
\ No newline at end of file
+const gridContainers = document.querySelectorAll('.grid-container');
+const boardSize = parseInt(
+    getComputedStyle(document.documentElement).getPropertyValue('--board-size'),
+    10,
+);
\ No newline at end of file
@@ -43,7 +43,7 @@ document.addEventListener('DOMContentLoaded', () => {
         let targetFace = getActiveFace(gridContainers);
         if (isWithinOuterCube(e.clientX, e.clientY, targetFace) && targetFace) {
             let faceRect = targetFace.getBoundingClientRect();
-            let gridSize = Math.round(faceRect.width / 15);
+            let gridSize = Math.round(faceRect.width / boardSize);
 
             let relativeX = Math.round(e.clientX - faceRect.left);
             let relativeY = Math.round(e.clientY - faceRect.top);
@@ -60,7 +60,7 @@ document.addEventListener('DOMContentLoaded', () => {
             relativeX = Math.max(0, Math.min(relativeX, faceRect.width - activeSquare.offsetWidth));
             relativeY = Math.max(0, Math.min(relativeY, faceRect.height - activeSquare.offsetHeight));
 
-            // Obliczanie pozycji w siatce (0-14)
+            // Obliczanie pozycji w siatce (0 - boardSize-1)
             let gridX = Math.floor(relativeX / gridSize);
             let gridY = Math.floor(relativeY / gridSize);
             
@@ -2,6 +2,7 @@ function updateMakePlayHxVals(form) {
     const currentVals = {
         actionType: "makePlay",
         side: getNormalizedRotation(),
+        tilt: currentTilt,
         chars: getSquaresPositions(),
     }
     form.setAttribute('hx-vals', JSON.stringify(currentVals));
@@ -1,13 +1,43 @@
 let currentRotation = 0;
+// 90 shows the top of the cube, -90 the bottom
+let currentTilt = 0;
+
+function applyCubeTransform() {
+    document.querySelector('#outer-cube').style.transform =
+        `rotateX(${-currentTilt}deg) rotateY(${currentRotation}deg)`;
+}
+
+// Tilting turns the cube back to the north side first, so the top and the
+// bottom are always seen the same way
+function tilt(step) {
+    let nextTilt = Math.max(-90, Math.min(90, currentTilt + step));
+    if (nextTilt !== 0) {
+        currentRotation -= getNormalizedRotation() > 180
+            ? getNormalizedRotation() - 360
+            : getNormalizedRotation();
+    }
+    currentTilt = nextTilt;
+    applyCubeTransform();
+}
 
 document.getElementById('rotate-left').addEventListener('click', () => {
+    currentTilt = 0;
     currentRotation -= 90;
-    document.querySelector('#outer-cube').style.transform = `rotateY(${currentRotation}deg)`;
+    applyCubeTransform();
 });
 
 document.getElementById('rotate-right').addEventListener('click', () => {
+    currentTilt = 0;
     currentRotation += 90;
-    document.querySelector('#outer-cube').style.transform = `rotateY(${currentRotation}deg)`;
+    applyCubeTransform();
+});
+
+document.getElementById('tilt-up').addEventListener('click', () => {
+    tilt(90);
+});
+
+document.getElementById('tilt-down').addEventListener('click', () => {
+    tilt(-90);
 });
 
 function getNormalizedRotation() {
@@ -15,6 +45,11 @@ function getNormalizedRotation() {
 }
 
 function getActiveFace(gridContainers) {
+    switch(currentTilt) {
+        case 90:  return gridContainers[4]; // top
+        case -90: return gridContainers[5]; // bottom
+    }
+
     let normalizedRotation = getNormalizedRotation();
 
     switch(normalizedRotation) {
@@ -23,6 +23,14 @@ document.getElementById('rotate-right').addEventListener('click', () => {
     returnAllSquaresToDefaultPosition();
 })
 
+document.getElementById('tilt-up').addEventListener('click', () => {
+    returnAllSquaresToDefaultPosition();
+})
+
+document.getElementById('tilt-down').addEventListener('click', () => {
+    returnAllSquaresToDefaultPosition();
+})
+
 document.getElementById('test-functions').addEventListener('click', () => {
     returnAllSquaresToDefaultPosition();
 })